@@ -3,12 +3,16 @@ package ast
 import (
 	"bytes"
 	"fmt"
+	"github.com/goby-lang/goby/compiler/token"
+	"math/big"
 	"strings"
 )
 
 type IntegerLiteral struct {
 	*BaseNode
 	Value int
+	// BigValue is set instead of Value when the literal doesn't fit in an int
+	BigValue *big.Int
 }
 
 func (il *IntegerLiteral) expressionNode() {}
@@ -19,6 +23,19 @@ func (il *IntegerLiteral) String() string {
 	return il.Token.Literal
 }
 
+type FloatLiteral struct {
+	*BaseNode
+	Value float64
+}
+
+func (fl *FloatLiteral) expressionNode() {}
+func (fl *FloatLiteral) TokenLiteral() string {
+	return fl.Token.Literal
+}
+func (fl *FloatLiteral) String() string {
+	return fl.Token.Literal
+}
+
 type StringLiteral struct {
 	*BaseNode
 	Value string
@@ -37,6 +54,66 @@ func (sl *StringLiteral) String() string {
 	return out.String()
 }
 
+// InterpolatedString is a double-quoted string with `#{...}` like "foo#{bar}",
+// its parts are string literals and the interpolated expressions
+type InterpolatedString struct {
+	*BaseNode
+	Parts []Expression
+}
+
+func (is *InterpolatedString) expressionNode() {}
+func (is *InterpolatedString) TokenLiteral() string {
+	return is.Token.Literal
+}
+func (is *InterpolatedString) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("\"")
+
+	for _, part := range is.Parts {
+		if sl, ok := part.(*StringLiteral); ok {
+			out.WriteString(sl.Value)
+			continue
+		}
+
+		out.WriteString("#{")
+		out.WriteString(part.String())
+		out.WriteString("}")
+	}
+
+	out.WriteString("\"")
+	return out.String()
+}
+
+// SymbolLiteral is a symbol like `:foo`, its value doesn't contain the colon
+type SymbolLiteral struct {
+	*BaseNode
+	Value string
+}
+
+func (sl *SymbolLiteral) expressionNode() {}
+func (sl *SymbolLiteral) TokenLiteral() string {
+	return sl.Token.Literal
+}
+func (sl *SymbolLiteral) String() string {
+	return ":" + sl.Value
+}
+
+// RegexpLiteral is a regexp like `/foo/i` or `%r{foo}`
+type RegexpLiteral struct {
+	*BaseNode
+	Value   string
+	Options string
+}
+
+func (rl *RegexpLiteral) expressionNode() {}
+func (rl *RegexpLiteral) TokenLiteral() string {
+	return rl.Token.Literal
+}
+func (rl *RegexpLiteral) String() string {
+	return "/" + rl.Value + "/" + rl.Options
+}
+
 type ArrayExpression struct {
 	*BaseNode
 	Elements []Expression
@@ -90,9 +167,12 @@ func (pe *PairExpression) String() string {
 	return fmt.Sprintf("%s: %s", pe.Key.String(), pe.Value.String())
 }
 
+// HashExpression represents a hash literal, its keys and values are kept in the order they're written.
+// Keys written like `foo:` are string literals.
 type HashExpression struct {
 	*BaseNode
-	Data map[string]Expression
+	Keys   []Expression
+	Values []Expression
 }
 
 func (he *HashExpression) expressionNode() {}
@@ -103,8 +183,8 @@ func (he *HashExpression) String() string {
 	var out bytes.Buffer
 	var pairs []string
 
-	for key, value := range he.Data {
-		pairs = append(pairs, fmt.Sprintf("%s: %s", key, value.String()))
+	for i, key := range he.Keys {
+		pairs = append(pairs, fmt.Sprintf("%s => %s", key.String(), he.Values[i].String()))
 	}
 
 	out.WriteString("{ ")
@@ -220,6 +300,32 @@ func (n *NilExpression) String() string {
 	return "nil"
 }
 
+// TernaryExpression represents conditional expressions like `foo ? bar : baz`
+type TernaryExpression struct {
+	*BaseNode
+	Condition   Expression
+	Consequence Expression
+	Alternative Expression
+}
+
+func (te *TernaryExpression) expressionNode() {}
+func (te *TernaryExpression) TokenLiteral() string {
+	return te.Token.Literal
+}
+func (te *TernaryExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("(")
+	out.WriteString(te.Condition.String())
+	out.WriteString(" ? ")
+	out.WriteString(te.Consequence.String())
+	out.WriteString(" : ")
+	out.WriteString(te.Alternative.String())
+	out.WriteString(")")
+
+	return out.String()
+}
+
 type IfExpression struct {
 	*BaseNode
 	Conditionals []*ConditionalExpression
@@ -235,7 +341,11 @@ func (ie *IfExpression) String() string {
 
 	for i, c := range ie.Conditionals {
 		if i == 0 {
-			out.WriteString("if")
+			if c.IsUnless() {
+				out.WriteString("unless")
+			} else {
+				out.WriteString("if")
+			}
 			out.WriteString(" ")
 		} else {
 			out.WriteString("elsif")
@@ -270,6 +380,11 @@ func (ce *ConditionalExpression) TokenLiteral() string {
 	return ce.Token.Literal
 }
 
+// IsUnless returns true if the consequence runs when the condition is falsy, like `unless foo` or `bar unless foo`
+func (ce *ConditionalExpression) IsUnless() bool {
+	return ce.Token.Type == token.Unless
+}
+
 func (ce *ConditionalExpression) String() string {
 	var out bytes.Buffer
 
@@ -287,6 +402,10 @@ type CallExpression struct {
 	Arguments      []Expression
 	Block          *BlockStatement
 	BlockArguments []*Identifier
+	// BlockPass is the expression passed as block with `&`, like `foo(&blk)`
+	BlockPass Expression
+	// SafeNavigation means the call is like `foo&.bar`, which returns nil without calling the method when the receiver is nil
+	SafeNavigation bool
 }
 
 func (ce *CallExpression) expressionNode() {}
@@ -297,7 +416,13 @@ func (ce *CallExpression) String() string {
 	var out bytes.Buffer
 
 	out.WriteString(ce.Receiver.String())
-	out.WriteString(".")
+
+	if ce.SafeNavigation {
+		out.WriteString("&.")
+	} else {
+		out.WriteString(".")
+	}
+
 	out.WriteString(ce.Method)
 
 	var args = []string{}
@@ -305,6 +430,10 @@ func (ce *CallExpression) String() string {
 		args = append(args, arg.String())
 	}
 
+	if ce.BlockPass != nil {
+		args = append(args, "&"+ce.BlockPass.String())
+	}
+
 	out.WriteString("(")
 	out.WriteString(strings.Join(args, ", "))
 	out.WriteString(")")
@@ -330,6 +459,33 @@ func (ce *CallExpression) String() string {
 	return out.String()
 }
 
+// SuperExpression represents a `super` call, which calls the method of the same name in the superclass.
+// The method name and receiver of the embedded CallExpression are not used.
+type SuperExpression struct {
+	*CallExpression
+	// ImplicitArguments is true for bare `super`, which passes the current method's arguments
+	ImplicitArguments bool
+}
+
+func (se *SuperExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("super")
+
+	if !se.ImplicitArguments {
+		var args = []string{}
+		for _, arg := range se.Arguments {
+			args = append(args, arg.String())
+		}
+
+		out.WriteString("(")
+		out.WriteString(strings.Join(args, ", "))
+		out.WriteString(")")
+	}
+
+	return out.String()
+}
+
 type SelfExpression struct {
 	*BaseNode
 }
@@ -388,3 +544,84 @@ func (re *RangeExpression) String() string {
 
 	return out.String()
 }
+
+// BeginExpression represents `begin ... rescue ... else ... ensure ... end`, and also a method body with `rescue` or `ensure`
+type BeginExpression struct {
+	*BaseNode
+	Body    *BlockStatement
+	Rescues []*RescueClause
+	Else    *BlockStatement
+	Ensure  *BlockStatement
+}
+
+func (be *BeginExpression) expressionNode() {}
+
+// TokenLiteral returns `begin`
+func (be *BeginExpression) TokenLiteral() string {
+	return be.Token.Literal
+}
+func (be *BeginExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("begin\n")
+	out.WriteString(be.Body.String())
+
+	for _, r := range be.Rescues {
+		out.WriteString("\n")
+		out.WriteString(r.String())
+	}
+
+	if be.Else != nil {
+		out.WriteString("\nelse\n")
+		out.WriteString(be.Else.String())
+	}
+
+	if be.Ensure != nil {
+		out.WriteString("\nensure\n")
+		out.WriteString(be.Ensure.String())
+	}
+
+	out.WriteString("\nend")
+
+	return out.String()
+}
+
+// RescueClause represents a `rescue Foo, Bar => e` clause of a BeginExpression
+type RescueClause struct {
+	*BaseNode
+	Exceptions []Expression
+	Variable   *Identifier
+	Body       *BlockStatement
+}
+
+func (rc *RescueClause) expressionNode() {}
+
+// TokenLiteral returns `rescue`
+func (rc *RescueClause) TokenLiteral() string {
+	return rc.Token.Literal
+}
+func (rc *RescueClause) String() string {
+	var out bytes.Buffer
+	var exceptions []string
+
+	for _, e := range rc.Exceptions {
+		exceptions = append(exceptions, e.String())
+	}
+
+	out.WriteString("rescue")
+
+	if len(exceptions) > 0 {
+		out.WriteString(" ")
+		out.WriteString(strings.Join(exceptions, ", "))
+	}
+
+	if rc.Variable != nil {
+		out.WriteString(" => ")
+		out.WriteString(rc.Variable.String())
+	}
+
+	out.WriteString("\n")
+	out.WriteString(rc.Body.String())
+
+	return out.String()
+}
@@ -0,0 +1,227 @@
+package ast
+
+import (
+	"bytes"
+	"strings"
+)
+
+/*
+	Patterns are the expressions after `in` of a CaseInExpression.
+	Besides the pattern types below, an Identifier is a pattern that binds the matched value to the local variable (`_` matches anything without binding),
+	and any other expression is a value pattern that matches objects with `===`.
+*/
+
+// CaseInExpression represents pattern matching like
+//
+// ```ruby
+// case foo
+// in [a, *rest] if a > 0
+//   bar
+// else
+//   baz
+// end
+// ```
+type CaseInExpression struct {
+	*BaseNode
+	Subject     Expression
+	Clauses     []*InClause
+	Alternative *BlockStatement
+}
+
+func (ce *CaseInExpression) expressionNode() {}
+
+// TokenLiteral returns `case`
+func (ce *CaseInExpression) TokenLiteral() string {
+	return ce.Token.Literal
+}
+func (ce *CaseInExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("case ")
+	out.WriteString(ce.Subject.String())
+
+	for _, c := range ce.Clauses {
+		out.WriteString("\n")
+		out.WriteString(c.String())
+	}
+
+	if ce.Alternative != nil {
+		out.WriteString("\nelse\n")
+		out.WriteString(ce.Alternative.String())
+	}
+
+	out.WriteString("\nend")
+
+	return out.String()
+}
+
+// InClause represents an `in pattern if guard` clause of a CaseInExpression
+type InClause struct {
+	*BaseNode
+	Pattern Expression
+	// Guard is the condition after the pattern, it's nil if the clause doesn't have one
+	Guard Expression
+	// UnlessGuard means the guard is written like `in pattern unless guard`
+	UnlessGuard bool
+	Consequence *BlockStatement
+}
+
+func (ic *InClause) expressionNode() {}
+
+// TokenLiteral returns `in`
+func (ic *InClause) TokenLiteral() string {
+	return ic.Token.Literal
+}
+func (ic *InClause) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("in ")
+	out.WriteString(ic.Pattern.String())
+
+	if ic.Guard != nil {
+		if ic.UnlessGuard {
+			out.WriteString(" unless ")
+		} else {
+			out.WriteString(" if ")
+		}
+
+		out.WriteString(ic.Guard.String())
+	}
+
+	out.WriteString("\n")
+	out.WriteString(ic.Consequence.String())
+
+	return out.String()
+}
+
+// ArrayPattern represents patterns like `[a, *rest, 1]`, it matches arrays with the same length unless it has a SplatPattern
+type ArrayPattern struct {
+	*BaseNode
+	Elements []Expression
+}
+
+func (ap *ArrayPattern) expressionNode() {}
+func (ap *ArrayPattern) TokenLiteral() string {
+	return ap.Token.Literal
+}
+func (ap *ArrayPattern) String() string {
+	var elements []string
+
+	for _, e := range ap.Elements {
+		elements = append(elements, e.String())
+	}
+
+	return "[" + strings.Join(elements, ", ") + "]"
+}
+
+// SplatIndex returns the position of the pattern's SplatPattern, or -1 if it doesn't have one
+func (ap *ArrayPattern) SplatIndex() int {
+	for i, e := range ap.Elements {
+		if _, ok := e.(*SplatPattern); ok {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// SplatPattern represents the `*rest` in an ArrayPattern, Variable is nil for the anonymous `*`
+type SplatPattern struct {
+	*BaseNode
+	Variable *Identifier
+}
+
+func (sp *SplatPattern) expressionNode() {}
+func (sp *SplatPattern) TokenLiteral() string {
+	return sp.Token.Literal
+}
+func (sp *SplatPattern) String() string {
+	if sp.Variable == nil {
+		return "*"
+	}
+
+	return "*" + sp.Variable.String()
+}
+
+// HashPattern represents patterns like `{status: "ok", data:}`, it matches hashes that have all the keys.
+// Other keys are allowed unless the pattern ends with `**nil`, and they can be collected with `**rest`.
+type HashPattern struct {
+	*BaseNode
+	Keys   []string
+	Values []Expression
+	// Rest is the variable of `**rest`
+	Rest *Identifier
+	// NoRest means the pattern ends with `**nil`
+	NoRest bool
+}
+
+func (hp *HashPattern) expressionNode() {}
+func (hp *HashPattern) TokenLiteral() string {
+	return hp.Token.Literal
+}
+func (hp *HashPattern) String() string {
+	var pairs []string
+
+	for i, key := range hp.Keys {
+		pairs = append(pairs, key+": "+hp.Values[i].String())
+	}
+
+	if hp.Rest != nil {
+		pairs = append(pairs, "**"+hp.Rest.String())
+	}
+
+	if hp.NoRest {
+		pairs = append(pairs, "**nil")
+	}
+
+	return "{" + strings.Join(pairs, ", ") + "}"
+}
+
+// AlternativePattern represents patterns like `1 | 2 | [_]`, it matches if any of the alternatives matches
+type AlternativePattern struct {
+	*BaseNode
+	Alternatives []Expression
+}
+
+func (ap *AlternativePattern) expressionNode() {}
+func (ap *AlternativePattern) TokenLiteral() string {
+	return ap.Token.Literal
+}
+func (ap *AlternativePattern) String() string {
+	var alternatives []string
+
+	for _, a := range ap.Alternatives {
+		alternatives = append(alternatives, a.String())
+	}
+
+	return "(" + strings.Join(alternatives, " | ") + ")"
+}
+
+// CapturePattern represents patterns like `[_, _] => pair`, which binds the matched value to the variable
+type CapturePattern struct {
+	*BaseNode
+	Pattern  Expression
+	Variable *Identifier
+}
+
+func (cp *CapturePattern) expressionNode() {}
+func (cp *CapturePattern) TokenLiteral() string {
+	return cp.Token.Literal
+}
+func (cp *CapturePattern) String() string {
+	return cp.Pattern.String() + " => " + cp.Variable.String()
+}
+
+// PinPattern represents patterns like `^foo`, which matches the variable's current value instead of binding it
+type PinPattern struct {
+	*BaseNode
+	Value Expression
+}
+
+func (pp *PinPattern) expressionNode() {}
+func (pp *PinPattern) TokenLiteral() string {
+	return pp.Token.Literal
+}
+func (pp *PinPattern) String() string {
+	return "^" + pp.Value.String()
+}
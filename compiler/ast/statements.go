@@ -2,6 +2,7 @@ package ast
 
 import (
 	"bytes"
+	"github.com/goby-lang/goby/compiler/token"
 )
 
 type ClassStatement struct {
@@ -28,6 +29,31 @@ func (cs *ClassStatement) String() string {
 	return out.String()
 }
 
+// SingletonClassStatement represents `class << self ... end`, whose body is evaluated in the object's singleton class
+type SingletonClassStatement struct {
+	*BaseNode
+	Object Expression
+	Body   *BlockStatement
+}
+
+func (ss *SingletonClassStatement) statementNode() {}
+
+// TokenLiteral returns `class`
+func (ss *SingletonClassStatement) TokenLiteral() string {
+	return ss.Token.Literal
+}
+func (ss *SingletonClassStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("class << ")
+	out.WriteString(ss.Object.String())
+	out.WriteString(" {\n")
+	out.WriteString(ss.Body.String())
+	out.WriteString("\n}")
+
+	return out.String()
+}
+
 // ModuleStatement represents module node in AST
 type ModuleStatement struct {
 	*BaseNode
@@ -158,10 +184,28 @@ func (bs *BreakStatement) String() string {
 	return bs.TokenLiteral()
 }
 
+// RetryStatement represents "retry" keyword
+type RetryStatement struct {
+	*BaseNode
+}
+
+func (rs *RetryStatement) statementNode() {}
+
+// TokenLiteral returns token's literal
+func (rs *RetryStatement) TokenLiteral() string {
+	return rs.Token.Literal
+}
+func (rs *RetryStatement) String() string {
+	return rs.TokenLiteral()
+}
+
+// WhileStatement represents `while` and `until` loops, including modifiers like `foo while bar`.
+// A post-condition loop like `begin ... end while foo` runs its body once before checking the condition.
 type WhileStatement struct {
 	*BaseNode
-	Condition Expression
-	Body      *BlockStatement
+	Condition     Expression
+	Body          *BlockStatement
+	PostCondition bool
 }
 
 func (ws *WhileStatement) statementNode() {}
@@ -171,7 +215,18 @@ func (ws *WhileStatement) TokenLiteral() string {
 func (ws *WhileStatement) String() string {
 	var out bytes.Buffer
 
-	out.WriteString("while ")
+	if ws.PostCondition {
+		out.WriteString(ws.Body.String())
+		out.WriteString(" ")
+		out.WriteString(ws.TokenLiteral())
+		out.WriteString(" ")
+		out.WriteString(ws.Condition.String())
+
+		return out.String()
+	}
+
+	out.WriteString(ws.TokenLiteral())
+	out.WriteString(" ")
 	out.WriteString(ws.Condition.String())
 	out.WriteString(" do\n")
 	out.WriteString(ws.Body.String())
@@ -180,6 +235,11 @@ func (ws *WhileStatement) String() string {
 	return out.String()
 }
 
+// IsUntil returns true if the loop runs while the condition is falsy, like `until foo` or `bar until foo`
+func (ws *WhileStatement) IsUntil() bool {
+	return ws.Token.Type == token.Until
+}
+
 type BlockStatement struct {
 	*BaseNode
 	Statements []Statement
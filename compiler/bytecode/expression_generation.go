@@ -12,10 +12,26 @@ func (g *Generator) compileExpression(is *InstructionSet, exp ast.Expression, sc
 		is.define(GetConstant, sourceLine, exp.Value, fmt.Sprint(exp.IsNamespace))
 	case *ast.InstanceVariable:
 		is.define(GetInstanceVariable, sourceLine, exp.Value)
+	case *ast.ClassVariable:
+		is.define(GetClassVariable, sourceLine, exp.Value)
+	case *ast.GlobalVariable:
+		is.define(GetGlobalVariable, sourceLine, exp.Value)
 	case *ast.IntegerLiteral:
-		is.define(PutObject, sourceLine, fmt.Sprint(exp.Value))
+		if exp.BigValue != nil {
+			is.define(PutObject, sourceLine, exp.BigValue.String())
+		} else {
+			is.define(PutObject, sourceLine, fmt.Sprint(exp.Value))
+		}
+	case *ast.FloatLiteral:
+		is.define(PutFloat, sourceLine, fmt.Sprint(exp.Value))
 	case *ast.StringLiteral:
 		is.define(PutString, sourceLine, exp.Value)
+	case *ast.SymbolLiteral:
+		is.define(PutSymbol, sourceLine, exp.Value)
+	case *ast.RegexpLiteral:
+		is.define(PutRegexp, sourceLine, exp.Value, exp.Options)
+	case *ast.InterpolatedString:
+		g.compileInterpolatedString(is, exp, scope, table)
 	case *ast.BooleanExpression:
 		is.define(PutBoolean, sourceLine, fmt.Sprint(exp.Value))
 	case *ast.NilExpression:
@@ -30,11 +46,11 @@ func (g *Generator) compileExpression(is *InstructionSet, exp ast.Expression, sc
 		}
 		is.define(NewArray, sourceLine, len(exp.Elements))
 	case *ast.HashExpression:
-		for key, value := range exp.Data {
-			is.define(PutString, sourceLine, key)
-			g.compileExpression(is, value, scope, table)
+		for i, key := range exp.Keys {
+			g.compileExpression(is, key, scope, table)
+			g.compileExpression(is, exp.Values[i], scope, table)
 		}
-		is.define(NewHash, sourceLine, len(exp.Data)*2)
+		is.define(NewHash, sourceLine, len(exp.Keys)*2)
 	case *ast.SelfExpression:
 		is.define(PutSelf, sourceLine)
 	case *ast.PairExpression:
@@ -49,10 +65,18 @@ func (g *Generator) compileExpression(is *InstructionSet, exp ast.Expression, sc
 		g.compileAssignExpression(is, exp, scope, table)
 	case *ast.IfExpression:
 		g.compileIfExpression(is, exp, scope, table)
+	case *ast.TernaryExpression:
+		g.compileTernaryExpression(is, exp, scope, table)
 	case *ast.YieldExpression:
 		g.compileYieldExpression(is, exp, scope, table)
 	case *ast.CallExpression:
 		g.compileCallExpression(is, exp, scope, table)
+	case *ast.SuperExpression:
+		g.compileSuperExpression(is, exp, scope, table)
+	case *ast.BeginExpression:
+		g.compileBeginExpression(is, exp, scope, table)
+	case *ast.CaseInExpression:
+		g.compileCaseInExpression(is, exp, scope, table)
 	}
 }
 
@@ -80,15 +104,54 @@ func (g *Generator) compileYieldExpression(is *InstructionSet, exp *ast.YieldExp
 }
 
 func (g *Generator) compileCallExpression(is *InstructionSet, exp *ast.CallExpression, scope *scope, table *localTable) {
+	// Compile receiver
+	g.compileExpression(is, exp.Receiver, scope, table)
+
+	// `foo&.bar(baz)` leaves the nil receiver as the result, without evaluating the arguments
+	nilAnchor := &anchor{}
+
+	if exp.SafeNavigation {
+		is.define(Dup, exp.Line())
+		is.define(BranchNil, exp.Line(), nilAnchor)
+	}
+
+	argSet, blockInfo := g.compileCallArguments(is, exp, scope, table)
+
+	i := is.define(Send, exp.Line(), exp.Method, len(exp.Arguments), blockInfo)
+	i.ArgSet = argSet
+	nilAnchor.line = is.count
+}
+
+func (g *Generator) compileSuperExpression(is *InstructionSet, exp *ast.SuperExpression, scope *scope, table *localTable) {
+	is.define(PutSelf, exp.Line())
+
+	call := exp.CallExpression
+
+	// Bare `super` passes the current method's parameters as they are
+	if def, ok := scope.self.(*ast.DefStatement); ok && exp.ImplicitArguments {
+		call = &ast.CallExpression{
+			BaseNode:       exp.BaseNode,
+			Arguments:      implicitSuperArguments(def),
+			Block:          exp.Block,
+			BlockArguments: exp.BlockArguments,
+			BlockPass:      exp.BlockPass,
+		}
+	}
+
+	argSet, blockInfo := g.compileCallArguments(is, call, scope, table)
+
+	i := is.define(InvokeSuper, exp.Line(), len(call.Arguments), blockInfo)
+	i.ArgSet = argSet
+}
+
+// compileCallArguments compiles a method call's arguments and block, and returns the arguments' types and the block info for the call
+func (g *Generator) compileCallArguments(is *InstructionSet, exp *ast.CallExpression, scope *scope, table *localTable) (*ArgSet, string) {
 	var blockInfo string
 	argSet := &ArgSet{
 		names: make([]string, len(exp.Arguments)),
 		types: make([]int, len(exp.Arguments)),
 	}
 
-	// Compile receiver
-	g.compileExpression(is, exp.Receiver, scope, table)
-
 	// Compile arguments
 	for i, arg := range exp.Arguments {
 		switch arg := arg.(type) {
@@ -128,8 +191,37 @@ func (g *Generator) compileCallExpression(is *InstructionSet, exp *ast.CallExpre
 		g.compileBlockArgExpression(blockIndex, exp, scope, newTable)
 	}
 
-	i := is.define(Send, exp.Line(), exp.Method, len(exp.Arguments), blockInfo)
-	i.ArgSet = argSet
+	// Compile block argument, like `&blk`
+	if exp.BlockPass != nil {
+		g.compileExpression(is, exp.BlockPass, scope, table)
+		blockInfo = BlockPassFlag
+	}
+
+	return argSet, blockInfo
+}
+
+// implicitSuperArguments returns the arguments that pass the method's parameters as they are, like `foo(a, *b, c: c)` for `def foo(a, *b, c:)`.
+// The block parameter is skipped because the method's block is always passed to `super`.
+func implicitSuperArguments(def *ast.DefStatement) []ast.Expression {
+	args := []ast.Expression{}
+
+	for _, param := range def.Parameters {
+		switch param := param.(type) {
+		case *ast.Identifier:
+			args = append(args, param)
+		case *ast.AssignExpression:
+			args = append(args, param.Variables[0])
+		case *ast.PrefixExpression:
+			if param.Operator == "*" {
+				args = append(args, param)
+			}
+		case *ast.PairExpression:
+			key := param.Key.(*ast.Identifier)
+			args = append(args, &ast.PairExpression{BaseNode: param.BaseNode, Key: key, Value: key})
+		}
+	}
+
+	return args
 }
 
 func (g *Generator) compileAssignExpression(is *InstructionSet, exp *ast.AssignExpression, scope *scope, table *localTable) {
@@ -154,6 +246,10 @@ func (g *Generator) compileAssignExpression(is *InstructionSet, exp *ast.AssignE
 				is.define(SetLocal, exp.Line(), depth, index)
 			case *ast.InstanceVariable:
 				is.define(SetInstanceVariable, exp.Line(), name.Value)
+			case *ast.ClassVariable:
+				is.define(SetClassVariable, exp.Line(), name.Value)
+			case *ast.GlobalVariable:
+				is.define(SetGlobalVariable, exp.Line(), name.Value)
 			case *ast.Constant:
 				is.define(SetConstant, exp.Line(), name.Value)
 			}
@@ -177,14 +273,25 @@ func (g *Generator) compileBlockArgExpression(index int, exp *ast.CallExpression
 	is := &InstructionSet{}
 	is.name = fmt.Sprint(index)
 	is.isType = Block
+	is.argTypes = &ArgSet{
+		names: make([]string, len(exp.BlockArguments)),
+		types: make([]int, len(exp.BlockArguments)),
+	}
 
 	for i := 0; i < len(exp.BlockArguments); i++ {
 		table.set(exp.BlockArguments[i].Value)
+		is.argTypes.setArg(i, exp.BlockArguments[i].Value, NormalArg)
 	}
 
+	// Block is executed in its own call frame, so it doesn't share rescue handlers with outside
+	rescueBlocks := scope.rescueBlocks
+	scope.rescueBlocks = nil
+
 	g.compileCodeBlock(is, exp.Block, scope, table)
 	g.endInstructions(is, exp.Line())
 	g.instructionSets = append(g.instructionSets, is)
+
+	scope.rescueBlocks = rescueBlocks
 }
 
 func (g *Generator) compileIfExpression(is *InstructionSet, exp *ast.IfExpression, scope *scope, table *localTable) {
@@ -194,7 +301,12 @@ func (g *Generator) compileIfExpression(is *InstructionSet, exp *ast.IfExpressio
 		anchorConditional := &anchor{}
 
 		g.compileExpression(is, c.Condition, scope, table)
-		is.define(BranchUnless, exp.Line(), anchorConditional)
+
+		if c.IsUnless() {
+			is.define(BranchIf, exp.Line(), anchorConditional)
+		} else {
+			is.define(BranchUnless, exp.Line(), anchorConditional)
+		}
 
 		if c.Consequence.IsEmpty() {
 			is.define(PutNull, exp.Line())
@@ -219,18 +331,170 @@ func (g *Generator) compileIfExpression(is *InstructionSet, exp *ast.IfExpressio
 	anchorLast.line = is.count
 }
 
+func (g *Generator) compileTernaryExpression(is *InstructionSet, exp *ast.TernaryExpression, scope *scope, table *localTable) {
+	alternativeAnchor := &anchor{}
+	lastAnchor := &anchor{}
+
+	g.compileExpression(is, exp.Condition, scope, table)
+	is.define(BranchUnless, exp.Line(), alternativeAnchor)
+
+	g.compileExpression(is, exp.Consequence, scope, table)
+	is.define(Jump, exp.Line(), lastAnchor)
+
+	alternativeAnchor.line = is.count
+	g.compileExpression(is, exp.Alternative, scope, table)
+
+	lastAnchor.line = is.count
+}
+
+/*
+	A begin expression like
+
+	```ruby
+	begin
+	  foo
+	rescue Bar => e
+	  baz
+	ensure
+	  qux
+	end
+	```
+
+	sets rescue handlers before its body and pops them afterwards.
+	When an error is raised between them, the vm resumes from the handler with the error on the stack top.
+	The rescue handler tries each clause and re-throws the error if none of them matches,
+	it's kept until the clause finishes so the error can be the cause of errors raised in the clause.
+	The ensure handler runs ensure body and then re-throws the error,
+	or keeps returning if it's run by a `return` in a block that leaves the method.
+*/
+func (g *Generator) compileBeginExpression(is *InstructionSet, exp *ast.BeginExpression, scope *scope, table *localTable) {
+	beginAnchor := &anchor{is.count}
+	rescueAnchor := &anchor{}
+	ensureAnchor := &anchor{}
+	doneAnchor := &anchor{}
+	endAnchor := &anchor{}
+
+	rb := &rescueBlock{ensure: exp.Ensure}
+	scope.rescueBlocks = append(scope.rescueBlocks, rb)
+
+	if exp.Ensure != nil {
+		is.define(SetEnsure, exp.Line(), ensureAnchor)
+		rb.handlers++
+	}
+
+	if len(exp.Rescues) > 0 {
+		is.define(SetRescue, exp.Line(), rescueAnchor)
+		rb.handlers++
+	}
+
+	g.compileValueBlock(is, exp.Body, exp.Line(), scope, table)
+
+	if len(exp.Rescues) > 0 {
+		is.define(PopRescue, exp.Line())
+		rb.handlers--
+	}
+
+	if exp.Else != nil {
+		is.define(Pop, exp.Line())
+		g.compileValueBlock(is, exp.Else, exp.Line(), scope, table)
+	}
+
+	is.define(Jump, exp.Line(), doneAnchor)
+
+	if len(exp.Rescues) > 0 {
+		rescueAnchor.line = is.count
+		rb.retry = beginAnchor
+		rb.handlers++
+
+		for _, r := range exp.Rescues {
+			nextAnchor := &anchor{}
+
+			if len(r.Exceptions) > 0 {
+				for _, e := range r.Exceptions {
+					g.compileExpression(is, e, scope, table)
+				}
+
+				is.define(MatchRescue, r.Line(), len(r.Exceptions))
+				is.define(BranchUnless, r.Line(), nextAnchor)
+			}
+
+			if r.Variable != nil {
+				index, depth := table.setLCL(r.Variable.Value, table.depth)
+				is.define(SetLocal, r.Line(), depth, index)
+			}
+
+			is.define(Pop, r.Line())
+			g.compileValueBlock(is, r.Body, r.Line(), scope, table)
+			is.define(PopRescue, r.Line())
+			is.define(Jump, r.Line(), doneAnchor)
+
+			nextAnchor.line = is.count
+		}
+
+		rb.retry = nil
+		rb.handlers--
+		is.define(PopRescue, exp.Line())
+		is.define(Throw, exp.Line())
+	}
+
+	doneAnchor.line = is.count
+	scope.rescueBlocks = scope.rescueBlocks[:len(scope.rescueBlocks)-1]
+
+	if exp.Ensure != nil {
+		is.define(PopRescue, exp.Line())
+		g.compileCodeBlock(is, exp.Ensure, scope, table)
+		is.define(Jump, exp.Line(), endAnchor)
+
+		ensureAnchor.line = is.count
+		g.compileCodeBlock(is, exp.Ensure, scope, table)
+		is.define(Throw, exp.Line())
+	}
+
+	endAnchor.line = is.count
+}
+
+// compileInterpolatedString compiles "foo#{bar}" as `"foo" + bar.to_s`
+func (g *Generator) compileInterpolatedString(is *InstructionSet, exp *ast.InterpolatedString, scope *scope, table *localTable) {
+	if len(exp.Parts) == 0 {
+		is.define(PutString, exp.Line(), "")
+		return
+	}
+
+	for i, part := range exp.Parts {
+		g.compileExpression(is, part, scope, table)
+
+		if _, ok := part.(*ast.StringLiteral); !ok {
+			is.define(Send, exp.Line(), "to_s", 0, "")
+		}
+
+		if i > 0 {
+			is.define(Send, exp.Line(), "+", 1, "")
+		}
+	}
+}
+
+// compileValueBlock compiles a block statement that should leave its value on the stack
+func (g *Generator) compileValueBlock(is *InstructionSet, block *ast.BlockStatement, line int, scope *scope, table *localTable) {
+	if block.IsEmpty() {
+		is.define(PutNull, line)
+		return
+	}
+
+	g.compileCodeBlock(is, block, scope, table)
+}
+
 func (g *Generator) compilePrefixExpression(is *InstructionSet, exp *ast.PrefixExpression, scope *scope, table *localTable) {
 	switch exp.Operator {
-	case "!":
+	case "!", "~":
 		g.compileExpression(is, exp.Right, scope, table)
 		is.define(Send, exp.Line(), exp.Operator, 0, "")
 	case "*":
 		g.compileExpression(is, exp.Right, scope, table)
 		is.define(SplatArray, exp.Line())
 	case "-":
-		is.define(PutObject, exp.Line(), 0)
+		// `-x` calls x's unary minus method `-@`
 		g.compileExpression(is, exp.Right, scope, table)
-		is.define(Send, exp.Line(), exp.Operator, 1, "")
+		is.define(Send, exp.Line(), "-@", 0, "")
 	}
 }
 
@@ -193,6 +193,144 @@ func TestIfExpressionWithElsifAndAlternativeCompilation(t *testing.T) {
 	compareBytecode(t, bytecode, expected)
 }
 
+func TestUnlessExpressionCompilation(t *testing.T) {
+	input := `
+	a = 10
+	unless a > 5
+	  a = 1
+	else
+	  a = 2
+	end
+
+	a += 1 unless a == 2
+	a
+`
+
+	expected := `
+<ProgramStart>
+0 putobject 10
+1 setlocal 0 0
+2 pop
+3 getlocal 0 0
+4 putobject 5
+5 send > 1
+6 branchif 10
+7 putobject 1
+8 setlocal 0 0
+9 jump 12
+10 putobject 2
+11 setlocal 0 0
+12 pop
+13 getlocal 0 0
+14 putobject 2
+15 send == 1
+16 branchif 22
+17 getlocal 0 0
+18 putobject 1
+19 send + 1
+20 setlocal 0 0
+21 jump 23
+22 putnil
+23 pop
+24 getlocal 0 0
+25 leave
+`
+
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
+
+func TestTernaryAndSafeNavigationCompilation(t *testing.T) {
+	input := `
+	a = 1
+	b = a > 0 ? a + 1 : nil
+	b&.to_s(a)
+`
+
+	expected := `
+<ProgramStart>
+0 putobject 1
+1 setlocal 0 0
+2 pop
+3 getlocal 0 0
+4 putobject 0
+5 send > 1
+6 branchunless 11
+7 getlocal 0 0
+8 putobject 1
+9 send + 1
+10 jump 12
+11 putnil
+12 setlocal 0 1
+13 pop
+14 getlocal 0 1
+15 dup
+16 branchnil 19
+17 getlocal 0 0
+18 send to_s 1
+19 leave
+`
+
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
+
+func TestCaseInExpressionCompilation(t *testing.T) {
+	input := `
+	case [1, 2]
+	in [a, 2] | [] then a
+	end
+`
+
+	expected := `
+<ProgramStart>
+0 putobject 1
+1 putobject 2
+2 newarray 2
+3 setlocal 0 0
+4 pop
+5 getlocal 0 0
+6 getconstant Array false
+7 send is_a? 1
+8 branchunless 25
+9 getlocal 0 0
+10 send length 0
+11 putobject 2
+12 send == 1
+13 branchunless 25
+14 getlocal 0 0
+15 expand_array 2
+16 setlocal 0 1
+17 pop
+18 setlocal 0 2
+19 pop
+20 putobject 2
+21 getlocal 0 2
+22 send === 1
+23 branchunless 25
+24 jump 34
+25 getlocal 0 0
+26 getconstant Array false
+27 send is_a? 1
+28 branchunless 36
+29 getlocal 0 0
+30 send length 0
+31 putobject 0
+32 send == 1
+33 branchunless 36
+34 getlocal 0 1
+35 jump 40
+36 putself
+37 getconstant NoMatchingPatternError false
+38 getlocal 0 0
+39 send raise 2
+40 leave
+`
+
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
+
 func TestMultipleVariableAssignmentCompilation(t *testing.T) {
 	input := `
 
@@ -262,6 +400,49 @@ func TestConstantCompilation(t *testing.T) {
 	compareBytecode(t, bytecode, expected)
 }
 
+func TestClassAndGlobalVariableCompilation(t *testing.T) {
+	input := `
+	@@foo = 10
+	$bar = @@foo
+	$bar + $0
+	`
+
+	expected := `
+<ProgramStart>
+0 putobject 10
+1 setclassvariable @@foo
+2 pop
+3 getclassvariable @@foo
+4 setglobalvariable $bar
+5 pop
+6 getglobalvariable $bar
+7 getglobalvariable $0
+8 send + 1
+9 leave
+`
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
+
+func TestUnaryMinusCompilation(t *testing.T) {
+	input := `
+	a = 10
+	-a
+	`
+
+	expected := `
+<ProgramStart>
+0 putobject 10
+1 setlocal 0 0
+2 pop
+3 getlocal 0 0
+4 send -@ 0
+5 leave
+`
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
+
 func TestBooleanCompilation(t *testing.T) {
 	input := `
 	a = true
@@ -287,6 +468,73 @@ func TestBooleanCompilation(t *testing.T) {
 	compareBytecode(t, bytecode, expected)
 }
 
+func TestInterpolatedStringCompilation(t *testing.T) {
+	input := `
+	a = 1
+	"#{a}b#{a + 1}"
+	`
+
+	expected := `
+<ProgramStart>
+0 putobject 1
+1 setlocal 0 0
+2 pop
+3 getlocal 0 0
+4 send to_s 0
+5 putstring b
+6 send + 1
+7 getlocal 0 0
+8 putobject 1
+9 send + 1
+10 send to_s 0
+11 send + 1
+12 leave
+`
+
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
+
+func TestNumericLiteralCompilation(t *testing.T) {
+	input := `
+	a = 0xff
+	a + 1.5
+	`
+
+	expected := `
+<ProgramStart>
+0 putobject 255
+1 setlocal 0 0
+2 pop
+3 getlocal 0 0
+4 putfloat 1.5
+5 send + 1
+6 leave
+`
+
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
+
+func TestSymbolCompilation(t *testing.T) {
+	input := `
+	foo.send(:bar, "baz")
+	`
+
+	expected := `
+<ProgramStart>
+0 putself
+1 send foo 0
+2 putsymbol bar
+3 putstring baz
+4 send send 2
+5 leave
+`
+
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
+
 func TestArrayCompilation(t *testing.T) {
 	input := `
 	a = [1, 2, "bar"]
@@ -321,15 +569,15 @@ func TestHashCompilation(t *testing.T) {
 	input := `
 	a = { foo: 1, bar: 5 }
 	b = {}
-	b["baz"] = a["bar"] - a["foo"]
-	b["baz"] + a["bar"]
+	b["baz"] = a[:bar] - a[:foo]
+	b["baz"] + a[:bar]
 `
 
 	expected1 := `
 <ProgramStart>
-0 putstring foo
+0 putsymbol foo
 1 putobject 1
-2 putstring bar
+2 putsymbol bar
 3 putobject 5
 4 newhash 4
 5 setlocal 0 0
@@ -340,10 +588,10 @@ func TestHashCompilation(t *testing.T) {
 10 getlocal 0 1
 11 putstring baz
 12 getlocal 0 0
-13 putstring bar
+13 putsymbol bar
 14 send [] 1
 15 getlocal 0 0
-16 putstring foo
+16 putsymbol foo
 17 send [] 1
 18 send - 1
 19 send []= 2
@@ -352,16 +600,16 @@ func TestHashCompilation(t *testing.T) {
 22 putstring baz
 23 send [] 1
 24 getlocal 0 0
-25 putstring bar
+25 putsymbol bar
 26 send [] 1
 27 send + 1
 28 leave
 `
 	expected2 := `
 <ProgramStart>
-0 putstring bar
+0 putsymbol bar
 1 putobject 5
-2 putstring foo
+2 putsymbol foo
 3 putobject 1
 4 newhash 4
 5 setlocal 0 0
@@ -372,10 +620,10 @@ func TestHashCompilation(t *testing.T) {
 10 getlocal 0 1
 11 putstring baz
 12 getlocal 0 0
-13 putstring bar
+13 putsymbol bar
 14 send [] 1
 15 getlocal 0 0
-16 putstring foo
+16 putsymbol foo
 17 send [] 1
 18 send - 1
 19 send []= 2
@@ -384,7 +632,7 @@ func TestHashCompilation(t *testing.T) {
 22 putstring baz
 23 send [] 1
 24 getlocal 0 0
-25 putstring bar
+25 putsymbol bar
 26 send [] 1
 27 send + 1
 28 leave
@@ -477,3 +725,135 @@ func TestUnusedExpressionRemoval(t *testing.T) {
 	bytecode := compileToBytecode(input)
 	compareBytecode(t, bytecode, expected)
 }
+
+func TestBeginExpressionCompilation(t *testing.T) {
+	input := `
+	begin
+	  a = 1
+	rescue TypeError => e
+	  retry
+	ensure
+	  a
+	end
+	`
+
+	expected := `
+<ProgramStart>
+0 set_ensure 22
+1 set_rescue 6
+2 putobject 1
+3 setlocal 0 0
+4 pop_rescue
+5 jump 18
+6 getconstant TypeError false
+7 match_rescue 1
+8 branchunless 16
+9 setlocal 0 1
+10 pop
+11 pop_rescue
+12 pop_rescue
+13 jump 0
+14 pop_rescue
+15 jump 18
+16 pop_rescue
+17 throw
+18 pop_rescue
+19 getlocal 0 0
+20 pop
+21 jump 25
+22 getlocal 0 0
+23 pop
+24 throw
+25 leave
+`
+
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
+
+func TestBlockPassCompilation(t *testing.T) {
+	input := `
+	def foo(x, &blk)
+	  bar(x, &blk)
+	end
+
+	foo(1, &:to_s)
+	`
+
+	expected := `
+<Def:foo>
+0 putself
+1 getlocal 0 0
+2 getlocal 0 1
+3 send bar 1 block:&
+4 leave
+<ProgramStart>
+0 putself
+1 putstring foo
+2 def_method 2
+3 putself
+4 putobject 1
+5 putsymbol to_s
+6 send foo 1 block:&
+7 leave
+`
+
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
+
+func TestSuperCompilation(t *testing.T) {
+	input := `
+	def foo(a, b = 1, d: 2, *c)
+	  super
+	  super(a)
+	end
+	`
+
+	expected := `
+<Def:foo>
+0 putobject 1
+1 setlocal 0 1 1
+2 putobject 2
+3 setlocal 0 2 1
+4 putself
+5 getlocal 0 0
+6 getlocal 0 1
+7 getlocal 0 2
+8 getlocal 0 3
+9 splat_array
+10 invokesuper 4
+11 pop
+12 putself
+13 getlocal 0 0
+14 invokesuper 1
+15 leave
+<ProgramStart>
+0 putself
+1 putstring foo
+2 def_method 4
+3 leave
+`
+
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
+
+func TestRegexpLiteralCompilation(t *testing.T) {
+	input := `
+	a = /foo/i
+	%r{/bar}m
+	`
+
+	expected := `
+<ProgramStart>
+0 putregexp foo i
+1 setlocal 0 0
+2 pop
+3 putregexp /bar m
+4 leave
+`
+
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
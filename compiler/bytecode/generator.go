@@ -13,6 +13,19 @@ type scope struct {
 	localTable *localTable
 	line       int
 	anchors    map[string]*anchor
+	// begin expressions that enclose the code being compiled, the innermost one is the last
+	rescueBlocks []*rescueBlock
+	// how many rescueBlocks enclose the innermost while loop
+	loopRescueLevel int
+}
+
+// rescueBlock keeps a begin expression's state while compiling its body and rescue clauses
+type rescueBlock struct {
+	// number of rescue handlers that are currently set
+	handlers int
+	ensure   *ast.BlockStatement
+	// where `retry` jumps to, only set while compiling rescue clauses
+	retry *anchor
 }
 
 func newScope(stmt ast.Statement) *scope {
@@ -24,7 +37,9 @@ type Generator struct {
 	REPL            bool
 	instructionSets []*InstructionSet
 	blockCounter    int
-	scope           *scope
+	// counts the hidden locals of pattern matching
+	patternCounter int
+	scope          *scope
 }
 
 // NewGenerator initializes new Generator with complete AST tree.
@@ -14,18 +14,32 @@ const (
 	Program   = "ProgramStart"
 )
 
+// SingletonClassBody is the name of the instruction sets compiled from `class << self` bodies
+const SingletonClassBody = "singleton class"
+
+// BlockPassFlag is the block info of a `send` whose block is passed as an argument like `foo(&blk)`,
+// the passed object is on the stack top
+const BlockPassFlag = "block:&"
+
 // instruction actions
 const (
 	GetLocal            = "getlocal"
 	GetConstant         = "getconstant"
 	GetInstanceVariable = "getinstancevariable"
+	GetClassVariable    = "getclassvariable"
+	GetGlobalVariable   = "getglobalvariable"
 	SetLocal            = "setlocal"
 	SetConstant         = "setconstant"
 	SetInstanceVariable = "setinstancevariable"
+	SetClassVariable    = "setclassvariable"
+	SetGlobalVariable   = "setglobalvariable"
 	PutBoolean          = "putboolean"
 	PutString           = "putstring"
+	PutSymbol           = "putsymbol"
+	PutRegexp           = "putregexp"
 	PutSelf             = "putself"
 	PutObject           = "putobject"
+	PutFloat            = "putfloat"
 	PutNull             = "putnil"
 	NewArray            = "newarray"
 	ExpandArray         = "expand_array"
@@ -34,15 +48,24 @@ const (
 	NewRange            = "newrange"
 	BranchUnless        = "branchunless"
 	BranchIf            = "branchif"
+	BranchNil           = "branchnil"
 	Jump                = "jump"
 	DefMethod           = "def_method"
 	DefSingletonMethod  = "def_singleton_method"
 	DefClass            = "def_class"
+	DefSingletonClass   = "def_singleton_class"
 	Send                = "send"
 	InvokeBlock         = "invokeblock"
+	InvokeSuper         = "invokesuper"
 	Pop                 = "pop"
 	Dup                 = "dup"
 	Leave               = "leave"
+	ReturnFromBlock     = "return_from_block"
+	SetRescue           = "set_rescue"
+	SetEnsure           = "set_ensure"
+	PopRescue           = "pop_rescue"
+	MatchRescue         = "match_rescue"
+	Throw               = "throw"
 )
 
 // Instruction represents compiled bytecode instruction
@@ -83,7 +106,7 @@ func (i *Instruction) compile() string {
 
 		// If the send action doesn't have a block (block info), we'll have a trailing space after join.
 		// So we need to remove that empty string element
-		if i.Action == Send && len(lastParam) == 0 {
+		if (i.Action == Send || i.Action == InvokeSuper) && len(lastParam) == 0 {
 			return fmt.Sprintf("%d %s %s\n", i.line, i.Action, strings.Join(i.Params[:len(i.Params)-1], " "))
 		}
 
@@ -0,0 +1,251 @@
+package bytecode
+
+import (
+	"fmt"
+
+	"github.com/goby-lang/goby/compiler/ast"
+)
+
+/*
+	A case expression with `in` clauses like
+
+	```ruby
+	case foo
+	in [a, *b] if a > 0
+	  bar
+	else
+	  baz
+	end
+	```
+
+	tries each clause's pattern in order, and jumps to the next clause as soon as any part of the pattern or the guard fails.
+	The subject and the values being destructured are kept in hidden locals, so the stack is the same wherever the match fails.
+	Without an else body, NoMatchingPatternError is raised if none of the clauses matches.
+*/
+func (g *Generator) compileCaseInExpression(is *InstructionSet, exp *ast.CaseInExpression, scope *scope, table *localTable) {
+	endAnchor := &anchor{}
+
+	g.compileExpression(is, exp.Subject, scope, table)
+	subject := g.setPatternLocal(is, exp.Line(), table)
+
+	for _, c := range exp.Clauses {
+		nextAnchor := &anchor{}
+
+		g.compilePattern(is, c.Pattern, subject, nextAnchor, scope, table)
+
+		if c.Guard != nil {
+			g.compileExpression(is, c.Guard, scope, table)
+
+			if c.UnlessGuard {
+				is.define(BranchIf, c.Line(), nextAnchor)
+			} else {
+				is.define(BranchUnless, c.Line(), nextAnchor)
+			}
+		}
+
+		g.compileValueBlock(is, c.Consequence, c.Line(), scope, table)
+		is.define(Jump, c.Line(), endAnchor)
+
+		nextAnchor.line = is.count
+	}
+
+	if exp.Alternative != nil {
+		g.compileValueBlock(is, exp.Alternative, exp.Line(), scope, table)
+	} else {
+		is.define(PutSelf, exp.Line())
+		is.define(GetConstant, exp.Line(), "NoMatchingPatternError", "false")
+		g.getPatternLocal(is, subject, exp.Line(), table)
+		is.define(Send, exp.Line(), "raise", 2, "")
+	}
+
+	endAnchor.line = is.count
+}
+
+// compilePattern matches the value of the hidden local against the pattern, and jumps to the fail anchor if it doesn't match
+func (g *Generator) compilePattern(is *InstructionSet, pattern ast.Expression, value string, fail *anchor, scope *scope, table *localTable) {
+	line := pattern.Line()
+
+	switch pattern := pattern.(type) {
+	case *ast.Identifier:
+		if pattern.Value != "_" {
+			g.getPatternLocal(is, value, line, table)
+			g.bindPatternVariable(is, pattern, line, table)
+		}
+	case *ast.CapturePattern:
+		g.compilePattern(is, pattern.Pattern, value, fail, scope, table)
+		g.getPatternLocal(is, value, line, table)
+		g.bindPatternVariable(is, pattern.Variable, line, table)
+	case *ast.PinPattern:
+		g.compileExpression(is, pattern.Value, scope, table)
+		g.getPatternLocal(is, value, line, table)
+		is.define(Send, line, "===", 1, "")
+		is.define(BranchUnless, line, fail)
+	case *ast.AlternativePattern:
+		matchedAnchor := &anchor{}
+
+		for i, alternative := range pattern.Alternatives {
+			if i == len(pattern.Alternatives)-1 {
+				g.compilePattern(is, alternative, value, fail, scope, table)
+				break
+			}
+
+			nextAnchor := &anchor{}
+			g.compilePattern(is, alternative, value, nextAnchor, scope, table)
+			is.define(Jump, line, matchedAnchor)
+			nextAnchor.line = is.count
+		}
+
+		matchedAnchor.line = is.count
+	case *ast.ArrayPattern:
+		g.compileArrayPattern(is, pattern, value, fail, scope, table)
+	case *ast.HashPattern:
+		g.compileHashPattern(is, pattern, value, fail, scope, table)
+	default:
+		g.compileExpression(is, pattern, scope, table)
+		g.getPatternLocal(is, value, line, table)
+		is.define(Send, line, "===", 1, "")
+		is.define(BranchUnless, line, fail)
+	}
+}
+
+// compileArrayPattern checks the value's class and length, then destructures it with `expand_array` like multiple assignments
+func (g *Generator) compileArrayPattern(is *InstructionSet, pattern *ast.ArrayPattern, value string, fail *anchor, scope *scope, table *localTable) {
+	line := pattern.Line()
+	splatIndex := pattern.SplatIndex()
+
+	g.compilePatternClassCheck(is, "Array", value, fail, line, table)
+
+	g.getPatternLocal(is, value, line, table)
+	is.define(Send, line, "length", 0, "")
+
+	if splatIndex < 0 {
+		is.define(PutObject, line, len(pattern.Elements))
+		is.define(Send, line, "==", 1, "")
+	} else {
+		is.define(PutObject, line, len(pattern.Elements)-1)
+		is.define(Send, line, ">=", 1, "")
+	}
+
+	is.define(BranchUnless, line, fail)
+
+	if len(pattern.Elements) == 0 {
+		return
+	}
+
+	g.getPatternLocal(is, value, line, table)
+
+	if splatIndex < 0 {
+		is.define(ExpandArray, line, len(pattern.Elements))
+	} else {
+		is.define(ExpandArray, line, len(pattern.Elements), splatIndex)
+	}
+
+	// Variables are bound right away, other patterns are matched after all the elements are popped
+	elements := make([]string, len(pattern.Elements))
+
+	for i, e := range pattern.Elements {
+		switch e := e.(type) {
+		case *ast.SplatPattern:
+			g.bindPatternVariable(is, e.Variable, line, table)
+		case *ast.Identifier:
+			g.bindPatternVariable(is, e, line, table)
+		default:
+			elements[i] = g.setPatternLocal(is, line, table)
+		}
+	}
+
+	for i, e := range pattern.Elements {
+		if elements[i] != "" {
+			g.compilePattern(is, e, elements[i], fail, scope, table)
+		}
+	}
+}
+
+// compileHashPattern checks the value's class and keys, then matches each key's value against its pattern
+func (g *Generator) compileHashPattern(is *InstructionSet, pattern *ast.HashPattern, value string, fail *anchor, scope *scope, table *localTable) {
+	line := pattern.Line()
+
+	g.compilePatternClassCheck(is, "Hash", value, fail, line, table)
+
+	if pattern.NoRest {
+		g.getPatternLocal(is, value, line, table)
+		is.define(Send, line, "length", 0, "")
+		is.define(PutObject, line, len(pattern.Keys))
+		is.define(Send, line, "==", 1, "")
+		is.define(BranchUnless, line, fail)
+	}
+
+	for _, key := range pattern.Keys {
+		g.getPatternLocal(is, value, line, table)
+		is.define(PutSymbol, line, key)
+		is.define(Send, line, "has_key?", 1, "")
+		is.define(BranchUnless, line, fail)
+	}
+
+	for i, key := range pattern.Keys {
+		g.getPatternLocal(is, value, line, table)
+		is.define(PutSymbol, line, key)
+		is.define(Send, line, "[]", 1, "")
+
+		if ident, ok := pattern.Values[i].(*ast.Identifier); ok {
+			g.bindPatternVariable(is, ident, line, table)
+			continue
+		}
+
+		g.compilePattern(is, pattern.Values[i], g.setPatternLocal(is, line, table), fail, scope, table)
+	}
+
+	// `**rest` is a copy of the hash without the pattern's keys
+	if pattern.Rest != nil {
+		g.getPatternLocal(is, value, line, table)
+		is.define(NewHash, line, 0)
+		is.define(Send, line, "merge", 1, "")
+
+		for _, key := range pattern.Keys {
+			is.define(Dup, line)
+			is.define(PutSymbol, line, key)
+			is.define(Send, line, "delete", 1, "")
+			is.define(Pop, line)
+		}
+
+		g.bindPatternVariable(is, pattern.Rest, line, table)
+	}
+}
+
+// compilePatternClassCheck jumps to the fail anchor unless the value is an instance of the class
+func (g *Generator) compilePatternClassCheck(is *InstructionSet, class string, value string, fail *anchor, line int, table *localTable) {
+	g.getPatternLocal(is, value, line, table)
+	is.define(GetConstant, line, class, "false")
+	is.define(Send, line, "is_a?", 1, "")
+	is.define(BranchUnless, line, fail)
+}
+
+// bindPatternVariable pops the stack top into the variable, the value is dropped if the variable is nil or `_`
+func (g *Generator) bindPatternVariable(is *InstructionSet, variable *ast.Identifier, line int, table *localTable) {
+	if variable == nil || variable.Value == "_" {
+		is.define(Pop, line)
+		return
+	}
+
+	index, depth := table.setLCL(variable.Value, table.depth)
+	is.define(SetLocal, line, depth, index)
+	is.define(Pop, line)
+}
+
+// setPatternLocal pops the stack top into a new hidden local and returns its name.
+// The names can't be written in Goby, so they never conflict with variables.
+func (g *Generator) setPatternLocal(is *InstructionSet, line int, table *localTable) string {
+	name := fmt.Sprintf("%%pattern:%d", g.patternCounter)
+	g.patternCounter++
+
+	index, depth := table.setLCL(name, table.depth)
+	is.define(SetLocal, line, depth, index)
+	is.define(Pop, line)
+
+	return name
+}
+
+func (g *Generator) getPatternLocal(is *InstructionSet, name string, line int, table *localTable) {
+	index, depth, _ := table.getLCL(name, table.depth)
+	is.define(GetLocal, line, depth, index)
+}
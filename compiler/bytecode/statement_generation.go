@@ -13,6 +13,7 @@ const (
 	SplatArg
 	RequiredKeywordArg
 	OptionalKeywordArg
+	BlockArg
 )
 
 func (g *Generator) compileStatements(stmts []ast.Statement, scope *scope, table *localTable) {
@@ -54,17 +55,28 @@ func (g *Generator) compileStatement(is *InstructionSet, statement ast.Statement
 		if stmt.SuperClass != nil {
 			is.define(Pop, statement.Line())
 		}
+	case *ast.SingletonClassStatement:
+		g.compileSingletonClassStmt(is, stmt, scope, table)
 	case *ast.ModuleStatement:
 		g.compileModuleStmt(is, stmt, scope)
 	case *ast.ReturnStatement:
 		g.compileExpression(is, stmt.ReturnValue, scope, table)
-		g.endInstructions(is, stmt.Line())
+		g.leaveRescueBlocks(is, stmt.Line(), 0, scope, table)
+
+		// `return` in a block returns from the method where the block is defined
+		if is.isType == Block {
+			is.define(ReturnFromBlock, stmt.Line())
+		} else {
+			g.endInstructions(is, stmt.Line())
+		}
 	case *ast.WhileStatement:
 		g.compileWhileStmt(is, stmt, scope, table)
 	case *ast.NextStatement:
-		g.compileNextStatement(is, stmt, scope)
+		g.compileNextStatement(is, stmt, scope, table)
 	case *ast.BreakStatement:
-		g.compileBreakStatement(is, stmt, scope)
+		g.compileBreakStatement(is, stmt, scope, table)
+	case *ast.RetryStatement:
+		g.compileRetryStatement(is, stmt, scope)
 	}
 }
 
@@ -72,32 +84,84 @@ func (g *Generator) compileWhileStmt(is *InstructionSet, stmt *ast.WhileStatemen
 	anchor1 := &anchor{}
 	breakAnchor := &anchor{}
 
-	is.define(Jump, stmt.Line(), anchor1)
+	// A post-condition loop runs its body before checking the condition
+	if !stmt.PostCondition {
+		is.define(Jump, stmt.Line(), anchor1)
+	}
 
 	anchor2 := &anchor{is.count}
 
 	scope.anchors["next"] = anchor1
 	scope.anchors["break"] = breakAnchor
 
+	loopRescueLevel := scope.loopRescueLevel
+	scope.loopRescueLevel = len(scope.rescueBlocks)
+
 	g.compileCodeBlock(is, stmt.Body, scope, table)
 
+	scope.loopRescueLevel = loopRescueLevel
+
 	anchor1.line = is.count
 
 	g.compileExpression(is, stmt.Condition, scope, table)
 
-	is.define(BranchIf, stmt.Line(), anchor2)
+	if stmt.IsUntil() {
+		is.define(BranchUnless, stmt.Line(), anchor2)
+	} else {
+		is.define(BranchIf, stmt.Line(), anchor2)
+	}
 
 	breakAnchor.line = is.count
 }
 
-func (g *Generator) compileNextStatement(is *InstructionSet, stmt ast.Statement, scope *scope) {
+func (g *Generator) compileNextStatement(is *InstructionSet, stmt ast.Statement, scope *scope, table *localTable) {
+	g.leaveRescueBlocks(is, stmt.Line(), scope.loopRescueLevel, scope, table)
 	is.define(Jump, stmt.Line(), scope.anchors["next"])
 }
 
-func (g *Generator) compileBreakStatement(is *InstructionSet, stmt ast.Statement, scope *scope) {
+func (g *Generator) compileBreakStatement(is *InstructionSet, stmt ast.Statement, scope *scope, table *localTable) {
+	g.leaveRescueBlocks(is, stmt.Line(), scope.loopRescueLevel, scope, table)
 	is.define(Jump, stmt.Line(), scope.anchors["break"])
 }
 
+// compileRetryStatement pops the handlers set inside the rescued begin expression and jumps back to its beginning
+func (g *Generator) compileRetryStatement(is *InstructionSet, stmt ast.Statement, scope *scope) {
+	for i := len(scope.rescueBlocks) - 1; i >= 0; i-- {
+		rb := scope.rescueBlocks[i]
+
+		for j := 0; j < rb.handlers; j++ {
+			is.define(PopRescue, stmt.Line())
+		}
+
+		if rb.retry != nil {
+			is.define(Jump, stmt.Line(), rb.retry)
+			return
+		}
+	}
+}
+
+// leaveRescueBlocks pops the handlers of begin expressions nested deeper than the given level and runs their ensure bodies,
+// it's for statements that jump out of begin expressions like `return`, `break` and `next`.
+func (g *Generator) leaveRescueBlocks(is *InstructionSet, sourceLine, level int, scope *scope, table *localTable) {
+	rescueBlocks := scope.rescueBlocks
+
+	for i := len(rescueBlocks) - 1; i >= level; i-- {
+		rb := rescueBlocks[i]
+
+		for j := 0; j < rb.handlers; j++ {
+			is.define(PopRescue, sourceLine)
+		}
+
+		if rb.ensure != nil {
+			// Jumping out of the ensure body shouldn't run it again
+			scope.rescueBlocks = rescueBlocks[:i]
+			g.compileCodeBlock(is, rb.ensure, scope, table)
+		}
+	}
+
+	scope.rescueBlocks = rescueBlocks
+}
+
 func (g *Generator) compileClassStmt(is *InstructionSet, stmt *ast.ClassStatement, scope *scope, table *localTable) {
 	is.define(PutSelf, stmt.Line())
 
@@ -122,6 +186,24 @@ func (g *Generator) compileClassStmt(is *InstructionSet, stmt *ast.ClassStatemen
 	g.instructionSets = append(g.instructionSets, newIS)
 }
 
+// compileSingletonClassStmt compiles the body like a class's, which is evaluated with the object's singleton class as self.
+// All the bodies share the same instruction set name, since they're executed in the order they're defined like classes with the same name.
+func (g *Generator) compileSingletonClassStmt(is *InstructionSet, stmt *ast.SingletonClassStatement, scope *scope, table *localTable) {
+	g.compileExpression(is, stmt.Object, scope, table)
+	is.define(DefSingletonClass, stmt.Line())
+	is.define(Pop, stmt.Line())
+
+	scope = newScope(stmt)
+
+	newIS := &InstructionSet{}
+	newIS.name = SingletonClassBody
+	newIS.isType = ClassDef
+
+	g.compileCodeBlock(newIS, stmt.Body, scope, scope.localTable)
+	newIS.define(Leave, stmt.Line())
+	g.instructionSets = append(g.instructionSets, newIS)
+}
+
 func (g *Generator) compileModuleStmt(is *InstructionSet, stmt *ast.ModuleStatement, scope *scope) {
 	is.define(PutSelf, stmt.Line())
 	is.define(DefClass, stmt.Line(), "module:"+stmt.Name.Value)
@@ -176,13 +258,21 @@ func (g *Generator) compileDefStmt(is *InstructionSet, stmt *ast.DefStatement, s
 
 			newIS.argTypes.setArg(i, varName.Value, OptionedArg)
 		case *ast.PrefixExpression:
-			if exp.Operator != "*" {
+			var argType int
+
+			switch exp.Operator {
+			case "*":
+				argType = SplatArg
+			case "&":
+				argType = BlockArg
+			default:
 				continue
 			}
+
 			ident := exp.Right.(*ast.Identifier)
 			scope.localTable.setLCL(ident.Value, scope.localTable.depth)
 
-			newIS.argTypes.setArg(i, ident.Value, SplatArg)
+			newIS.argTypes.setArg(i, ident.Value, argType)
 		case *ast.PairExpression:
 			key := exp.Key.(*ast.Identifier)
 			index, depth := scope.localTable.setLCL(key.Value, scope.localTable.depth)
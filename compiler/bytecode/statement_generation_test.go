@@ -143,6 +143,46 @@ Foo.bar
 	compareBytecode(t, bytecode, expected)
 }
 
+func TestSingletonClassCompilation(t *testing.T) {
+	input := `
+class Foo
+  class << self
+    def bar
+      @@count = $count
+    end
+  end
+end
+
+Foo.bar
+`
+	expected := `
+<Def:bar>
+0 getglobalvariable $count
+1 setclassvariable @@count
+2 leave
+<DefClass:singleton class>
+0 putself
+1 putstring bar
+2 def_method 0
+3 leave
+<DefClass:Foo>
+0 putself
+1 def_singleton_class
+2 pop
+3 leave
+<ProgramStart>
+0 putself
+1 def_class class:Foo
+2 pop
+3 getconstant Foo false
+4 send bar 0
+5 leave
+`
+
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
+
 func TestClassCompilation(t *testing.T) {
 	input := `
 class Bar
@@ -352,6 +392,53 @@ func TestWhileStatementWithMethodCallInCondition(t *testing.T) {
 	compareBytecode(t, bytecode, expected)
 }
 
+func TestUntilAndPostConditionWhileStatementCompilation(t *testing.T) {
+	input := `
+	i = 10
+
+	until i == 0 do
+	  i -= 1
+	end
+
+	begin
+	  i += 1
+	end while i < 5
+
+	i
+`
+	expected := `
+<ProgramStart>
+0 putobject 10
+1 setlocal 0 0
+2 pop
+3 jump 9
+4 getlocal 0 0
+5 putobject 1
+6 send - 1
+7 setlocal 0 0
+8 pop
+9 getlocal 0 0
+10 putobject 0
+11 send == 1
+12 branchunless 4
+13 getlocal 0 0
+14 putobject 1
+15 send + 1
+16 setlocal 0 0
+17 jump 18
+18 pop
+19 getlocal 0 0
+20 putobject 5
+21 send < 1
+22 branchif 13
+23 getlocal 0 0
+24 leave
+`
+
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
+
 func TestNextStatementCompilation(t *testing.T) {
 	input := `
 	x = 0
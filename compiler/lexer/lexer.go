@@ -3,6 +3,7 @@ package lexer
 import (
 	"github.com/goby-lang/goby/compiler/token"
 	"github.com/looplab/fsm"
+	"strings"
 )
 
 // Lexer is used for tokenizing programs
@@ -13,11 +14,35 @@ type Lexer struct {
 	ch           rune
 	line         int
 	FSM          *fsm.FSM
+	// Counts unclosed braces in each string interpolation being lexed, the innermost one is the last
+	interpolations []int
+	// The type of the last token except comments, which tells if a slash starts a regexp or means division
+	lastTokenType token.Type
+	// The number of heredoc body lines removed from the input, which are counted at the end of the current line
+	skippedLines int
+	// Names of the local variables seen so far, a slash after one of them is always a division like `a /b`
+	locals map[string]bool
+	// The last identifier that can be a local variable, it's empty if the last token isn't one
+	lastLocal string
+	// Tells if the parameters of a method, block or lambda are being lexed, and the line of the last `def`
+	params  paramState
+	defLine int
+	// Counts unclosed parentheses in the parameters being lexed
+	paramParens int
 }
 
+type paramState int
+
+const (
+	noParams paramState = iota
+	defName
+	defParams
+	blockParams
+)
+
 // New initializes a new lexer with input string
 func New(input string) *Lexer {
-	l := &Lexer{input: []rune(input)}
+	l := &Lexer{input: []rune(input), locals: map[string]bool{}}
 	l.readChar()
 	l.FSM = fsm.NewFSM(
 		"initial",
@@ -39,19 +64,120 @@ func New(input string) *Lexer {
 
 // NextToken makes lexer tokenize next character(s)
 func (l *Lexer) NextToken() token.Token {
+	tok := l.nextToken()
+
+	if tok.Type != token.Comment {
+		l.trackLocals(tok)
+		l.lastTokenType = tok.Type
+	}
+
+	return tok
+}
+
+// trackLocals records the names of local variables, which are assigned like `a = 1` or `a ||= 1`,
+// or declared as method, block and lambda parameters.
+// Like in Ruby, `a /b` is a division once `a` is a local variable and a regexp argument otherwise.
+// Scopes aren't tracked, so a name stays a local variable until the end of the input.
+func (l *Lexer) trackLocals(tok token.Token) {
+	// The method name like `foo=` or `self.bar` ends at the parameters or at the end of the line
+	if l.params == defName && (tok.Line != l.defLine || tok.Type == token.Semicolon) {
+		l.params = noParams
+	}
+
+	switch l.params {
+	case defName:
+		if tok.Type == token.LParen {
+			l.params = defParams
+			l.paramParens = 1
+		}
+	case defParams:
+		switch tok.Type {
+		case token.LParen:
+			l.paramParens++
+		case token.RParen:
+			l.paramParens--
+
+			if l.paramParens == 0 {
+				l.params = noParams
+			}
+		case token.Ident:
+			l.trackParameter(tok)
+		}
+	case blockParams:
+		switch tok.Type {
+		case token.Bar:
+			l.params = noParams
+		case token.Ident:
+			l.trackParameter(tok)
+		}
+	default:
+		switch {
+		case tok.Type == token.Def:
+			l.params = defName
+			l.defLine = tok.Line
+		case tok.Type == token.Bar && (l.lastTokenType == token.Do || l.lastTokenType == token.LBrace):
+			l.params = blockParams
+		case tok.Type == token.LParen && l.lastTokenType == token.Arrow:
+			l.params = defParams
+			l.paramParens = 1
+		case (tok.Type == token.Assign || tok.Type == token.OrEq) && l.lastLocal != "":
+			l.locals[l.lastLocal] = true
+		}
+	}
+
+	l.lastLocal = ""
+
+	// `foo.bar` is always a method call
+	if tok.Type == token.Ident && l.lastTokenType != token.Dot && l.lastTokenType != token.SafeNavigation {
+		l.lastLocal = tok.Literal
+	}
+}
+
+// trackParameter records the parameter names, which follow the opening of the parameters, commas, splats and `&`.
+// Others like `b` in `def foo(a = b)` are values.
+func (l *Lexer) trackParameter(tok token.Token) {
+	switch l.lastTokenType {
+	case token.LParen, token.Bar, token.Comma, token.Asterisk, token.Pow, token.Amp:
+		l.locals[tok.Literal] = true
+	}
+}
+
+func (l *Lexer) nextToken() token.Token {
 
 	var tok token.Token
 	l.resetNosymbol()
 
+	position, line := l.position, l.line
 	l.skipWhitespace()
+	spaced, newLine := l.position > position, l.line > line
+
+	// Operator method names like `def ==(other)`, `def []=(key, value)`, `def -@` or `a.+(b)` are identifiers
+	if l.FSM.Is("method") {
+		if name := l.readOperatorMethodName(); name != "" {
+			l.FSM.Event("initial")
+			return token.Token{Type: token.Ident, Literal: name, Line: l.line}
+		}
+	}
+
 	switch l.ch {
 	case '"', '\'':
-		tok.Literal = l.readString(l.ch)
+		literal, interpolated := l.readString(l.ch)
+		tok.Literal = literal
 		tok.Type = token.String
 		tok.Line = l.line
+
+		if interpolated {
+			tok.Type = token.InterpolationStart
+			l.interpolations = append(l.interpolations, 0)
+		}
+
 		return tok
 	case '=':
-		if l.peekChar() == '=' {
+		if l.peekChar() == '=' && l.peekCharAt(1) == '=' {
+			l.readChar()
+			l.readChar()
+			tok = token.Token{Type: token.CaseEq, Literal: "===", Line: l.line}
+		} else if l.peekChar() == '=' {
 			currentByte := l.ch
 			l.readChar()
 			tok = token.Token{Type: token.Eq, Literal: string(currentByte) + string(l.ch), Line: l.line}
@@ -59,6 +185,10 @@ func (l *Lexer) NextToken() token.Token {
 			currentByte := l.ch
 			l.readChar()
 			tok = token.Token{Type: token.Match, Literal: string(currentByte) + string(l.ch), Line: l.line}
+		} else if l.peekChar() == '>' {
+			currentByte := l.ch
+			l.readChar()
+			tok = token.Token{Type: token.HashRocket, Literal: string(currentByte) + string(l.ch), Line: l.line}
 		} else {
 			tok = newToken(token.Assign, l.ch, l.line)
 		}
@@ -77,6 +207,13 @@ func (l *Lexer) NextToken() token.Token {
 			l.readChar()
 			l.readChar()
 			return tok
+		} else if l.peekChar() == '>' {
+			tok.Literal = "->"
+			tok.Line = l.line
+			tok.Type = token.Arrow
+			l.readChar()
+			l.readChar()
+			return tok
 		}
 		tok = newToken(token.Minus, l.ch, l.line)
 	case '!':
@@ -88,6 +225,14 @@ func (l *Lexer) NextToken() token.Token {
 			tok = newToken(token.Bang, l.ch, l.line)
 		}
 	case '/':
+		// A pattern can't start with a quantifier, so `/*` or `/+` is always a division
+		if next := l.peekChar(); next != '*' && next != '+' && next != '?' && l.valueExpected(spaced, newLine) {
+			tok.Literal = l.readRegexp('/', '/')
+			tok.Type = token.Regexp
+			tok.Line = l.line
+			return tok
+		}
+
 		tok = newToken(token.Slash, l.ch, l.line)
 	case '*':
 		if l.peekChar() == '*' {
@@ -97,7 +242,12 @@ func (l *Lexer) NextToken() token.Token {
 			tok = newToken(token.Asterisk, l.ch, l.line)
 		}
 	case '<':
-		if l.peekChar() == '=' {
+		if l.peekChar() == '<' && (l.peekCharAt(1) == '~' || l.peekCharAt(1) == '-') && isHeredocIdentifierStart(l.peekCharAt(2)) && l.valueExpected(spaced, newLine) {
+			return l.readHeredoc()
+		} else if l.peekChar() == '<' {
+			l.readChar()
+			tok = token.Token{Type: token.LShift, Literal: "<<", Line: l.line}
+		} else if l.peekChar() == '=' {
 			l.readChar()
 			if l.peekChar() == '>' {
 				l.readChar()
@@ -109,12 +259,21 @@ func (l *Lexer) NextToken() token.Token {
 			tok = newToken(token.LT, l.ch, l.line)
 		}
 	case '>':
-		if l.peekChar() == '=' {
+		if l.peekChar() == '>' {
+			l.readChar()
+			tok = token.Token{Type: token.RShift, Literal: ">>", Line: l.line}
+		} else if l.peekChar() == '=' {
 			l.readChar()
 			tok = token.Token{Type: token.GTE, Literal: ">=", Line: l.line}
 		} else {
 			tok = newToken(token.GT, l.ch, l.line)
 		}
+	case '?':
+		tok = newToken(token.Question, l.ch, l.line)
+	case '^':
+		tok = newToken(token.Caret, l.ch, l.line)
+	case '~':
+		tok = newToken(token.Tilde, l.ch, l.line)
 	case ';':
 		tok = newToken(token.Semicolon, l.ch, l.line)
 	case '(':
@@ -141,8 +300,31 @@ func (l *Lexer) NextToken() token.Token {
 		}
 		tok = newToken(token.Plus, l.ch, l.line)
 	case '{':
+		if n := len(l.interpolations); n > 0 {
+			l.interpolations[n-1]++
+		}
+
 		tok = newToken(token.LBrace, l.ch, l.line)
 	case '}':
+		if n := len(l.interpolations); n > 0 {
+			// The brace closes the interpolation, so we continue reading the rest of the string
+			if l.interpolations[n-1] == 0 {
+				literal, interpolated := l.readString('"')
+				tok.Literal = literal
+				tok.Type = token.InterpolationMid
+				tok.Line = l.line
+
+				if !interpolated {
+					tok.Type = token.InterpolationEnd
+					l.interpolations = l.interpolations[:n-1]
+				}
+
+				return tok
+			}
+
+			l.interpolations[n-1]--
+		}
+
 		tok = newToken(token.RBrace, l.ch, l.line)
 	case '[':
 		tok = newToken(token.LBracket, l.ch, l.line)
@@ -168,12 +350,17 @@ func (l *Lexer) NextToken() token.Token {
 				l.readChar()
 				tok = token.Token{Type: token.ResolutionOperator, Literal: "::", Line: l.line}
 
-			} else if isLetter(l.peekChar()) {
+			} else if isLetter(l.peekChar()) || (l.peekChar() == '@' && isLetter(l.peekCharAt(1))) {
 				tok.Literal = string(l.readSymbol())
-				tok.Type = token.String
+				tok.Type = token.Symbol
 				tok.Line = l.line
 				return tok
 
+			} else if name := l.operatorMethodNameAt(l.readPosition); name != "" {
+				// Operator symbols like `:+` or `:[]=`, so they can be passed to `send`
+				l.moveTo(l.readPosition + len(name))
+				return token.Token{Type: token.Symbol, Literal: name, Line: l.line}
+
 			} else {
 				tok = newToken(token.Colon, l.ch, l.line)
 			}
@@ -194,8 +381,18 @@ func (l *Lexer) NextToken() token.Token {
 		if l.peekChar() == '&' {
 			l.readChar()
 			tok = token.Token{Type: token.And, Literal: "&&", Line: l.line}
+		} else if l.peekChar() == '.' {
+			l.readChar()
+			tok = token.Token{Type: token.SafeNavigation, Literal: "&.", Line: l.line}
+			l.FSM.Event("method")
+		} else {
+			tok = newToken(token.Amp, l.ch, l.line)
 		}
 	case '%':
+		if strings.ContainsRune("rwiqQ", l.peekChar()) && percentLiteralDelimiters[l.peekCharAt(1)] != 0 && l.valueExpected(spaced, newLine) {
+			return l.readPercentLiteral()
+		}
+
 		tok = newToken(token.Modulo, l.ch, l.line)
 	case '#':
 		tok.Literal = string(l.absorbComment())
@@ -245,10 +442,33 @@ func (l *Lexer) NextToken() token.Token {
 				return tok
 			}
 
-			return newToken(token.Illegal, l.ch, l.line)
+			// Class variables like `@@count`
+			if isInstanceVariable(l.peekChar()) && isLetter(l.peekCharAt(1)) {
+				tok.Literal = string(l.readInstanceVariable())
+				tok.Type = token.ClassVariable
+				tok.Line = l.line
+				return tok
+			}
+
+			tok = newToken(token.Illegal, l.ch, l.line)
+			l.readChar()
+			return tok
+		} else if isGlobalVariable(l.ch) {
+			// Global variables like `$stdout` or `$0`
+			if isLetter(l.peekChar()) || isDigit(l.peekChar()) {
+				tok.Literal = string(l.readGlobalVariable())
+				tok.Type = token.GlobalVariable
+				tok.Line = l.line
+				return tok
+			}
+
+			tok = newToken(token.Illegal, l.ch, l.line)
+			l.readChar()
+			return tok
 		} else if isDigit(l.ch) {
-			tok.Literal = string(l.readNumber())
-			tok.Type = token.Int
+			literal, tokenType := l.readNumber()
+			tok.Literal = string(literal)
+			tok.Type = tokenType
 			tok.Line = l.line
 			return tok
 		}
@@ -263,7 +483,8 @@ func (l *Lexer) NextToken() token.Token {
 func (l *Lexer) skipWhitespace() {
 	for l.ch == ' ' || l.ch == '\t' || l.ch == '\r' || l.ch == '\n' {
 		if l.ch == '\n' {
-			l.line++
+			l.line += 1 + l.skippedLines
+			l.skippedLines = 0
 		}
 		l.readChar()
 	}
@@ -278,12 +499,32 @@ func (l *Lexer) resetNosymbol() {
 
 }
 
-func (l *Lexer) readNumber() []rune {
+// readNumber reads integer literals like `1_000`, `0xff`, `0o17`, `0b1010` and float literals like `3.14`, `1e-9`.
+// Letters and underscores right after the number are read as part of it, so parser can report malformed literals.
+func (l *Lexer) readNumber() ([]rune, token.Type) {
 	position := l.position
-	for isDigit(l.ch) {
+	var tokenType token.Type = token.Int
+	prefixed := l.ch == '0' && strings.ContainsRune("xXoObB", l.peekChar())
+
+	for isDigit(l.ch) || isLetter(l.ch) {
+		if !prefixed && (l.ch == 'e' || l.ch == 'E') {
+			tokenType = token.Float
+
+			if l.peekChar() == '+' || l.peekChar() == '-' {
+				l.readChar()
+			}
+		}
+
 		l.readChar()
+
+		// Only a dot followed by digits belongs to the number, `1.to_s` and `1..2` don't
+		if !prefixed && tokenType == token.Int && l.ch == '.' && isDigit(l.peekChar()) {
+			tokenType = token.Float
+			l.readChar()
+		}
 	}
-	return l.input[position:l.position]
+
+	return l.input[position:l.position], tokenType
 }
 
 func (l *Lexer) readIdentifier() []rune {
@@ -315,18 +556,64 @@ func (l *Lexer) readInstanceVariable() []rune {
 	return l.input[position:l.position]
 }
 
-func (l *Lexer) readString(ch rune) string {
+// operatorMethodNames are the operators that can be defined as methods, longer names come first so they're matched first
+var operatorMethodNames = []string{
+	"[]=", "<=>", "===", "==", "!=", "=~", "<=", ">=", "<<", ">>", "**", "[]", "+@", "-@", "~@",
+	"+", "-", "*", "/", "%", "<", ">", "!", "&", "|", "^", "~",
+}
+
+// readOperatorMethodName reads the operator method name at the current position, or returns an empty string if there isn't one
+func (l *Lexer) readOperatorMethodName() string {
+	name := l.operatorMethodNameAt(l.position)
+
+	if name != "" {
+		l.moveTo(l.position + len(name))
+	}
+
+	// `~x` calls `~`, so `def ~@` defines `~` like in Ruby
+	if name == "~@" {
+		return "~"
+	}
+
+	return name
+}
+
+func (l *Lexer) operatorMethodNameAt(position int) string {
+	for _, name := range operatorMethodNames {
+		end := position + len(name)
+
+		if end <= len(l.input) && string(l.input[position:end]) == name {
+			return name
+		}
+	}
+
+	return ""
+}
+
+func (l *Lexer) readGlobalVariable() []rune {
+	position := l.position
 	l.readChar()
 
-	// Empty strings case such as "" or ''
-	if l.ch == ch {
+	for isLetter(l.ch) || isDigit(l.ch) {
 		l.readChar()
-		return ""
 	}
+	return l.input[position:l.position]
+}
+
+// readString reads the string until its latter quote.
+// For double-quoted strings it stops after `#{` and returns true, then lexer reads the interpolated expression's tokens.
+func (l *Lexer) readString(ch rune) (string, bool) {
+	l.readChar()
 
 	result := ""
 
-	for {
+	for l.ch != ch && l.ch != 0 {
+		if ch == '"' && l.ch == '#' && l.peekChar() == '{' {
+			l.readChar()
+			l.readChar() // move to the interpolated expression's first character
+			return result, true
+		}
+
 		if isEscapedChar(l.ch) {
 			result += escapedCharResult(ch, l.peekChar())
 			l.readChar()
@@ -334,16 +621,217 @@ func (l *Lexer) readString(ch rune) string {
 			result += string(l.ch)
 		}
 		l.readChar()
+	}
+
+	l.readChar() // move over string's latter quote
+
+	return result, false
+}
 
-		if l.ch == ch || l.peekChar() == 0 {
-			break
+// valueExpected tells if the current character starts a value like a regexp or a percent literal instead of being an operator,
+// which depends on the last token.
+// After an identifier, `foo /bar/` is a regexp argument while `foo / bar` and `foo/bar` are divisions,
+// and `a /b` is a division if `a` is a local variable.
+// A value at the beginning of a line is always expected.
+func (l *Lexer) valueExpected(spaced, newLine bool) bool {
+	// Operator method names like `def /(other)` and `a./(b)`
+	if l.FSM.Is("method") {
+		return false
+	}
+
+	if newLine {
+		return true
+	}
+
+	switch l.lastTokenType {
+	case token.Ident:
+		if l.locals[l.lastLocal] {
+			return false
 		}
+
+		next := l.peekChar()
+		return spaced && next != ' ' && next != '\t' && next != '\n' && next != '\r'
+	case token.Int, token.Float, token.String, token.InterpolationEnd, token.Symbol, token.Regexp,
+		token.WordArray, token.SymbolArray, token.Constant, token.InstanceVariable, token.ClassVariable, token.GlobalVariable,
+		token.True, token.False, token.Null, token.Self, token.RParen, token.RBracket, token.RBrace, token.End, token.Dot, token.SafeNavigation, token.Def:
+		return false
 	}
 
-	// fmt.Println(l.ch) <- Currently at string's last character
-	l.readChar() // move to string's latter quote
+	return true
+}
 
-	return result
+// readRegexp reads a regexp literal from its opening delimiter and returns it like `/pattern/options`.
+// Escaped characters are kept as they are for the regexp engine.
+func (l *Lexer) readRegexp(open, close rune) string {
+	pattern := l.readDelimited(open, close)
+	options := []rune{}
+
+	for isLetter(l.ch) {
+		options = append(options, l.ch)
+		l.readChar()
+	}
+
+	return "/" + pattern + "/" + string(options)
+}
+
+// readDelimited reads the content between the opening delimiter and its closing one, and moves over the closing one.
+// Escaped characters are kept as they are, and nested pairs of bracket delimiters are read as part of the content.
+func (l *Lexer) readDelimited(open, close rune) string {
+	l.readChar()
+
+	content := []rune{}
+	depth := 0
+
+	for l.ch != 0 && (l.ch != close || depth > 0) {
+		switch {
+		case l.ch == '\\':
+			content = append(content, l.ch)
+			l.readChar()
+		case l.ch == open && open != close:
+			depth++
+		case l.ch == close:
+			depth--
+		case l.ch == '\n':
+			l.line++
+		}
+
+		content = append(content, l.ch)
+		l.readChar()
+	}
+
+	l.readChar() // move over the closing delimiter
+
+	return string(content)
+}
+
+// readPercentLiteral reads percent literals from the `%`:
+//
+// - `%w[foo bar]` word arrays and `%i[foo bar]` symbol arrays, whose words are split by parser
+// - `%q(foo)` strings, which work like single-quoted strings
+// - `%Q(foo #{bar})` strings, which work like double-quoted strings
+// - `%r{foo}` regexps
+func (l *Lexer) readPercentLiteral() token.Token {
+	start := l.position
+	l.readChar()
+	kind := l.ch
+	l.readChar() // move to the opening delimiter
+	open, close := l.ch, percentLiteralDelimiters[l.ch]
+
+	if kind == 'r' {
+		literal := l.readRegexp(open, close)
+		return token.Token{Type: token.Regexp, Literal: literal, Line: l.line}
+	}
+
+	content := l.readDelimited(open, close)
+
+	switch kind {
+	case 'w':
+		return token.Token{Type: token.WordArray, Literal: content, Line: l.line}
+	case 'i':
+		return token.Token{Type: token.SymbolArray, Literal: content, Line: l.line}
+	case 'q':
+		replacer := strings.NewReplacer(`\\`, `\`, `\`+string(open), string(open), `\`+string(close), string(close))
+		return token.Token{Type: token.String, Literal: replacer.Replace(content), Line: l.line}
+	}
+
+	// The literal is rewritten as a double-quoted string, so its escapes and interpolations are lexed like one
+	l.replaceInput(start, l.position, quoteString(content))
+	l.moveTo(start)
+	return l.nextToken()
+}
+
+// readHeredoc reads heredocs like `<<~SQL`, `<<-EOS` or `<<~'EOS'`.
+// The body starts from the next line and ends before the line of the identifier, and it's removed from the input
+// so the rest of the current line can be lexed as usual.
+// `<<~` also removes the body's common indentation, and the body is interpolated unless the identifier is single-quoted.
+func (l *Lexer) readHeredoc() token.Token {
+	start, line := l.position, l.line
+	l.readChar()
+	l.readChar() // move to the `~` or `-`
+	squiggly := l.ch == '~'
+	l.readChar()
+
+	quote := rune(0)
+
+	if l.ch == '\'' || l.ch == '"' {
+		quote = l.ch
+		l.readChar()
+	}
+
+	identifier := string(l.readConstant())
+
+	if quote != 0 && l.ch == quote {
+		l.readChar()
+	}
+
+	headerEnd := l.position
+	bodyStart := headerEnd
+
+	for bodyStart < len(l.input) && l.input[bodyStart] != '\n' {
+		bodyStart++
+	}
+
+	bodyStart++ // move over the header line's newline
+
+	lines := []string{}
+
+	for end := bodyStart; end < len(l.input); {
+		lineEnd := end
+
+		for lineEnd < len(l.input) && l.input[lineEnd] != '\n' {
+			lineEnd++
+		}
+
+		bodyLine := string(l.input[end:lineEnd])
+
+		if strings.TrimSpace(bodyLine) == identifier {
+			bodyEnd := lineEnd + 1 // the identifier line's newline
+
+			if bodyEnd > len(l.input) {
+				bodyEnd = len(l.input)
+			}
+
+			l.replaceInput(bodyStart, bodyEnd, nil)
+			l.skippedLines += len(lines) + 1
+
+			if squiggly {
+				removeIndentation(lines)
+			}
+
+			content := ""
+
+			for _, bodyLine := range lines {
+				content += bodyLine + "\n"
+			}
+
+			if quote == '\'' {
+				return token.Token{Type: token.String, Literal: content, Line: line}
+			}
+
+			l.replaceInput(start, headerEnd, quoteString(content))
+			l.moveTo(start)
+			return l.nextToken()
+		}
+
+		lines = append(lines, bodyLine)
+		end = lineEnd + 1
+	}
+
+	return token.Token{Type: token.Illegal, Literal: string(l.input[start:headerEnd]), Line: line}
+}
+
+// replaceInput replaces the input between the positions with the given characters
+func (l *Lexer) replaceInput(start, end int, replacement []rune) {
+	input := make([]rune, 0, len(l.input)-(end-start)+len(replacement))
+	input = append(input, l.input[:start]...)
+	input = append(input, replacement...)
+	l.input = append(input, l.input[end:]...)
+}
+
+// moveTo moves the lexer to the given position of the input
+func (l *Lexer) moveTo(position int) {
+	l.readPosition = position
+	l.readChar()
 }
 
 func (l *Lexer) readSymbol() []rune {
@@ -355,6 +843,11 @@ func (l *Lexer) readSymbol() []rune {
 		l.readChar()
 	}
 
+	// Symbols like `:empty?` or `:save!`, but not the `!` in `:a != :b`
+	if (l.peekChar() == '?' || l.peekChar() == '!') && l.peekCharAt(1) != '=' {
+		l.readChar()
+	}
+
 	l.readChar()                           // currently at string's last letter
 	result := l.input[position:l.position] // get full string
 	return result
@@ -389,6 +882,15 @@ func (l *Lexer) peekChar() rune {
 	// Peek shouldn't increment positions.
 }
 
+// peekCharAt returns the character n characters after the peeked one
+func (l *Lexer) peekCharAt(n int) rune {
+	if l.readPosition+n >= len(l.input) {
+		return 0
+	}
+
+	return l.input[l.readPosition+n]
+}
+
 func isDigit(ch rune) bool {
 	return '0' <= ch && ch <= '9'
 }
@@ -397,10 +899,18 @@ func isLetter(ch rune) bool {
 	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_'
 }
 
+func isHeredocIdentifierStart(ch rune) bool {
+	return isLetter(ch) || ch == '\'' || ch == '"'
+}
+
 func isInstanceVariable(ch rune) bool {
 	return ch == '@'
 }
 
+func isGlobalVariable(ch rune) bool {
+	return ch == '$'
+}
+
 func isEscapedChar(ch rune) bool {
 	return ch == '\\'
 }
@@ -424,6 +934,8 @@ func escapedCharResult(quotedChar rune, peeked rune) string {
 			return "\""
 		case '\'':
 			return "'"
+		case '#':
+			return "#"
 		default:
 			return "\\" + string(peeked)
 		}
@@ -438,6 +950,73 @@ func escapedCharResult(quotedChar rune, peeked rune) string {
 	}
 }
 
+// percentLiteralDelimiters maps the opening delimiters of percent literals like `%r{...}` to their closing ones
+var percentLiteralDelimiters = map[rune]rune{
+	'{': '}',
+	'(': ')',
+	'[': ']',
+	'<': '>',
+	'|': '|',
+	'!': '!',
+}
+
+// quoteString returns the content as a double-quoted string literal.
+// Double quotes in the content are escaped unless they're in interpolations, and other escapes are kept as they are.
+func quoteString(content string) []rune {
+	runes := []rune(content)
+	result := []rune{'"'}
+	depth := 0
+
+	for i := 0; i < len(runes); i++ {
+		ch := runes[i]
+
+		switch {
+		case ch == '\\' && i+1 < len(runes):
+			result = append(result, ch, runes[i+1])
+			i++
+			continue
+		case ch == '#' && depth == 0 && i+1 < len(runes) && runes[i+1] == '{':
+			result = append(result, ch, runes[i+1])
+			depth++
+			i++
+			continue
+		case ch == '{' && depth > 0:
+			depth++
+		case ch == '}' && depth > 0:
+			depth--
+		case ch == '"' && depth == 0:
+			result = append(result, '\\')
+		}
+
+		result = append(result, ch)
+	}
+
+	return append(result, '"')
+}
+
+// removeIndentation removes the least indentation of the non-blank lines from all the lines
+func removeIndentation(lines []string) {
+	indentation := -1
+
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if n := len(line) - len(strings.TrimLeft(line, " \t")); indentation == -1 || n < indentation {
+			indentation = n
+		}
+	}
+
+	for i, line := range lines {
+		if len(line) >= indentation && indentation >= 0 {
+			lines[i] = line[indentation:]
+		} else {
+			lines[i] = strings.TrimLeft(line, " \t")
+		}
+	}
+}
+
 func newToken(tokenType token.Type, ch rune, line int) token.Token {
 	return token.Token{Type: tokenType, Literal: string(ch), Line: line}
 }
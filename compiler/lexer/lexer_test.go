@@ -133,6 +133,31 @@ func TestNextToken(t *testing.T) {
 	'\"string\"'
 	"\'string\'"
 	'\'string\''
+
+	begin
+	  raise(Foo, "bar")
+	rescue Foo => e
+	  retry
+	ensure
+	  1
+	end
+
+	"a#{b + "c#{d}"}e" 'f#{g}'
+	"#{ {x: 1}[:x] }" "\#{h}"
+	3.14 1e-9 2.5E+3 1_000 0xff 0o17 0b1010 1.to_s 1..2
+	:foo :empty? :save! :a!= :b :@bar
+	->(x) { x } foo(&:bar)
+	a / b /c\/d/i; %r{/a{1}}x; 4/2
+	%w[a b]; %i(c); %q(d\)); foo(<<~EOS, 1)
+	  e
+	EOS
+	10 % 3
+	a ? b : c; d&.e
+	a & b | c ^ ~d << 1 >> 2
+	Integer === a == b
+	class << self; @@a = $b + $0; end
+	def []=(k); def -@; a.+(b); def self.<=>(c); [:+, :[]=]
+	def +@; def ~@; a.+@; def @
 	`
 
 	tests := []struct {
@@ -391,7 +416,7 @@ func TestNextToken(t *testing.T) {
 		{token.String, "", 93},
 
 		{token.Next, "next", 95},
-		{token.String, "apple", 96},
+		{token.Symbol, "apple", 96},
 
 		{token.LBrace, "{", 97},
 		{token.Ident, "test", 97},
@@ -402,7 +427,7 @@ func TestNextToken(t *testing.T) {
 		{token.LBrace, "{", 98},
 		{token.Ident, "test", 98},
 		{token.Colon, ":", 98},
-		{token.String, "abc", 98},
+		{token.Symbol, "abc", 98},
 		{token.RBrace, "}", 98},
 
 		{token.LBrace, "{", 99},
@@ -465,7 +490,178 @@ func TestNextToken(t *testing.T) {
 		{token.String, "'string'", 125},
 		{token.String, "'string'", 126},
 
-		{token.EOF, "", 127},
+		{token.Begin, "begin", 128},
+		{token.Ident, "raise", 129},
+		{token.LParen, "(", 129},
+		{token.Constant, "Foo", 129},
+		{token.Comma, ",", 129},
+		{token.String, "bar", 129},
+		{token.RParen, ")", 129},
+		{token.Rescue, "rescue", 130},
+		{token.Constant, "Foo", 130},
+		{token.HashRocket, "=>", 130},
+		{token.Ident, "e", 130},
+		{token.Retry, "retry", 131},
+		{token.Ensure, "ensure", 132},
+		{token.Int, "1", 133},
+		{token.End, "end", 134},
+
+		{token.InterpolationStart, "a", 136},
+		{token.Ident, "b", 136},
+		{token.Plus, "+", 136},
+		{token.InterpolationStart, "c", 136},
+		{token.Ident, "d", 136},
+		{token.InterpolationEnd, "", 136},
+		{token.InterpolationEnd, "e", 136},
+		{token.String, "f#{g}", 136},
+		{token.InterpolationStart, "", 137},
+		{token.LBrace, "{", 137},
+		{token.Ident, "x", 137},
+		{token.Colon, ":", 137},
+		{token.Int, "1", 137},
+		{token.RBrace, "}", 137},
+		{token.LBracket, "[", 137},
+		{token.Symbol, "x", 137},
+		{token.RBracket, "]", 137},
+		{token.InterpolationEnd, "", 137},
+		{token.String, "#{h}", 137},
+		{token.Float, "3.14", 138},
+		{token.Float, "1e-9", 138},
+		{token.Float, "2.5E+3", 138},
+		{token.Int, "1_000", 138},
+		{token.Int, "0xff", 138},
+		{token.Int, "0o17", 138},
+		{token.Int, "0b1010", 138},
+		{token.Int, "1", 138},
+		{token.Dot, ".", 138},
+		{token.Ident, "to_s", 138},
+		{token.Int, "1", 138},
+		{token.Range, "..", 138},
+		{token.Int, "2", 138},
+		{token.Symbol, "foo", 139},
+		{token.Symbol, "empty?", 139},
+		{token.Symbol, "save!", 139},
+		{token.Symbol, "a", 139},
+		{token.NotEq, "!=", 139},
+		{token.Symbol, "b", 139},
+		{token.Symbol, "@bar", 139},
+		{token.Arrow, "->", 140},
+		{token.LParen, "(", 140},
+		{token.Ident, "x", 140},
+		{token.RParen, ")", 140},
+		{token.LBrace, "{", 140},
+		{token.Ident, "x", 140},
+		{token.RBrace, "}", 140},
+		{token.Ident, "foo", 140},
+		{token.LParen, "(", 140},
+		{token.Amp, "&", 140},
+		{token.Symbol, "bar", 140},
+		{token.RParen, ")", 140},
+		{token.Ident, "a", 141},
+		{token.Slash, "/", 141},
+		{token.Ident, "b", 141},
+		{token.Regexp, "/c\\/d/i", 141},
+		{token.Semicolon, ";", 141},
+		{token.Regexp, "//a{1}/x", 141},
+		{token.Semicolon, ";", 141},
+		{token.Int, "4", 141},
+		{token.Slash, "/", 141},
+		{token.Int, "2", 141},
+		{token.WordArray, "a b", 142},
+		{token.Semicolon, ";", 142},
+		{token.SymbolArray, "c", 142},
+		{token.Semicolon, ";", 142},
+		{token.String, "d)", 142},
+		{token.Semicolon, ";", 142},
+		{token.Ident, "foo", 142},
+		{token.LParen, "(", 142},
+		{token.String, "e\n", 142},
+		{token.Comma, ",", 142},
+		{token.Int, "1", 142},
+		{token.RParen, ")", 142},
+		{token.Int, "10", 145},
+		{token.Modulo, "%", 145},
+		{token.Int, "3", 145},
+		{token.Ident, "a", 146},
+		{token.Question, "?", 146},
+		{token.Ident, "b", 146},
+		{token.Colon, ":", 146},
+		{token.Ident, "c", 146},
+		{token.Semicolon, ";", 146},
+		{token.Ident, "d", 146},
+		{token.SafeNavigation, "&.", 146},
+		{token.Ident, "e", 146},
+		{token.Ident, "a", 147},
+		{token.Amp, "&", 147},
+		{token.Ident, "b", 147},
+		{token.Bar, "|", 147},
+		{token.Ident, "c", 147},
+		{token.Caret, "^", 147},
+		{token.Tilde, "~", 147},
+		{token.Ident, "d", 147},
+		{token.LShift, "<<", 147},
+		{token.Int, "1", 147},
+		{token.RShift, ">>", 147},
+		{token.Int, "2", 147},
+		{token.Constant, "Integer", 148},
+		{token.CaseEq, "===", 148},
+		{token.Ident, "a", 148},
+		{token.Eq, "==", 148},
+		{token.Ident, "b", 148},
+		{token.Class, "class", 149},
+		{token.LShift, "<<", 149},
+		{token.Self, "self", 149},
+		{token.Semicolon, ";", 149},
+		{token.ClassVariable, "@@a", 149},
+		{token.Assign, "=", 149},
+		{token.GlobalVariable, "$b", 149},
+		{token.Plus, "+", 149},
+		{token.GlobalVariable, "$0", 149},
+		{token.Semicolon, ";", 149},
+		{token.End, "end", 149},
+		{token.Def, "def", 150},
+		{token.Ident, "[]=", 150},
+		{token.LParen, "(", 150},
+		{token.Ident, "k", 150},
+		{token.RParen, ")", 150},
+		{token.Semicolon, ";", 150},
+		{token.Def, "def", 150},
+		{token.Ident, "-@", 150},
+		{token.Semicolon, ";", 150},
+		{token.Ident, "a", 150},
+		{token.Dot, ".", 150},
+		{token.Ident, "+", 150},
+		{token.LParen, "(", 150},
+		{token.Ident, "b", 150},
+		{token.RParen, ")", 150},
+		{token.Semicolon, ";", 150},
+		{token.Def, "def", 150},
+		{token.Self, "self", 150},
+		{token.Dot, ".", 150},
+		{token.Ident, "<=>", 150},
+		{token.LParen, "(", 150},
+		{token.Ident, "c", 150},
+		{token.RParen, ")", 150},
+		{token.Semicolon, ";", 150},
+		{token.LBracket, "[", 150},
+		{token.Symbol, "+", 150},
+		{token.Comma, ",", 150},
+		{token.Symbol, "[]=", 150},
+		{token.RBracket, "]", 150},
+		{token.Def, "def", 151},
+		{token.Ident, "+@", 151},
+		{token.Semicolon, ";", 151},
+		{token.Def, "def", 151},
+		{token.Ident, "~", 151},
+		{token.Semicolon, ";", 151},
+		{token.Ident, "a", 151},
+		{token.Dot, ".", 151},
+		{token.Ident, "+@", 151},
+		{token.Semicolon, ";", 151},
+		{token.Def, "def", 151},
+		{token.Illegal, "@", 151},
+
+		{token.EOF, "", 152},
 	}
 	l := New(input)
 
@@ -484,3 +680,35 @@ func TestNextToken(t *testing.T) {
 		}
 	}
 }
+
+func TestSlashAfterLocalVariable(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected token.Type
+	}{
+		{`foo /b/`, token.Regexp},
+		{`a = 4; a /b`, token.Slash},
+		{`a ||= 4; a /b`, token.Slash},
+		{`def foo(x, *y, &z); x /b; end`, token.Slash},
+		{`def foo(x, *y, &z); y /b; end`, token.Slash},
+		{`def foo(x = c); c /b/; end`, token.Regexp},
+		{`def foo=(x); foo /b/; end`, token.Regexp},
+		{`[1].each do |x| x /b end`, token.Slash},
+		{`[1].each { |x, y| y /b }`, token.Slash},
+		{`->(x) { x /b }`, token.Slash},
+		{`foo.a = 4; a /b/`, token.Regexp},
+	}
+
+	for i, tt := range tests {
+		l := New(tt.input)
+		tok := l.NextToken()
+
+		for tok.Type != token.Slash && tok.Type != token.Regexp && tok.Type != token.EOF {
+			tok = l.NextToken()
+		}
+
+		if tok.Type != tt.expected {
+			t.Fatalf("At case %d expect the slash to be %s. got=%s", i, tt.expected, tok.Type)
+		}
+	}
+}
@@ -1,15 +1,27 @@
 package parser
 
 import (
+	"fmt"
 	"github.com/goby-lang/goby/compiler/ast"
 	"github.com/goby-lang/goby/compiler/token"
+	"math/big"
 	"strconv"
+	"strings"
 )
 
 func (p *Parser) parseIntegerLiteral() ast.Expression {
 	lit := &ast.IntegerLiteral{BaseNode: &ast.BaseNode{Token: p.curToken}}
 
 	value, err := strconv.ParseInt(lit.TokenLiteral(), 0, 64)
+
+	// Literals out of the int range are big integers, like the results of arithmetic that overflows
+	if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange {
+		if bigValue, ok := new(big.Int).SetString(lit.TokenLiteral(), 0); ok {
+			lit.BigValue = bigValue
+			return lit
+		}
+	}
+
 	if err != nil {
 		p.error = newTypeParsingError(lit.TokenLiteral(), "integer", p.curToken.Line)
 		return nil
@@ -20,6 +32,20 @@ func (p *Parser) parseIntegerLiteral() ast.Expression {
 	return lit
 }
 
+func (p *Parser) parseFloatLiteral() ast.Expression {
+	lit := &ast.FloatLiteral{BaseNode: &ast.BaseNode{Token: p.curToken}}
+
+	value, err := strconv.ParseFloat(lit.TokenLiteral(), 64)
+	if err != nil {
+		p.error = newTypeParsingError(lit.TokenLiteral(), "float", p.curToken.Line)
+		return nil
+	}
+
+	lit.Value = value
+
+	return lit
+}
+
 func (p *Parser) parseStringLiteral() ast.Expression {
 	lit := &ast.StringLiteral{BaseNode: &ast.BaseNode{Token: p.curToken}}
 	lit.Value = p.curToken.Literal
@@ -27,6 +53,86 @@ func (p *Parser) parseStringLiteral() ast.Expression {
 	return lit
 }
 
+func (p *Parser) parseSymbolLiteral() ast.Expression {
+	return &ast.SymbolLiteral{BaseNode: &ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal}
+}
+
+// parseRegexpLiteral parses the regexp token like `/pattern/options`, options can only be `i`, `m` and `x`
+func (p *Parser) parseRegexpLiteral() ast.Expression {
+	lit := &ast.RegexpLiteral{BaseNode: &ast.BaseNode{Token: p.curToken}}
+	literal := p.curToken.Literal
+	closing := strings.LastIndex(literal, "/")
+
+	lit.Value = literal[1:closing]
+	lit.Options = literal[closing+1:]
+
+	for _, option := range lit.Options {
+		if !strings.ContainsRune("imx", option) {
+			p.error = &Error{Message: fmt.Sprintf("Unknown regexp option '%c'. Line: %d", option, p.curToken.Line), errType: SyntaxError}
+			return nil
+		}
+	}
+
+	// Interpolations aren't supported, so `#{` has to be escaped like `\#{` to match itself
+	for i := 0; i < len(lit.Value); i++ {
+		switch {
+		case lit.Value[i] == '\\':
+			i++
+		case strings.HasPrefix(lit.Value[i:], "#{"):
+			p.error = &Error{Message: fmt.Sprintf("Regexp interpolation is not supported. Line: %d", p.curToken.Line), errType: SyntaxError}
+			return nil
+		}
+	}
+
+	return lit
+}
+
+// parseWordArray parses word arrays like `%w[foo bar]` and symbol arrays like `%i[foo bar]` into array literals
+func (p *Parser) parseWordArray() ast.Expression {
+	arr := &ast.ArrayExpression{BaseNode: &ast.BaseNode{Token: p.curToken}}
+
+	for _, word := range strings.Fields(p.curToken.Literal) {
+		if p.curTokenIs(token.SymbolArray) {
+			tok := token.Token{Type: token.Symbol, Literal: word, Line: p.curToken.Line}
+			arr.Elements = append(arr.Elements, &ast.SymbolLiteral{BaseNode: &ast.BaseNode{Token: tok}, Value: word})
+		} else {
+			tok := token.Token{Type: token.String, Literal: word, Line: p.curToken.Line}
+			arr.Elements = append(arr.Elements, &ast.StringLiteral{BaseNode: &ast.BaseNode{Token: tok}, Value: word})
+		}
+	}
+
+	return arr
+}
+
+func (p *Parser) parseInterpolatedString() ast.Expression {
+	is := &ast.InterpolatedString{BaseNode: &ast.BaseNode{Token: p.curToken}}
+	p.appendStringPart(is)
+
+	for !p.curTokenIs(token.InterpolationEnd) {
+		// Empty interpolation like "#{}"
+		if !p.peekTokenIs(token.InterpolationMid) && !p.peekTokenIs(token.InterpolationEnd) {
+			p.nextToken()
+			is.Parts = append(is.Parts, p.parseExpression(NORMAL))
+		}
+
+		if !p.peekTokenIs(token.InterpolationMid) && !p.peekTokenIs(token.InterpolationEnd) {
+			p.peekError(token.RBrace)
+			return nil
+		}
+
+		p.nextToken()
+		p.appendStringPart(is)
+	}
+
+	return is
+}
+
+func (p *Parser) appendStringPart(is *ast.InterpolatedString) {
+	if p.curToken.Literal != "" {
+		is.Parts = append(is.Parts, &ast.StringLiteral{BaseNode: &ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal})
+	}
+}
+
 func (p *Parser) parseBooleanLiteral() ast.Expression {
 	lit := &ast.BooleanExpression{BaseNode: &ast.BaseNode{Token: p.curToken}}
 
@@ -47,53 +153,47 @@ func (p *Parser) parseNilExpression() ast.Expression {
 
 func (p *Parser) parseHashExpression() ast.Expression {
 	hash := &ast.HashExpression{BaseNode: &ast.BaseNode{Token: p.curToken}}
-	hash.Data = p.parseHashPairs()
-	return hash
-}
-
-func (p *Parser) parseHashPairs() map[string]ast.Expression {
-	pairs := map[string]ast.Expression{}
 
 	if p.peekTokenIs(token.RBrace) {
 		p.nextToken() // '}'
-		return pairs
+		return hash
 	}
 
-	p.parseHashPair(pairs)
+	p.parseHashPair(hash)
 
 	for p.peekTokenIs(token.Comma) {
 		p.nextToken()
 
-		p.parseHashPair(pairs)
+		p.parseHashPair(hash)
 	}
 
 	if !p.expectPeek(token.RBrace) {
 		return nil
 	}
 
-	return pairs
+	return hash
 }
 
-func (p *Parser) parseHashPair(pairs map[string]ast.Expression) {
-	var key string
-	var value ast.Expression
+// parseHashPair parses a pair like `key: value` or `key => value`, the key of the first form is a string
+func (p *Parser) parseHashPair(hash *ast.HashExpression) {
+	var key ast.Expression
 
 	p.nextToken()
 
-	switch p.curToken.Type {
-	case token.Constant, token.Ident:
-		key = p.parseIdentifier().(ast.Variable).ReturnValue()
-	default:
-		return
-	}
+	if (p.curTokenIs(token.Constant) || p.curTokenIs(token.Ident)) && p.peekTokenIs(token.Colon) {
+		key = &ast.SymbolLiteral{BaseNode: &ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal}
+		p.nextToken()
+	} else {
+		key = p.parseExpression(NORMAL)
 
-	if !p.expectPeek(token.Colon) {
-		return
+		if !p.expectPeek(token.HashRocket) {
+			return
+		}
 	}
 
 	p.nextToken()
-	value = p.parseExpression(NORMAL)
-	pairs[key] = value
+	hash.Keys = append(hash.Keys, key)
+	hash.Values = append(hash.Values, p.parseExpression(NORMAL))
 }
 
 func (p *Parser) parseArrayExpression() ast.Expression {
@@ -8,18 +8,27 @@ import (
 )
 
 var arguments = map[token.Type]bool{
-	token.Int:              true,
-	token.String:           true,
-	token.True:             true,
-	token.False:            true,
-	token.Null:             true,
-	token.InstanceVariable: true,
-	token.Ident:            true,
-	token.Constant:         true,
+	token.Int:                true,
+	token.Float:              true,
+	token.String:             true,
+	token.Symbol:             true,
+	token.Regexp:             true,
+	token.WordArray:          true,
+	token.SymbolArray:        true,
+	token.InterpolationStart: true,
+	token.True:               true,
+	token.False:              true,
+	token.Null:               true,
+	token.InstanceVariable:   true,
+	token.ClassVariable:      true,
+	token.GlobalVariable:     true,
+	token.Ident:              true,
+	token.Constant:           true,
 }
 
 var precedence = map[token.Type]int{
 	token.Eq:                 EQUALS,
+	token.CaseEq:             EQUALS,
 	token.NotEq:              EQUALS,
 	token.Match:              COMPARE,
 	token.LT:                 COMPARE,
@@ -27,6 +36,11 @@ var precedence = map[token.Type]int{
 	token.GT:                 COMPARE,
 	token.GTE:                COMPARE,
 	token.COMP:               COMPARE,
+	token.Bar:                BITOR,
+	token.Caret:              BITOR,
+	token.Amp:                BITAND,
+	token.LShift:             SHIFT,
+	token.RShift:             SHIFT,
 	token.And:                LOGIC,
 	token.Or:                 LOGIC,
 	token.Range:              RANGE,
@@ -40,6 +54,7 @@ var precedence = map[token.Type]int{
 	token.Pow:                PRODUCT,
 	token.LBracket:           INDEX,
 	token.Dot:                CALL,
+	token.SafeNavigation:     CALL,
 	token.LParen:             CALL,
 	token.ResolutionOperator: CALL,
 	token.Assign:             ASSIGN,
@@ -47,6 +62,7 @@ var precedence = map[token.Type]int{
 	token.MinusEq:            ASSIGN,
 	token.OrEq:               ASSIGN,
 	token.Colon:              ASSIGN,
+	token.Question:           TERNARY,
 }
 
 // Constants for denoting precedence
@@ -55,10 +71,14 @@ const (
 	LOWEST
 	NORMAL
 	ASSIGN
+	TERNARY
 	LOGIC
 	RANGE
 	EQUALS
 	COMPARE
+	BITOR
+	BITAND
+	SHIFT
 	SUM
 	PRODUCT
 	PREFIX
@@ -130,7 +150,15 @@ func (p *Parser) parseExpression(precedence int) ast.Expression {
 		}
 	}
 
-	leftExp := parseFn()
+	var leftExp ast.Expression
+
+	// Parse method call with a brace block, like `foo { |x| x }`, which can be chained like `foo { |x| x }.bar`
+	if p.curTokenIs(token.Ident) && !p.fsm.Is(parsingMethodParam) && p.peekBraceBlockStart() {
+		method := p.parseIdentifier()
+		leftExp = p.parseCallExpressionWithoutReceiver(method)
+	} else {
+		leftExp = parseFn()
+	}
 
 	/*
 		Precedence example:
@@ -189,6 +217,12 @@ func (p *Parser) parseYieldExpression() ast.Expression {
 		ye.Arguments = p.parseCallArguments()
 	}
 
+	for _, arg := range ye.Arguments {
+		if pe, ok := arg.(*ast.PrefixExpression); ok && pe.Operator == "&" {
+			p.error = &Error{Message: fmt.Sprintf("Can't pass block argument to yield. Line: %d", pe.Line()), errType: ArgumentError}
+		}
+	}
+
 	return ye
 }
 
@@ -216,6 +250,14 @@ func (p *Parser) parseInstanceVariable() ast.Expression {
 	return &ast.InstanceVariable{BaseNode: &ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal}
 }
 
+func (p *Parser) parseClassVariable() ast.Expression {
+	return &ast.ClassVariable{BaseNode: &ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal}
+}
+
+func (p *Parser) parseGlobalVariable() ast.Expression {
+	return &ast.GlobalVariable{BaseNode: &ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal}
+}
+
 func (p *Parser) parsePairExpression(key ast.Expression) ast.Expression {
 	exp := &ast.PairExpression{BaseNode: &ast.BaseNode{Token: p.curToken}, Key: key}
 
@@ -234,17 +276,10 @@ func (p *Parser) parsePairExpression(key ast.Expression) ast.Expression {
 func (p *Parser) parseIndexExpression(left ast.Expression) ast.Expression {
 	callExpression := &ast.CallExpression{Receiver: left, Method: "[]", BaseNode: &ast.BaseNode{Token: p.curToken}}
 
-	if p.peekTokenIs(token.RBracket) {
-		callExpression.Arguments = []ast.Expression{}
-		p.nextToken()
-		return callExpression
-	}
-
-	p.nextToken()
-
-	callExpression.Arguments = []ast.Expression{p.parseExpression(NORMAL)}
+	// Indexes can take several arguments, like `a[1, 2]`
+	callExpression.Arguments = p.parseArrayElements()
 
-	if !p.expectPeek(token.RBracket) {
+	if callExpression.Arguments == nil {
 		return nil
 	}
 
@@ -277,8 +312,9 @@ func (p *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
 	operator := p.curToken
 	precedence := p.curPrecedence()
 
+	// `a || b ? c : d` means `(a || b) ? c : d`
 	if operator.Literal == "||" || operator.Literal == "&&" {
-		precedence = NORMAL
+		precedence = TERNARY
 	}
 
 	p.nextToken()
@@ -287,6 +323,12 @@ func (p *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
 }
 
 func (p *Parser) parseAssignExpression(v ast.Expression) ast.Expression {
+	// `a && b = c` assigns to the right operand, like `a && (b = c)`
+	if infix, ok := v.(*ast.InfixExpression); ok && (infix.Operator == "||" || infix.Operator == "&&") {
+		infix.Right = p.parseAssignExpression(infix.Right)
+		return infix
+	}
+
 	var value ast.Expression
 	var tok token.Token
 	exp := &ast.AssignExpression{BaseNode: &ast.BaseNode{}}
@@ -109,9 +109,62 @@ func TestHashExpression(t *testing.T) {
 
 		hash, ok := stmt.Expression.(*ast.HashExpression)
 
-		for key := range hash.Data {
-			testIntegerLiteral(t, hash.Data[key], tt.expectedElements[key])
+		if len(hash.Keys) != len(tt.expectedElements) {
+			t.Fatalf("expect hash to have %d pairs. got=%d", len(tt.expectedElements), len(hash.Keys))
 		}
+
+		for i, key := range hash.Keys {
+			symbol, ok := key.(*ast.SymbolLiteral)
+
+			if !ok {
+				t.Fatalf("expect key to be a SymbolLiteral. got=%T", key)
+			}
+
+			testIntegerLiteral(t, hash.Values[i], tt.expectedElements[symbol.Value])
+		}
+	}
+}
+
+func TestHashExpressionWithHashRocket(t *testing.T) {
+	input := `{ b: 1, 2 => 3, :c => 4, "d" => 5 }`
+
+	l := lexer.New(input)
+	p := New(l)
+	program, err := p.ParseProgram()
+
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	hash, ok := stmt.Expression.(*ast.HashExpression)
+
+	if !ok {
+		t.Fatalf("expect expression to be a HashExpression. got=%T", stmt.Expression)
+	}
+
+	if len(hash.Keys) != 4 {
+		t.Fatalf("expect hash to have 4 pairs. got=%d", len(hash.Keys))
+	}
+
+	symbol, ok := hash.Keys[0].(*ast.SymbolLiteral)
+
+	if !ok || symbol.Value != "b" {
+		t.Fatalf("expect first key to be :b. got=%s", hash.Keys[0].String())
+	}
+
+	testIntegerLiteral(t, hash.Keys[1], 2)
+
+	symbol, ok = hash.Keys[2].(*ast.SymbolLiteral)
+
+	if !ok || symbol.Value != "c" {
+		t.Fatalf("expect third key to be :c. got=%s", hash.Keys[2].String())
+	}
+
+	testStringLiteral(t, hash.Keys[3], "d")
+
+	for i, value := range hash.Values {
+		testIntegerLiteral(t, value, []int{1, 3, 4, 5}[i])
 	}
 }
 
@@ -198,6 +251,53 @@ func TestArrayExpression(t *testing.T) {
 	}
 }
 
+func TestWordArrayExpression(t *testing.T) {
+	tests := []struct {
+		input            string
+		expectedElements []string
+		symbol           bool
+	}{
+		{`%w[]`, []string{}, false},
+		{`%w[foo bar]`, []string{"foo", "bar"}, false},
+		{`%w(foo
+		  bar)`, []string{"foo", "bar"}, false},
+		{`%i{foo bar}`, []string{"foo", "bar"}, true},
+	}
+
+	for i, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program, err := p.ParseProgram()
+
+		if err != nil {
+			t.Fatalf("At case %d got error: %s", i, err.Message)
+		}
+
+		arr, ok := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.ArrayExpression)
+
+		if !ok {
+			t.Fatalf("At case %d expect expression to be an ArrayExpression. got=%T", i, program.Statements[0].(*ast.ExpressionStatement).Expression)
+		}
+
+		if len(arr.Elements) != len(tt.expectedElements) {
+			t.Fatalf("At case %d expect %d elements. got=%d", i, len(tt.expectedElements), len(arr.Elements))
+		}
+
+		for j, elem := range arr.Elements {
+			if !tt.symbol {
+				testStringLiteral(t, elem, tt.expectedElements[j])
+				continue
+			}
+
+			symbol, ok := elem.(*ast.SymbolLiteral)
+
+			if !ok || symbol.Value != tt.expectedElements[j] {
+				t.Fatalf("At case %d expect element %d to be symbol %q. got=%v", i, j, tt.expectedElements[j], elem)
+			}
+		}
+	}
+}
+
 func TestArrayIndexExpression(t *testing.T) {
 	tests := []struct {
 		input         string
@@ -240,6 +340,36 @@ func TestArrayIndexExpression(t *testing.T) {
 	}
 }
 
+func TestArrayIndexExpressionWithSeveralArguments(t *testing.T) {
+	input := `a[1, foo]`
+
+	l := lexer.New(input)
+	p := New(l)
+	program, err := p.ParseProgram()
+
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	arrIndex, ok := stmt.Expression.(*ast.CallExpression)
+
+	if !ok {
+		t.Fatalf("expression is not ast.CallExpression. got=%T", stmt.Expression)
+	}
+
+	if arrIndex.Method != "[]" {
+		t.Fatalf("expect method to be []. got=%s", arrIndex.Method)
+	}
+
+	if len(arrIndex.Arguments) != 2 {
+		t.Fatalf("expect 2 arguments. got=%d", len(arrIndex.Arguments))
+	}
+
+	testIntegerLiteral(t, arrIndex.Arguments[0], 1)
+	testIdentifier(t, arrIndex.Arguments[1], "foo")
+}
+
 func TestIdentifierExpression(t *testing.T) {
 	input := `foobar;`
 
@@ -314,20 +444,110 @@ func TestIntegerLiteralExpression(t *testing.T) {
 	testIntegerLiteral(t, literal, 5)
 }
 
-func TestIntegerLiteralExpressionFail(t *testing.T) {
-	input := `9223372036854775808;`
+func TestBigIntegerLiteralExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`9223372036854775808;`, "9223372036854775808"},
+		{`100_000_000_000_000_000_000;`, "100000000000000000000"},
+		{`0x1_0000_0000_0000_0000;`, "18446744073709551616"},
+	}
 
-	l := lexer.New(input)
-	p := New(l)
-	_, err := p.ParseProgram()
+	for i, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program, err := p.ParseProgram()
+
+		if err != nil {
+			t.Fatalf("At case %d got error: %s", i, err.Message)
+		}
+
+		literal := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.IntegerLiteral)
 
-	if err == nil {
-		t.Fatal("Expected Integer literal parsing error")
-	} else if p.error.Message != "could not parse \"9223372036854775808\" as integer. Line: 0" {
-		t.Fatalf("Unexpected parsing error: %s", p.error.Message)
+		if literal.BigValue == nil || literal.BigValue.String() != tt.expected {
+			t.Fatalf("At case %d expect big value to be %s. got=%v", i, tt.expected, literal.BigValue)
+		}
+	}
+}
+
+func TestNumericLiteralExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`1_000;`, 1000},
+		{`0xff;`, 255},
+		{`0o17;`, 15},
+		{`0b1010;`, 10},
+		{`3.14;`, 3.14},
+		{`1e-9;`, 1e-9},
+		{`2.5E3;`, 2500.0},
 	}
 
-	// "could not parse 9223372036854775808 as integer. Line: 1"
+	for i, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program, err := p.ParseProgram()
+
+		if err != nil {
+			t.Fatal(err.Message)
+		}
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			literal, ok := stmt.Expression.(*ast.IntegerLiteral)
+
+			if !ok {
+				t.Fatalf("At case %d expect expression to be an IntegerLiteral. got=%T", i, stmt.Expression)
+			}
+
+			if literal.Value != expected {
+				t.Fatalf("At case %d expect integer literal's value to be %d. got=%d", i, expected, literal.Value)
+			}
+		case float64:
+			literal, ok := stmt.Expression.(*ast.FloatLiteral)
+
+			if !ok {
+				t.Fatalf("At case %d expect expression to be a FloatLiteral. got=%T", i, stmt.Expression)
+			}
+
+			if literal.Value != expected {
+				t.Fatalf("At case %d expect float literal's value to be %f. got=%f", i, expected, literal.Value)
+			}
+		}
+	}
+}
+
+func TestNumericLiteralExpressionFail(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`1__0`, "could not parse \"1__0\" as integer. Line: 0"},
+		{`1_`, "could not parse \"1_\" as integer. Line: 0"},
+		{`0b102`, "could not parse \"0b102\" as integer. Line: 0"},
+		{`0x`, "could not parse \"0x\" as integer. Line: 0"},
+		{`12abc`, "could not parse \"12abc\" as integer. Line: 0"},
+		{`1.5e`, "could not parse \"1.5e\" as float. Line: 0"},
+		{`1.5_`, "could not parse \"1.5_\" as float. Line: 0"},
+	}
+
+	for i, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		_, err := p.ParseProgram()
+
+		if err == nil {
+			t.Fatalf("At case %d expect a parsing error", i)
+		}
+
+		if err.Message != tt.expected {
+			t.Fatalf("At case %d expect error message to be %q. got=%q", i, tt.expected, err.Message)
+		}
+	}
 }
 
 func TestStringLiteralExpression(t *testing.T) {
@@ -363,6 +583,139 @@ func TestStringLiteralExpression(t *testing.T) {
 	}
 }
 
+func TestSymbolLiteralExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: `:foo;`, expected: "foo"},
+		{input: `:empty?;`, expected: "empty?"},
+		{input: `:save!;`, expected: "save!"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program, err := p.ParseProgram()
+
+		if err != nil {
+			t.Fatal(err.Message)
+		}
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		literal, ok := stmt.Expression.(*ast.SymbolLiteral)
+
+		if !ok {
+			t.Fatalf("expect expression to be a SymbolLiteral. got=%T", stmt.Expression)
+		}
+
+		if literal.Value != tt.expected {
+			t.Fatalf("expect symbol's value to be %q. got=%q", tt.expected, literal.Value)
+		}
+	}
+}
+
+func TestRegexpLiteralExpression(t *testing.T) {
+	tests := []struct {
+		input   string
+		value   string
+		options string
+	}{
+		{`/foo/`, "foo", ""},
+		{`/fo\/o/im`, `fo\/o`, "im"},
+		{`%r{/usr/bin}x`, "/usr/bin", "x"},
+		{`%r(a(b)c)`, "a(b)c", ""},
+		{`foo /bar/`, "bar", ""},
+		{`/a\#{b}/`, `a\#{b}`, ""},
+	}
+
+	for i, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program, err := p.ParseProgram()
+
+		if err != nil {
+			t.Fatalf("At case %d got error: %s", i, err.Message)
+		}
+
+		exp := program.Statements[0].(*ast.ExpressionStatement).Expression
+
+		if call, ok := exp.(*ast.CallExpression); ok {
+			exp = call.Arguments[0]
+		}
+
+		literal, ok := exp.(*ast.RegexpLiteral)
+
+		if !ok {
+			t.Fatalf("At case %d expect expression to be a RegexpLiteral. got=%T", i, exp)
+		}
+
+		if literal.Value != tt.value {
+			t.Fatalf("At case %d expect regexp's value to be %q. got=%q", i, tt.value, literal.Value)
+		}
+
+		if literal.Options != tt.options {
+			t.Fatalf("At case %d expect regexp's options to be %q. got=%q", i, tt.options, literal.Options)
+		}
+	}
+}
+
+func TestRegexpLiteralExpressionFail(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`/foo/g`, "Unknown regexp option 'g'. Line: 0"},
+		{`/a#{b}c/`, "Regexp interpolation is not supported. Line: 0"},
+		{`%r{a#{b}c}`, "Regexp interpolation is not supported. Line: 0"},
+	}
+
+	for i, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		_, err := p.ParseProgram()
+
+		if err == nil {
+			t.Fatalf("At case %d expect a syntax error", i)
+		}
+
+		if err.Message != tt.expected {
+			t.Fatalf("At case %d expect error message to be %q. got=%q", i, tt.expected, err.Message)
+		}
+	}
+}
+
+func TestInterpolatedStringExpression(t *testing.T) {
+	input := `"foo#{bar + 1}baz#{"#{qux}"}"`
+
+	l := lexer.New(input)
+	p := New(l)
+	program, err := p.ParseProgram()
+
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	exp, ok := stmt.Expression.(*ast.InterpolatedString)
+
+	if !ok {
+		t.Fatalf("expect expression to be an InterpolatedString. got=%T", stmt.Expression)
+	}
+
+	if len(exp.Parts) != 4 {
+		t.Fatalf("expect the length of parts to be 4. got=%d", len(exp.Parts))
+	}
+
+	testStringLiteral(t, exp.Parts[0], "foo")
+	testInfixExpression(t, exp.Parts[1], "bar", "+", 1)
+	testStringLiteral(t, exp.Parts[2], "baz")
+
+	if exp.Parts[3].String() != `"#{qux}"` {
+		t.Fatalf(`expect the last part to be "#{qux}". got=%s`, exp.Parts[3].String())
+	}
+}
+
 func TestParsingInfixExpression(t *testing.T) {
 	infixTests := []struct {
 		input      string
@@ -553,7 +906,7 @@ func TestCaseExpression(t *testing.T) {
 
 	c0 := cs[0]
 
-	if !testInfixExpression(t, c0.Condition, 2, "==", 0) {
+	if !testInfixExpression(t, c0.Condition, 0, "===", 2) {
 		return
 	}
 
@@ -573,7 +926,7 @@ func TestCaseExpression(t *testing.T) {
 
 	c1 := cs[1]
 
-	if !testInfixExpression(t, c1.Condition, 2, "==", 1) {
+	if !testInfixExpression(t, c1.Condition, 1, "===", 2) {
 		return
 	}
 
@@ -602,6 +955,108 @@ func TestCaseExpression(t *testing.T) {
 	}
 }
 
+func TestCaseInExpression(t *testing.T) {
+	input := `
+	case foo
+	in {status: "ok", data: [first, *rest]} then
+	  first
+	in [1 | 2 => n, ^limit, *] if n > 0
+	  n
+	in a, *b, 1..3
+	  b
+	in status:, code: Integer => c, **nil
+	  c
+	else
+	  nil
+	end
+	`
+
+	l := lexer.New(input)
+	p := New(l)
+	program, err := p.ParseProgram()
+
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	exp, ok := stmt.Expression.(*ast.CaseInExpression)
+
+	if !ok {
+		t.Fatalf("expect statement to be a CaseInExpression. got=%T", stmt.Expression)
+	}
+
+	testIdentifier(t, exp.Subject, "foo")
+
+	expectedPatterns := []string{
+		`{status: "ok", data: [first, *rest]}`,
+		`[(1 | 2) => n, ^limit, *]`,
+		`[a, *b, (1..3)]`,
+		`{status: status, code: Integer => c, **nil}`,
+	}
+
+	if len(exp.Clauses) != len(expectedPatterns) {
+		t.Fatalf("expect the length of clauses to be %d. got=%d", len(expectedPatterns), len(exp.Clauses))
+	}
+
+	for i, c := range exp.Clauses {
+		if c.Pattern.String() != expectedPatterns[i] {
+			t.Errorf("expect clause %d's pattern to be %q. got=%q", i, expectedPatterns[i], c.Pattern.String())
+		}
+	}
+
+	if exp.Clauses[0].Guard != nil {
+		t.Errorf("expect clause 0 not to have a guard")
+	}
+
+	testInfixExpression(t, exp.Clauses[1].Guard, "n", ">", 0)
+	testIdentifier(t, exp.Clauses[2].Consequence.Statements[0].(*ast.ExpressionStatement).Expression, "b")
+
+	if exp.Alternative == nil {
+		t.Fatalf("expect case expression to have an else body")
+	}
+}
+
+func TestCaseInExpressionSyntaxError(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`
+		case x
+		in [*a, *b]
+		  a
+		end
+		`, "array pattern can't have more than one splat. Line: 2"},
+		{`
+		case x
+		in {"a" => 1}
+		  a
+		end
+		`, "expected a key like `name:` in hash pattern, got a instead. Line: 2"},
+		{`
+		case x
+		in ^1
+		  a
+		end
+		`, "expected a variable after ^, got 1 instead. Line: 2"},
+	}
+
+	for i, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		_, err := p.ParseProgram()
+
+		if err == nil {
+			t.Fatalf("At case %d expect a syntax error", i)
+		}
+
+		if err.Message != tt.expected {
+			t.Fatalf("At case %d expect error message to be %q. got=%q", i, tt.expected, err.Message)
+		}
+	}
+}
+
 func TestMethodParameterParsing(t *testing.T) {
 	tests := []struct {
 		input          string
@@ -732,6 +1187,186 @@ func TestCallExpressionWithBlock(t *testing.T) {
 	testMethodName(t, exp, "puts")
 }
 
+func TestCallExpressionWithBraceBlock(t *testing.T) {
+	tests := []struct {
+		input      string
+		method     string
+		blockParam string
+	}{
+		{`[1, 2].each { |i| puts(i) }`, "each", "i"},
+		{`foo(1) { |i| puts(i) }`, "foo", "i"},
+		{`foo { |i| puts(i) }`, "foo", "i"},
+		{`x = foo { |i| puts(i) }`, "foo", "i"},
+	}
+
+	for i, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program, err := p.ParseProgram()
+
+		if err != nil {
+			t.Fatalf("At case %d got error: %s", i, err.Message)
+		}
+
+		exp := program.Statements[0].(*ast.ExpressionStatement).Expression
+
+		if assign, ok := exp.(*ast.AssignExpression); ok {
+			exp = assign.Value
+		}
+
+		callExpression := exp.(*ast.CallExpression)
+		testMethodName(t, callExpression, tt.method)
+		testIdentifier(t, callExpression.BlockArguments[0], tt.blockParam)
+
+		blockExp := callExpression.Block.Statements[0].(*ast.ExpressionStatement).Expression
+		testMethodName(t, blockExp, "puts")
+	}
+}
+
+func TestBraceBlockInCallArguments(t *testing.T) {
+	tests := []struct {
+		input     string
+		method    string
+		arguments int
+	}{
+		{`puts([1, 2].map { |x| id x }.to_s)`, "id", 1},
+		{`puts(f { raise "A" })`, "raise", 1},
+		{`puts(f { foo 1, 2 })`, "foo", 2},
+	}
+
+	for i, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program, err := p.ParseProgram()
+
+		if err != nil {
+			t.Fatalf("At case %d got error: %s", i, err.Message)
+		}
+
+		callExpression := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.CallExpression)
+		argument := callExpression.Arguments[0].(*ast.CallExpression)
+
+		// `[1, 2].map { ... }.to_s` has the block call as its receiver
+		if argument.Block == nil {
+			argument = argument.Receiver.(*ast.CallExpression)
+		}
+
+		blockExp := argument.Block.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.CallExpression)
+		testMethodName(t, blockExp, tt.method)
+
+		if len(blockExp.Arguments) != tt.arguments {
+			t.Fatalf("At case %d expect %d arguments. got=%d", i, tt.arguments, len(blockExp.Arguments))
+		}
+	}
+}
+
+func TestLambdaExpression(t *testing.T) {
+	tests := []struct {
+		input  string
+		params []string
+	}{
+		{`->(x, y) { x + y }`, []string{"x", "y"}},
+		{`->() { 1 }`, []string{}},
+		{`-> { 1 }`, []string{}},
+		{`->(x) do
+		  x
+		end`, []string{"x"}},
+	}
+
+	for i, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program, err := p.ParseProgram()
+
+		if err != nil {
+			t.Fatalf("At case %d got error: %s", i, err.Message)
+		}
+
+		callExpression := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.CallExpression)
+		testMethodName(t, callExpression, "lambda")
+
+		if _, ok := callExpression.Receiver.(*ast.SelfExpression); !ok {
+			t.Fatalf("At case %d expect receiver to be SelfExpression. got=%T", i, callExpression.Receiver)
+		}
+
+		if len(callExpression.BlockArguments) != len(tt.params) {
+			t.Fatalf("At case %d expect %d block parameters. got=%d", i, len(tt.params), len(callExpression.BlockArguments))
+		}
+
+		for j, param := range tt.params {
+			testIdentifier(t, callExpression.BlockArguments[j], param)
+		}
+
+		if callExpression.Block == nil {
+			t.Fatalf("At case %d expect lambda to have a block", i)
+		}
+	}
+}
+
+func TestCallExpressionWithBlockPass(t *testing.T) {
+	tests := []struct {
+		input     string
+		argCount  int
+		blockPass string
+	}{
+		{`foo(&blk)`, 0, "blk"},
+		{`foo(1, 2, &blk)`, 2, "blk"},
+		{`a.map(&:to_s)`, 0, ":to_s"},
+	}
+
+	for i, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program, err := p.ParseProgram()
+
+		if err != nil {
+			t.Fatalf("At case %d got error: %s", i, err.Message)
+		}
+
+		callExpression := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.CallExpression)
+
+		if len(callExpression.Arguments) != tt.argCount {
+			t.Fatalf("At case %d expect %d arguments. got=%d", i, tt.argCount, len(callExpression.Arguments))
+		}
+
+		if callExpression.BlockPass == nil || callExpression.BlockPass.String() != tt.blockPass {
+			t.Fatalf("At case %d expect block argument to be %s. got=%v", i, tt.blockPass, callExpression.BlockPass)
+		}
+	}
+}
+
+func TestBlockArgumentSyntaxError(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`foo(&blk, 1)`, "Block argument should be the last argument. Line: 0"},
+		{`foo(&blk) do
+		  1
+		end`, "Both block argument and block are given. Line: 0"},
+		{`def foo(&blk, a); end`, "Normal argument \"a\" should be defined before Block argument. Line: 0"},
+		{`def foo(&a, &b); end`, "Can't define block argument more than once. Line: 0"},
+		{`def foo(&:a); end`, "Block argument should be an identifier. got: :a. Line: 0"},
+		{`def foo
+		  yield(&blk)
+		end`, "Can't pass block argument to yield. Line: 1"},
+	}
+
+	for i, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		_, err := p.ParseProgram()
+
+		if err == nil {
+			t.Fatalf("At case %d expect an argument error", i)
+		}
+
+		if err.Message != tt.expected {
+			t.Fatalf("At case %d expect error message to be %q. got=%q", i, tt.expected, err.Message)
+		}
+	}
+}
+
 func TestAssignInfixExpressionWithLiteralValue(t *testing.T) {
 	tests := []struct {
 		input              string
@@ -777,6 +1412,10 @@ func TestAssignIndexExpressionWithVariableValue(t *testing.T) {
 		{"Foo = @bar", "Foo", "@bar", testConstant, testInstanceVariable},
 		{"@bar = Foo", "@bar", "Foo", testInstanceVariable, testConstant},
 		{"@bar = @foo", "@bar", "@foo", testInstanceVariable, testInstanceVariable},
+		{"@@foo = y", "@@foo", "y", testClassVariable, testIdentifier},
+		{"y = @@foo", "y", "@@foo", testIdentifier, testClassVariable},
+		{"$foo = @@bar", "$foo", "@@bar", testGlobalVariable, testClassVariable},
+		{"@foo = $0", "@foo", "$0", testInstanceVariable, testGlobalVariable},
 	}
 
 	for _, tt := range tests {
@@ -831,3 +1470,149 @@ func testAssignExpression(t *testing.T, exp ast.Expression, expectedIdentifier s
 		testBoolLiteral(t, assignExp.Value, expected)
 	}
 }
+
+func TestBeginExpression(t *testing.T) {
+	input := `
+	begin
+	  x + 5
+	rescue ArgumentError, TypeError => e
+	  retry
+	rescue
+	  y
+	else
+	  z
+	ensure
+	  x - 1
+	end
+	`
+
+	l := lexer.New(input)
+	p := New(l)
+	program, err := p.ParseProgram()
+
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	exp, ok := stmt.Expression.(*ast.BeginExpression)
+
+	if !ok {
+		t.Fatalf("expect statement to be a BeginExpression. got=%T", stmt.Expression)
+	}
+
+	body := exp.Body.Statements[0].(*ast.ExpressionStatement)
+	testInfixExpression(t, body.Expression, "x", "+", 5)
+
+	if len(exp.Rescues) != 2 {
+		t.Fatalf("expect the length of rescue clauses to be 2. got=%d", len(exp.Rescues))
+	}
+
+	r0 := exp.Rescues[0]
+
+	if len(r0.Exceptions) != 2 {
+		t.Fatalf("expect first rescue clause to have 2 exceptions. got=%d", len(r0.Exceptions))
+	}
+
+	testConstant(t, r0.Exceptions[0], "ArgumentError")
+	testConstant(t, r0.Exceptions[1], "TypeError")
+	testIdentifier(t, r0.Variable, "e")
+
+	if _, ok := r0.Body.Statements[0].(*ast.RetryStatement); !ok {
+		t.Fatalf("expect first rescue clause's body to be a RetryStatement. got=%T", r0.Body.Statements[0])
+	}
+
+	r1 := exp.Rescues[1]
+
+	if len(r1.Exceptions) != 0 || r1.Variable != nil {
+		t.Fatalf("expect second rescue clause to have no exceptions and variable. got=%s", r1.String())
+	}
+
+	testIdentifier(t, exp.Else.Statements[0].(*ast.ExpressionStatement).Expression, "z")
+	testInfixExpression(t, exp.Ensure.Statements[0].(*ast.ExpressionStatement).Expression, "x", "-", 1)
+}
+
+func TestBeginExpressionSyntaxError(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`
+		begin
+		  x
+		else
+		  y
+		end
+		`, "else without rescue is useless. Line: 3"},
+		{`
+		retry
+		`, "retry can only be used in rescue clause. Line: 1"},
+		{`
+		unless x
+		  y
+		elsif z
+		  w
+		end
+		`, "unless can't have elsif clauses. Line: 3"},
+	}
+
+	for i, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		_, err := p.ParseProgram()
+
+		if err == nil {
+			t.Fatalf("At case %d expect a syntax error", i)
+		}
+
+		if err.Message != tt.expected {
+			t.Fatalf("At case %d expect error message to be %q. got=%q", i, tt.expected, err.Message)
+		}
+	}
+}
+
+func TestSuperExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		argCount int
+		implicit bool
+		hasBlock bool
+	}{
+		{`super`, 0, true, false},
+		{`super()`, 0, false, false},
+		{`super(1, 2)`, 2, false, false},
+		{`super 1, 2`, 2, false, false},
+		{`super { 1 }`, 0, true, true},
+		{`super(1) do |x|
+		  x
+		end`, 1, false, true},
+	}
+
+	for i, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program, err := p.ParseProgram()
+
+		if err != nil {
+			t.Fatalf("At case %d got error: %s", i, err.Message)
+		}
+
+		superExpression, ok := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.SuperExpression)
+
+		if !ok {
+			t.Fatalf("At case %d expect SuperExpression. got=%T", i, program.Statements[0].(*ast.ExpressionStatement).Expression)
+		}
+
+		if len(superExpression.Arguments) != tt.argCount {
+			t.Fatalf("At case %d expect %d arguments. got=%d", i, tt.argCount, len(superExpression.Arguments))
+		}
+
+		if superExpression.ImplicitArguments != tt.implicit {
+			t.Fatalf("At case %d expect implicit arguments to be %t", i, tt.implicit)
+		}
+
+		if (superExpression.Block != nil) != tt.hasBlock {
+			t.Fatalf("At case %d expect block existence to be %t", i, tt.hasBlock)
+		}
+	}
+}
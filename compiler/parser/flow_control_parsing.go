@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"fmt"
+
 	"github.com/goby-lang/goby/compiler/ast"
 	"github.com/goby-lang/goby/compiler/token"
 )
@@ -19,18 +21,28 @@ import (
 // is the same with if expression below
 //
 // ```ruby
-// if 1 == 0 || 1 == 1
+// if 0 === 1 || 1 === 1
 //  '0 or 1'
 // else
 //  'else'
 // end
 // ```
 //
-// TODO Implement '===' method and replace '==' to '===' in Case expression
+// So `when` values can match the objects in their own ways, like `when 1..5`, `when String` or `when /foo/`.
+//
+// A case expression with `in` clauses is parsed as a CaseInExpression for pattern matching instead.
 
 func (p *Parser) parseCaseExpression() ast.Expression {
-	ie := &ast.IfExpression{BaseNode: &ast.BaseNode{Token: p.curToken}}
-	ie.Conditionals = p.parseCaseConditionals()
+	tok := p.curToken
+	p.nextToken()
+	base := p.parseExpression(NORMAL)
+
+	if p.peekTokenIs(token.In) {
+		return p.parseCaseInExpression(tok, base)
+	}
+
+	ie := &ast.IfExpression{BaseNode: &ast.BaseNode{Token: tok}}
+	ie.Conditionals = p.parseCaseConditionals(base)
 
 	if p.curTokenIs(token.Else) {
 		ie.Alternative = p.parseBlockStatement(token.End)
@@ -41,10 +53,7 @@ func (p *Parser) parseCaseExpression() ast.Expression {
 }
 
 // case expression parsing helpers
-func (p *Parser) parseCaseConditionals() []*ast.ConditionalExpression {
-	p.nextToken()
-	base := p.parseExpression(NORMAL)
-
+func (p *Parser) parseCaseConditionals(base ast.Expression) []*ast.ConditionalExpression {
 	p.expectPeek(token.When)
 	ce := []*ast.ConditionalExpression{}
 
@@ -68,14 +77,14 @@ func (p *Parser) parseCaseConditional(base ast.Expression) *ast.ConditionalExpre
 
 func (p *Parser) parseCaseCondition(base ast.Expression) *ast.InfixExpression {
 	first := p.parseExpression(NORMAL)
-	infix := newInfixExpression(base, token.Token{Type: token.Eq, Literal: token.Eq}, first)
+	infix := newInfixExpression(first, token.Token{Type: token.CaseEq, Literal: token.CaseEq, Line: p.curToken.Line}, base)
 
 	for p.peekTokenIs(token.Comma) {
 		p.nextToken()
 		p.nextToken()
 
 		right := p.parseExpression(NORMAL)
-		rightInfix := newInfixExpression(base, token.Token{Type: token.Eq, Literal: token.Eq}, right)
+		rightInfix := newInfixExpression(right, token.Token{Type: token.CaseEq, Literal: token.CaseEq, Line: p.curToken.Line}, base)
 		infix = newInfixExpression(infix, token.Token{Type: token.Or, Literal: token.Or}, rightInfix)
 	}
 
@@ -100,6 +109,50 @@ func (p *Parser) parseIfExpression() ast.Expression {
 	return ie
 }
 
+// Ternary expression is a shorter if expression like `foo ? bar : baz`, it's right associative so
+// `a ? b : c ? d : e` means `a ? b : (c ? d : e)`
+func (p *Parser) parseTernaryExpression(condition ast.Expression) ast.Expression {
+	exp := &ast.TernaryExpression{BaseNode: &ast.BaseNode{Token: p.curToken}, Condition: condition}
+
+	p.nextToken()
+	exp.Consequence = p.parseExpression(ASSIGN)
+
+	if !p.expectPeek(token.Colon) {
+		return nil
+	}
+
+	p.nextToken()
+	exp.Alternative = p.parseExpression(ASSIGN)
+
+	return exp
+}
+
+// Unless expression runs its body when the condition is falsy, it can have an `else` body but no `elsif` clauses
+//
+// ```ruby
+// unless foo
+//   bar
+// else
+//   baz
+// end
+// ```
+func (p *Parser) parseUnlessExpression() ast.Expression {
+	ie := &ast.IfExpression{BaseNode: &ast.BaseNode{Token: p.curToken}}
+	ie.Conditionals = []*ast.ConditionalExpression{p.parseConditionalExpression()}
+
+	if p.curTokenIs(token.ElsIf) {
+		p.error = &Error{Message: fmt.Sprintf("unless can't have elsif clauses. Line: %d", p.curToken.Line), errType: SyntaxError}
+		return nil
+	}
+
+	if p.curTokenIs(token.Else) {
+		ie.Alternative = p.parseBlockStatement(token.End)
+		ie.Alternative.KeepLastValue()
+	}
+
+	return ie
+}
+
 // infix expression parsing helpers
 func (p *Parser) parseConditionalExpressions() []*ast.ConditionalExpression {
 	// first conditional expression should start with if
@@ -121,3 +174,84 @@ func (p *Parser) parseConditionalExpression() *ast.ConditionalExpression {
 
 	return ce
 }
+
+// Begin expression handles errors raised in its body
+//
+// ```ruby
+// begin
+//   foo
+// rescue ArgumentError, TypeError => e
+//   bar(e)
+// rescue
+//   retry
+// else
+//   baz
+// ensure
+//   qux
+// end
+// ```
+//
+// `rescue` clauses are tried in order, a clause without error classes rescues any error.
+// The `else` body runs when no error is raised, and the `ensure` body always runs.
+func (p *Parser) parseBeginExpression() ast.Expression {
+	be := &ast.BeginExpression{BaseNode: &ast.BaseNode{Token: p.curToken}}
+	be.Body = p.parseBlockStatement(token.Rescue, token.Else, token.Ensure, token.End)
+	be.Body.KeepLastValue()
+
+	p.parseRescueClauses(be)
+
+	return be
+}
+
+// parseRescueClauses parses the rest of a begin expression when the current token is `rescue`, `else`, `ensure` or `end`.
+func (p *Parser) parseRescueClauses(be *ast.BeginExpression) {
+	for p.curTokenIs(token.Rescue) {
+		be.Rescues = append(be.Rescues, p.parseRescueClause())
+	}
+
+	if p.curTokenIs(token.Else) {
+		if len(be.Rescues) == 0 {
+			p.error = &Error{Message: fmt.Sprintf("else without rescue is useless. Line: %d", p.curToken.Line), errType: SyntaxError}
+			return
+		}
+
+		be.Else = p.parseBlockStatement(token.Ensure, token.End)
+		be.Else.KeepLastValue()
+	}
+
+	if p.curTokenIs(token.Ensure) {
+		be.Ensure = p.parseBlockStatement(token.End)
+	}
+}
+
+func (p *Parser) parseRescueClause() *ast.RescueClause {
+	rc := &ast.RescueClause{BaseNode: &ast.BaseNode{Token: p.curToken}}
+
+	// rescue Foo, Bar
+	if p.peekTokenAtSameLine() && !p.peekTokenIs(token.HashRocket) && !p.peekTokenIs(token.Then) && !p.peekTokenIs(token.Semicolon) {
+		p.nextToken()
+		rc.Exceptions = p.parseCallArguments()
+	}
+
+	// rescue => e
+	if p.peekTokenIs(token.HashRocket) {
+		p.nextToken()
+
+		if !p.expectPeek(token.Ident) {
+			return rc
+		}
+
+		rc.Variable = &ast.Identifier{BaseNode: &ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal}
+	}
+
+	if p.peekTokenIs(token.Then) {
+		p.nextToken()
+	}
+
+	p.rescueDepth++
+	rc.Body = p.parseBlockStatement(token.Rescue, token.Else, token.Ensure, token.End)
+	rc.Body.KeepLastValue()
+	p.rescueDepth--
+
+	return rc
+}
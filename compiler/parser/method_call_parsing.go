@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"fmt"
+
 	"github.com/goby-lang/goby/compiler/ast"
 	"github.com/goby-lang/goby/compiler/token"
 )
@@ -30,8 +32,9 @@ func (p *Parser) parseCallExpressionWithoutReceiver(receiver ast.Expression) ast
 	}
 
 	p.fsm.Event(eventTable[oldState])
+	p.parseBlockPass(exp)
 
-	if p.peekTokenIs(token.Do) && p.acceptBlock { // foo do
+	if p.peekBlockStart() { // foo do || foo {
 		p.parseBlockArgument(exp)
 	}
 
@@ -39,7 +42,7 @@ func (p *Parser) parseCallExpressionWithoutReceiver(receiver ast.Expression) ast
 }
 
 func (p *Parser) parseCallExpressionWithReceiver(receiver ast.Expression) ast.Expression {
-	exp := &ast.CallExpression{BaseNode: &ast.BaseNode{}}
+	exp := &ast.CallExpression{BaseNode: &ast.BaseNode{}, SafeNavigation: p.curTokenIs(token.SafeNavigation)}
 
 	oldState := p.fsm.Current()
 	p.fsm.Event(parseFuncCall)
@@ -72,15 +75,45 @@ func (p *Parser) parseCallExpressionWithReceiver(receiver ast.Expression) ast.Ex
 	}
 
 	p.fsm.Event(eventTable[oldState])
+	p.parseBlockPass(exp)
 
 	// Parse block
-	if p.peekTokenIs(token.Do) && p.acceptBlock {
+	if p.peekBlockStart() {
 		p.parseBlockArgument(exp)
 	}
 
 	return exp
 }
 
+// parseSuperExpression parses `super`, `super(x)` and `super x`, bare `super` passes the current method's arguments
+func (p *Parser) parseSuperExpression() ast.Expression {
+	exp := &ast.SuperExpression{
+		CallExpression: &ast.CallExpression{BaseNode: &ast.BaseNode{Token: p.curToken}, Method: "super", Arguments: []ast.Expression{}},
+	}
+
+	oldState := p.fsm.Current()
+	p.fsm.Event(parseFuncCall)
+
+	if p.peekTokenIs(token.LParen) {
+		p.nextToken()
+		exp.Arguments = p.parseCallArgumentsWithParens()
+	} else if arguments[p.peekToken.Type] && p.peekTokenAtSameLine() {
+		p.nextToken()
+		exp.Arguments = p.parseCallArguments()
+	} else {
+		exp.ImplicitArguments = true
+	}
+
+	p.fsm.Event(eventTable[oldState])
+	p.parseBlockPass(exp.CallExpression)
+
+	if p.peekBlockStart() {
+		p.parseBlockArgument(exp.CallExpression)
+	}
+
+	return exp
+}
+
 func (p *Parser) parseCallArgumentsWithParens() []ast.Expression {
 	args := []ast.Expression{}
 
@@ -103,44 +136,127 @@ func (p *Parser) parseCallArgumentsWithParens() []ast.Expression {
 func (p *Parser) parseCallArguments() []ast.Expression {
 	args := []ast.Expression{}
 
-	args = append(args, p.parseExpression(NORMAL))
+	args = append(args, p.parseBlockPassOrExpression())
 
 	for p.peekTokenIs(token.Comma) {
 		p.nextToken() // ","
 		p.nextToken() // start of next expression
-		args = append(args, p.parseExpression(NORMAL))
+		args = append(args, p.parseBlockPassOrExpression())
 	}
 
 	return args
 }
 
+// parseBlockPassOrExpression parses an argument or a parameter, which can also be a block one like `&blk`
+func (p *Parser) parseBlockPassOrExpression() ast.Expression {
+	if p.curTokenIs(token.Amp) {
+		return p.parsePrefixExpression()
+	}
+
+	return p.parseExpression(NORMAL)
+}
+
+// parseBlockPass moves the `&blk` argument out of the call's arguments, it must be the last one
+func (p *Parser) parseBlockPass(exp *ast.CallExpression) {
+	for i, arg := range exp.Arguments {
+		pe, ok := arg.(*ast.PrefixExpression)
+
+		if !ok || pe.Operator != "&" {
+			continue
+		}
+
+		if i != len(exp.Arguments)-1 {
+			p.error = &Error{Message: fmt.Sprintf("Block argument should be the last argument. Line: %d", pe.Line()), errType: ArgumentError}
+			return
+		}
+
+		exp.BlockPass = pe.Right
+		exp.Arguments = exp.Arguments[:i]
+	}
+}
+
+// peekBlockStart checks if the next token starts a block: `do` or a `{` at the same line
+func (p *Parser) peekBlockStart() bool {
+	return (p.peekTokenIs(token.Do) && p.acceptBlock) || p.peekBraceBlockStart()
+}
+
+func (p *Parser) peekBraceBlockStart() bool {
+	return p.peekTokenIs(token.LBrace) && p.peekTokenAtSameLine() && p.acceptBlock
+}
+
 func (p *Parser) parseBlockArgument(exp *ast.CallExpression) {
 	p.nextToken()
 
+	if exp.BlockPass != nil {
+		p.error = &Error{Message: fmt.Sprintf("Both block argument and block are given. Line: %d", p.curToken.Line), errType: ArgumentError}
+		return
+	}
+
+	// Block can be written as `do ... end` or `{ ... }`
+	var endToken token.Type = token.End
+
+	if p.curTokenIs(token.LBrace) {
+		endToken = token.RBrace
+	}
+
 	// Parse block arguments
 	if p.peekTokenIs(token.Bar) {
-		var params []*ast.Identifier
+		p.nextToken()
+		exp.BlockArguments = p.parseBlockParameters()
+
+		if !p.expectPeek(token.Bar) {
+			return
+		}
+	}
+
+	exp.Block = p.parseBlockStatement(endToken)
+	exp.Block.KeepLastValue()
+}
+
+// parseBlockParameters parses the comma separated parameters of a block, starting from the token before the first one
+func (p *Parser) parseBlockParameters() []*ast.Identifier {
+	var params []*ast.Identifier
+
+	p.nextToken()
+	params = append(params, &ast.Identifier{BaseNode: &ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal})
 
+	for p.peekTokenIs(token.Comma) {
 		p.nextToken()
 		p.nextToken()
+		params = append(params, &ast.Identifier{BaseNode: &ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal})
+	}
 
-		param := &ast.Identifier{BaseNode: &ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal}
-		params = append(params, param)
+	return params
+}
 
-		for p.peekTokenIs(token.Comma) {
-			p.nextToken()
-			p.nextToken()
-			param := &ast.Identifier{BaseNode: &ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal}
-			params = append(params, param)
+// parseLambdaExpression parses a lambda literal like `->(x) { x }` into a `lambda` call with a block
+func (p *Parser) parseLambdaExpression() ast.Expression {
+	selfTok := token.Token{Type: token.Self, Literal: "self", Line: p.curToken.Line}
+	exp := &ast.CallExpression{
+		BaseNode:  &ast.BaseNode{Token: p.curToken},
+		Receiver:  &ast.SelfExpression{BaseNode: &ast.BaseNode{Token: selfTok}},
+		Method:    "lambda",
+		Arguments: []ast.Expression{},
+	}
+
+	if p.peekTokenIs(token.LParen) {
+		p.nextToken()
+
+		if !p.peekTokenIs(token.RParen) {
+			exp.BlockArguments = p.parseBlockParameters()
 		}
 
-		if !p.expectPeek(token.Bar) {
-			return
+		if !p.expectPeek(token.RParen) {
+			return nil
 		}
+	}
 
-		exp.BlockArguments = params
+	if !p.peekTokenIs(token.LBrace) && !p.peekTokenIs(token.Do) {
+		p.peekError(token.LBrace)
+		return nil
 	}
 
-	exp.Block = p.parseBlockStatement(token.End)
-	exp.Block.KeepLastValue()
+	p.parseBlockArgument(exp)
+
+	return exp
 }
@@ -61,6 +61,8 @@ type Parser struct {
 	// currently only used when parsing while statement.
 	// However, this is not a very good practice should change it in the future.
 	acceptBlock bool
+	// Counts the rescue clauses being parsed, `retry` is only allowed inside them.
+	rescueDepth int
 	fsm         *fsm.FSM
 	Mode        int
 }
@@ -76,6 +78,7 @@ const (
 	SplatArg
 	RequiredKeywordArg
 	OptionalKeywordArg
+	BlockArg
 )
 
 // These are state machine's events
@@ -107,6 +110,7 @@ var argTable = map[int]string{
 	RequiredKeywordArg: "Keyword argument",
 	OptionalKeywordArg: "Optioned keyword argument",
 	SplatArg:           "Splat argument",
+	BlockArg:           "Block argument",
 }
 
 // New initializes a parser and returns it
@@ -131,22 +135,35 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerPrefix(token.Ident, p.parseIdentifier)
 	p.registerPrefix(token.Constant, p.parseConstant)
 	p.registerPrefix(token.InstanceVariable, p.parseInstanceVariable)
+	p.registerPrefix(token.ClassVariable, p.parseClassVariable)
+	p.registerPrefix(token.GlobalVariable, p.parseGlobalVariable)
 	p.registerPrefix(token.Int, p.parseIntegerLiteral)
+	p.registerPrefix(token.Float, p.parseFloatLiteral)
 	p.registerPrefix(token.String, p.parseStringLiteral)
+	p.registerPrefix(token.InterpolationStart, p.parseInterpolatedString)
+	p.registerPrefix(token.Symbol, p.parseSymbolLiteral)
+	p.registerPrefix(token.Regexp, p.parseRegexpLiteral)
+	p.registerPrefix(token.WordArray, p.parseWordArray)
+	p.registerPrefix(token.SymbolArray, p.parseWordArray)
 	p.registerPrefix(token.True, p.parseBooleanLiteral)
 	p.registerPrefix(token.False, p.parseBooleanLiteral)
 	p.registerPrefix(token.Null, p.parseNilExpression)
 	p.registerPrefix(token.Minus, p.parsePrefixExpression)
 	p.registerPrefix(token.Asterisk, p.parsePrefixExpression)
 	p.registerPrefix(token.Bang, p.parsePrefixExpression)
+	p.registerPrefix(token.Tilde, p.parsePrefixExpression)
 	p.registerPrefix(token.LParen, p.parseGroupedExpression)
 	p.registerPrefix(token.If, p.parseIfExpression)
+	p.registerPrefix(token.Unless, p.parseUnlessExpression)
 	p.registerPrefix(token.Case, p.parseCaseExpression)
 	p.registerPrefix(token.Self, p.parseSelfExpression)
 	p.registerPrefix(token.LBracket, p.parseArrayExpression)
 	p.registerPrefix(token.LBrace, p.parseHashExpression)
 	p.registerPrefix(token.Semicolon, p.parseSemicolon)
 	p.registerPrefix(token.Yield, p.parseYieldExpression)
+	p.registerPrefix(token.Begin, p.parseBeginExpression)
+	p.registerPrefix(token.Arrow, p.parseLambdaExpression)
+	p.registerPrefix(token.Super, p.parseSuperExpression)
 
 	p.infixParseFns = make(map[token.Type]infixParseFn)
 	p.registerInfix(token.Plus, p.parseInfixExpression)
@@ -157,6 +174,7 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerInfix(token.Slash, p.parseInfixExpression)
 	p.registerInfix(token.Pow, p.parseInfixExpression)
 	p.registerInfix(token.Eq, p.parseInfixExpression)
+	p.registerInfix(token.CaseEq, p.parseInfixExpression)
 	p.registerInfix(token.NotEq, p.parseInfixExpression)
 	p.registerInfix(token.Match, p.parseInfixExpression)
 	p.registerInfix(token.LT, p.parseInfixExpression)
@@ -164,6 +182,11 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerInfix(token.GT, p.parseInfixExpression)
 	p.registerInfix(token.GTE, p.parseInfixExpression)
 	p.registerInfix(token.COMP, p.parseInfixExpression)
+	p.registerInfix(token.Bar, p.parseInfixExpression)
+	p.registerInfix(token.Caret, p.parseInfixExpression)
+	p.registerInfix(token.Amp, p.parseInfixExpression)
+	p.registerInfix(token.LShift, p.parseInfixExpression)
+	p.registerInfix(token.RShift, p.parseInfixExpression)
 	p.registerInfix(token.And, p.parseInfixExpression)
 	p.registerInfix(token.Or, p.parseInfixExpression)
 	p.registerInfix(token.OrEq, p.parseAssignExpression)
@@ -172,6 +195,8 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerInfix(token.Assign, p.parseAssignExpression)
 	p.registerInfix(token.Range, p.parseRangeExpression)
 	p.registerInfix(token.Dot, p.parseCallExpressionWithReceiver)
+	p.registerInfix(token.SafeNavigation, p.parseCallExpressionWithReceiver)
+	p.registerInfix(token.Question, p.parseTernaryExpression)
 	p.registerInfix(token.LParen, p.parseCallExpressionWithoutReceiver)
 	p.registerInfix(token.LBracket, p.parseIndexExpression)
 	p.registerInfix(token.Colon, p.parsePairExpression)
@@ -269,6 +294,20 @@ func (p *Parser) peekTokenAtSameLine() bool {
 	return p.curToken.Line == p.peekToken.Line && p.peekToken.Type != token.EOF
 }
 
+// peekTokenIsModifier tells if the next token is a trailing modifier like the `if` in `return if foo`
+func (p *Parser) peekTokenIsModifier() bool {
+	if !p.peekTokenAtSameLine() {
+		return false
+	}
+
+	switch p.peekToken.Type {
+	case token.If, token.Unless, token.While, token.Until:
+		return true
+	}
+
+	return false
+}
+
 func (p *Parser) peekError(t token.Type) {
 	msg := fmt.Sprintf("expected next token to be %s, got %s instead. Line: %d", t, p.peekToken.Type, p.peekToken.Line)
 	p.error = &Error{Message: msg, errType: UnexpectedTokenError}
@@ -151,6 +151,42 @@ func TestOperatorPrecedenceParsing(t *testing.T) {
 			"n.add(a + b + c * d / f + g)",
 			"n.add((((a + b) + ((c * d) / f)) + g))",
 		},
+		{
+			"a || b ? c + 1 : d",
+			"((a || b) ? (c + 1) : d)",
+		},
+		{
+			"a ? b : c ? d : e",
+			"(a ? b : (c ? d : e))",
+		},
+		{
+			"a ? b ? c : d : e",
+			"(a ? (b ? c : d) : e)",
+		},
+		{
+			"n&.add(a ? 1 : 2).b",
+			"n&.add((a ? 1 : 2)).b()",
+		},
+		{
+			"a | b & c ^ d",
+			"((a | (b & c)) ^ d)",
+		},
+		{
+			"a << 1 + 2 >> b",
+			"((a << (1 + 2)) >> b)",
+		},
+		{
+			"a & 1 << 2 == b",
+			"((a & (1 << 2)) == b)",
+		},
+		{
+			"~a | ~b",
+			"((~a) | (~b))",
+		},
+		{
+			"Integer === a + 1 && b",
+			"((Integer === (a + 1)) && b)",
+		},
 	}
 
 	for _, tt := range tests {
@@ -269,6 +305,34 @@ func testConstant(t *testing.T, exp ast.Expression, value string) bool {
 	return true
 }
 
+func testClassVariable(t *testing.T, exp ast.Expression, value string) bool {
+	classVar, ok := exp.(*ast.ClassVariable)
+	if !ok {
+		t.Errorf("exp not *ast.ClassVariable. got=%T", exp)
+		return false
+	}
+	if classVar.Value != value {
+		t.Errorf("classVar.Value not %s. got=%s", value, classVar.Value)
+		return false
+	}
+
+	return true
+}
+
+func testGlobalVariable(t *testing.T, exp ast.Expression, value string) bool {
+	globalVar, ok := exp.(*ast.GlobalVariable)
+	if !ok {
+		t.Errorf("exp not *ast.GlobalVariable. got=%T", exp)
+		return false
+	}
+	if globalVar.Value != value {
+		t.Errorf("globalVar.Value not %s. got=%s", value, globalVar.Value)
+		return false
+	}
+
+	return true
+}
+
 func testInstanceVariable(t *testing.T, exp ast.Expression, value string) bool {
 	instVar, ok := exp.(*ast.InstanceVariable)
 	if !ok {
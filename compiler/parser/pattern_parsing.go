@@ -0,0 +1,302 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/goby-lang/goby/compiler/ast"
+	"github.com/goby-lang/goby/compiler/token"
+)
+
+// Case expression with `in` clauses matches the value against patterns
+//
+// ```ruby
+// case response
+// in {status: "ok", data: [first, *rest]}
+//   first
+// in {status: "error", message:} if message != ""
+//   message
+// in [1 | 2 => n, ^limit]
+//   n
+// else
+//   nil
+// end
+// ```
+//
+// The brackets of the top level array or hash pattern can be omitted, like `in first, *rest` or `in status:, data:`.
+func (p *Parser) parseCaseInExpression(tok token.Token, subject ast.Expression) ast.Expression {
+	ce := &ast.CaseInExpression{BaseNode: &ast.BaseNode{Token: tok}, Subject: subject}
+	p.nextToken()
+
+	for p.curTokenIs(token.In) {
+		clause := p.parseInClause()
+
+		if p.error != nil {
+			return nil
+		}
+
+		ce.Clauses = append(ce.Clauses, clause)
+	}
+
+	if p.curTokenIs(token.Else) {
+		ce.Alternative = p.parseBlockStatement(token.End)
+		ce.Alternative.KeepLastValue()
+	}
+
+	return ce
+}
+
+func (p *Parser) parseInClause() *ast.InClause {
+	ic := &ast.InClause{BaseNode: &ast.BaseNode{Token: p.curToken}}
+	p.nextToken()
+
+	ic.Pattern = p.parseTopLevelPattern()
+
+	if p.error != nil {
+		return nil
+	}
+
+	if p.peekTokenAtSameLine() && (p.peekTokenIs(token.If) || p.peekTokenIs(token.Unless)) {
+		p.nextToken()
+		ic.UnlessGuard = p.curTokenIs(token.Unless)
+		p.nextToken()
+		ic.Guard = p.parseExpression(NORMAL)
+	}
+
+	if p.peekTokenIs(token.Then) {
+		p.nextToken()
+	}
+
+	ic.Consequence = p.parseBlockStatement(token.In, token.Else, token.End)
+	ic.Consequence.KeepLastValue()
+
+	return ic
+}
+
+func (p *Parser) parseTopLevelPattern() ast.Expression {
+	// in status:, data:
+	if p.curTokenIs(token.Ident) && p.peekTokenIs(token.Colon) {
+		pattern := &ast.HashPattern{BaseNode: &ast.BaseNode{Token: p.curToken}}
+		p.parseHashPatternPairs(pattern)
+		return pattern
+	}
+
+	first := p.parseArrayPatternElement()
+
+	if _, ok := first.(*ast.SplatPattern); !ok && !p.peekTokenIs(token.Comma) {
+		return first
+	}
+
+	// in first, *rest
+	pattern := &ast.ArrayPattern{BaseNode: &ast.BaseNode{Token: p.curToken}}
+	p.appendArrayPatternElement(pattern, first)
+
+	for p.error == nil && p.peekTokenIs(token.Comma) {
+		p.nextToken()
+		p.nextToken()
+		p.appendArrayPatternElement(pattern, p.parseArrayPatternElement())
+	}
+
+	return pattern
+}
+
+// parsePattern parses a pattern with its alternatives and the variable it's bound to, like `1 | 2 => n`
+func (p *Parser) parsePattern() ast.Expression {
+	pattern := p.parsePrimaryPattern()
+
+	if p.error != nil {
+		return nil
+	}
+
+	if p.peekTokenIs(token.Bar) {
+		ap := &ast.AlternativePattern{BaseNode: &ast.BaseNode{Token: p.peekToken}, Alternatives: []ast.Expression{pattern}}
+
+		for p.peekTokenIs(token.Bar) {
+			p.nextToken()
+			p.nextToken()
+			ap.Alternatives = append(ap.Alternatives, p.parsePrimaryPattern())
+		}
+
+		pattern = ap
+	}
+
+	if p.peekTokenIs(token.HashRocket) {
+		p.nextToken()
+		cp := &ast.CapturePattern{BaseNode: &ast.BaseNode{Token: p.curToken}, Pattern: pattern}
+
+		if !p.expectPeek(token.Ident) {
+			return nil
+		}
+
+		cp.Variable = &ast.Identifier{BaseNode: &ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal}
+		pattern = cp
+	}
+
+	return pattern
+}
+
+func (p *Parser) parsePrimaryPattern() ast.Expression {
+	switch p.curToken.Type {
+	case token.LBracket:
+		return p.parseArrayPattern()
+	case token.LBrace:
+		return p.parseHashPattern()
+	case token.Ident:
+		return &ast.Identifier{BaseNode: &ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal}
+	case token.Caret:
+		pp := &ast.PinPattern{BaseNode: &ast.BaseNode{Token: p.curToken}}
+		p.nextToken()
+
+		switch p.curToken.Type {
+		case token.Ident:
+			pp.Value = &ast.Identifier{BaseNode: &ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal}
+		case token.InstanceVariable:
+			pp.Value = p.parseInstanceVariable()
+		case token.ClassVariable:
+			pp.Value = p.parseClassVariable()
+		case token.GlobalVariable:
+			pp.Value = p.parseGlobalVariable()
+		default:
+			p.error = &Error{Message: fmt.Sprintf("expected a variable after ^, got %s instead. Line: %d", p.curToken.Literal, p.curToken.Line), errType: SyntaxError}
+			return nil
+		}
+
+		return pp
+	}
+
+	// Value patterns stop before `|`, so their ranges are parsed here
+	value := p.parseExpression(BITOR)
+
+	if p.peekTokenIs(token.Range) {
+		p.nextToken()
+		re := &ast.RangeExpression{BaseNode: &ast.BaseNode{Token: p.curToken}, Start: value}
+		p.nextToken()
+		re.End = p.parseExpression(BITOR)
+		value = re
+	}
+
+	return value
+}
+
+func (p *Parser) parseArrayPattern() ast.Expression {
+	pattern := &ast.ArrayPattern{BaseNode: &ast.BaseNode{Token: p.curToken}}
+
+	if p.peekTokenIs(token.RBracket) {
+		p.nextToken()
+		return pattern
+	}
+
+	p.nextToken()
+	p.appendArrayPatternElement(pattern, p.parseArrayPatternElement())
+
+	for p.error == nil && p.peekTokenIs(token.Comma) {
+		p.nextToken()
+		p.nextToken()
+		p.appendArrayPatternElement(pattern, p.parseArrayPatternElement())
+	}
+
+	if !p.expectPeek(token.RBracket) {
+		return nil
+	}
+
+	return pattern
+}
+
+func (p *Parser) parseArrayPatternElement() ast.Expression {
+	if !p.curTokenIs(token.Asterisk) {
+		return p.parsePattern()
+	}
+
+	sp := &ast.SplatPattern{BaseNode: &ast.BaseNode{Token: p.curToken}}
+
+	if p.peekTokenIs(token.Ident) {
+		p.nextToken()
+		sp.Variable = &ast.Identifier{BaseNode: &ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal}
+	}
+
+	return sp
+}
+
+func (p *Parser) appendArrayPatternElement(pattern *ast.ArrayPattern, element ast.Expression) {
+	if _, ok := element.(*ast.SplatPattern); ok && pattern.SplatIndex() >= 0 {
+		p.error = &Error{Message: fmt.Sprintf("array pattern can't have more than one splat. Line: %d", p.curToken.Line), errType: SyntaxError}
+		return
+	}
+
+	pattern.Elements = append(pattern.Elements, element)
+}
+
+func (p *Parser) parseHashPattern() ast.Expression {
+	pattern := &ast.HashPattern{BaseNode: &ast.BaseNode{Token: p.curToken}}
+
+	if p.peekTokenIs(token.RBrace) {
+		p.nextToken()
+		return pattern
+	}
+
+	p.nextToken()
+	p.parseHashPatternPairs(pattern)
+
+	if p.error != nil || !p.expectPeek(token.RBrace) {
+		return nil
+	}
+
+	return pattern
+}
+
+// parseHashPatternPairs parses pairs like `key: pattern` or `key:`, the latter binds the value to the variable with the key's name.
+// `**rest` or `**nil` can only be the last one.
+func (p *Parser) parseHashPatternPairs(pattern *ast.HashPattern) {
+	for {
+		if p.curTokenIs(token.Pow) {
+			p.nextToken()
+
+			switch p.curToken.Type {
+			case token.Ident:
+				pattern.Rest = &ast.Identifier{BaseNode: &ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal}
+			case token.Null:
+				pattern.NoRest = true
+			default:
+				p.error = &Error{Message: fmt.Sprintf("expected a variable or nil after **, got %s instead. Line: %d", p.curToken.Literal, p.curToken.Line), errType: SyntaxError}
+			}
+
+			return
+		}
+
+		if !p.curTokenIs(token.Ident) || !p.peekTokenIs(token.Colon) {
+			p.error = &Error{Message: fmt.Sprintf("expected a key like `name:` in hash pattern, got %s instead. Line: %d", p.curToken.Literal, p.curToken.Line), errType: SyntaxError}
+			return
+		}
+
+		key := p.curToken
+		p.nextToken()
+		pattern.Keys = append(pattern.Keys, key.Literal)
+
+		if p.hashPatternValueOmitted() {
+			pattern.Values = append(pattern.Values, &ast.Identifier{BaseNode: &ast.BaseNode{Token: key}, Value: key.Literal})
+		} else {
+			p.nextToken()
+			pattern.Values = append(pattern.Values, p.parsePattern())
+		}
+
+		if p.error != nil || !p.peekTokenIs(token.Comma) {
+			return
+		}
+
+		p.nextToken()
+		p.nextToken()
+	}
+}
+
+// hashPatternValueOmitted tells if the pair is like `key:`, when the current token is its colon
+func (p *Parser) hashPatternValueOmitted() bool {
+	if !p.peekTokenAtSameLine() {
+		return true
+	}
+
+	switch p.peekToken.Type {
+	case token.Comma, token.RBrace, token.Then, token.If, token.Unless, token.Semicolon:
+		return true
+	}
+
+	return false
+}
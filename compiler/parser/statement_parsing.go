@@ -7,37 +7,97 @@ import (
 )
 
 func (p *Parser) parseStatement() ast.Statement {
+	var stmt ast.Statement
+
 	switch p.curToken.Type {
 	case token.Return:
-		return p.parseReturnStatement()
+		stmt = p.parseReturnStatement()
 	case token.Def:
-		return p.parseDefMethodStatement()
+		stmt = p.parseDefMethodStatement()
 	case token.Comment:
 		return nil
-	case token.While:
-		return p.parseWhileStatement()
+	case token.While, token.Until:
+		stmt = p.parseWhileStatement()
 	case token.Class:
-		return p.parseClassStatement()
+		if p.peekTokenIs(token.LShift) {
+			stmt = p.parseSingletonClassStatement()
+		} else {
+			stmt = p.parseClassStatement()
+		}
 	case token.Module:
-		return p.parseModuleStatement()
+		stmt = p.parseModuleStatement()
 	case token.Next:
-		return &ast.NextStatement{BaseNode: &ast.BaseNode{Token: p.curToken}}
+		stmt = &ast.NextStatement{BaseNode: &ast.BaseNode{Token: p.curToken}}
 	case token.Break:
-		return &ast.BreakStatement{BaseNode: &ast.BaseNode{Token: p.curToken}}
+		stmt = &ast.BreakStatement{BaseNode: &ast.BaseNode{Token: p.curToken}}
+	case token.Retry:
+		stmt = p.parseRetryStatement()
 	default:
 		exp := p.parseExpressionStatement()
+		p.markExpressionStatement(exp)
+		stmt = exp
+	}
 
-		// If parseExpressionStatement got error exp.Expression would be nil
-		if exp.Expression != nil {
-			// In REPL mode everything should return a value.
-			if p.Mode == REPLMode {
-				exp.Expression.MarkAsExp()
-			} else {
-				exp.Expression.MarkAsStmt()
-			}
+	for p.error == nil && p.peekTokenIsModifier() {
+		stmt = p.parseModifier(stmt)
+	}
+
+	return stmt
+}
+
+func (p *Parser) markExpressionStatement(exp *ast.ExpressionStatement) {
+	// If parseExpressionStatement got error exp.Expression would be nil
+	if exp.Expression != nil {
+		// In REPL mode everything should return a value.
+		if p.Mode == REPLMode {
+			exp.Expression.MarkAsExp()
+		} else {
+			exp.Expression.MarkAsStmt()
 		}
+	}
+}
+
+// parseModifier wraps the statement with its trailing modifier
+//
+// ```ruby
+// return foo if bar
+// foo unless bar
+// i += 1 while i < 10
+// i -= 1 until i < 0
+// ```
+//
+// A begin expression with `while` or `until` modifier runs its body once before checking the condition.
+//
+// ```ruby
+// begin
+//   i += 1
+// end while i < 10
+// ```
+func (p *Parser) parseModifier(stmt ast.Statement) ast.Statement {
+	p.nextToken()
+	tok := p.curToken
+	p.nextToken()
+
+	condition := p.parseExpression(NORMAL)
+	body := &ast.BlockStatement{BaseNode: &ast.BaseNode{Token: tok}, Statements: []ast.Statement{stmt}}
+
+	switch tok.Type {
+	case token.If, token.Unless:
+		body.KeepLastValue()
+		ce := &ast.ConditionalExpression{BaseNode: &ast.BaseNode{Token: tok}, Condition: condition, Consequence: body}
+		ie := &ast.IfExpression{BaseNode: &ast.BaseNode{Token: tok}, Conditionals: []*ast.ConditionalExpression{ce}}
+		exp := &ast.ExpressionStatement{BaseNode: &ast.BaseNode{Token: tok}, Expression: ie}
+		p.markExpressionStatement(exp)
 
 		return exp
+	default:
+		ws := &ast.WhileStatement{BaseNode: &ast.BaseNode{Token: tok}, Condition: condition, Body: body}
+
+		if exp, ok := stmt.(*ast.ExpressionStatement); ok {
+			_, ws.PostCondition = exp.Expression.(*ast.BeginExpression)
+		}
+
+		return ws
 	}
 }
 
@@ -68,6 +128,11 @@ func (p *Parser) parseDefMethodStatement() *ast.DefStatement {
 		}
 	}
 
+	if !p.curTokenIs(token.Ident) {
+		p.error = &Error{Message: fmt.Sprintf("Invalid method name: %s. Line: %d", p.curToken.Literal, p.curToken.Line), errType: MethodDefinitionError}
+		return nil
+	}
+
 	stmt.Name = &ast.Identifier{BaseNode: &ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal}
 
 	// Setter method def foo=()
@@ -98,9 +163,20 @@ func (p *Parser) parseDefMethodStatement() *ast.DefStatement {
 	}
 
 	stmt.Parameters = params
-	stmt.BlockStatement = p.parseBlockStatement(token.End)
+	stmt.BlockStatement = p.parseBlockStatement(token.Rescue, token.Ensure, token.End)
 	stmt.BlockStatement.KeepLastValue()
 
+	// Method body with `rescue` or `ensure` is treated as a begin expression
+	if !p.curTokenIs(token.End) {
+		be := &ast.BeginExpression{BaseNode: &ast.BaseNode{Token: stmt.BlockStatement.Token}, Body: stmt.BlockStatement}
+		p.parseRescueClauses(be)
+
+		stmt.BlockStatement = &ast.BlockStatement{
+			BaseNode:   &ast.BaseNode{Token: be.Token},
+			Statements: []ast.Statement{&ast.ExpressionStatement{BaseNode: &ast.BaseNode{Token: be.Token}, Expression: be}},
+		}
+	}
+
 	return stmt
 }
 
@@ -109,7 +185,7 @@ func (p *Parser) parseParameters() []ast.Expression {
 	params := []ast.Expression{}
 
 	p.nextToken()
-	param := p.parseExpression(NORMAL)
+	param := p.parseBlockPassOrExpression()
 	params = append(params, param)
 
 	for p.peekTokenIs(token.Comma) {
@@ -121,7 +197,7 @@ func (p *Parser) parseParameters() []ast.Expression {
 			break
 		}
 
-		param := p.parseExpression(NORMAL)
+		param := p.parseBlockPassOrExpression()
 		params = append(params, param)
 	}
 
@@ -138,6 +214,7 @@ func (p *Parser) checkMethodParameters(params []ast.Expression) {
 		1 means previous arg is optioned argument
 		2 means previous arg is keyword argument
 		3 means previous arg is splat argument
+		4 means previous arg is block argument
 	*/
 	argState := NormalArg
 
@@ -155,6 +232,8 @@ func (p *Parser) checkMethodParameters(params []ast.Expression) {
 				p.error = newArgumentError(NormalArg, OptionalKeywordArg, exp.Value, p.curToken.Line)
 			case SplatArg:
 				p.error = newArgumentError(NormalArg, SplatArg, exp.Value, p.curToken.Line)
+			case BlockArg:
+				p.error = newArgumentError(NormalArg, BlockArg, exp.Value, p.curToken.Line)
 			}
 		case *ast.AssignExpression:
 			switch argState {
@@ -164,6 +243,8 @@ func (p *Parser) checkMethodParameters(params []ast.Expression) {
 				p.error = newArgumentError(OptionedArg, OptionalKeywordArg, exp.String(), p.curToken.Line)
 			case SplatArg:
 				p.error = newArgumentError(OptionedArg, SplatArg, exp.String(), p.curToken.Line)
+			case BlockArg:
+				p.error = newArgumentError(OptionedArg, BlockArg, exp.String(), p.curToken.Line)
 			}
 			argState = OptionedArg
 		case *ast.PairExpression:
@@ -173,6 +254,8 @@ func (p *Parser) checkMethodParameters(params []ast.Expression) {
 					p.error = newArgumentError(RequiredKeywordArg, OptionalKeywordArg, exp.String(), p.curToken.Line)
 				case SplatArg:
 					p.error = newArgumentError(RequiredKeywordArg, SplatArg, exp.String(), p.curToken.Line)
+				case BlockArg:
+					p.error = newArgumentError(RequiredKeywordArg, BlockArg, exp.String(), p.curToken.Line)
 				}
 
 				argState = RequiredKeywordArg
@@ -180,14 +263,29 @@ func (p *Parser) checkMethodParameters(params []ast.Expression) {
 				switch argState {
 				case SplatArg:
 					p.error = newArgumentError(OptionalKeywordArg, SplatArg, exp.String(), p.curToken.Line)
+				case BlockArg:
+					p.error = newArgumentError(OptionalKeywordArg, BlockArg, exp.String(), p.curToken.Line)
 				}
 
 				argState = OptionalKeywordArg
 			}
 		case *ast.PrefixExpression:
+			if exp.Operator == "&" {
+				if _, ok := exp.Right.(*ast.Identifier); !ok {
+					p.error = &Error{Message: fmt.Sprintf("Block argument should be an identifier. got: %s. Line: %d", exp.Right.String(), p.curToken.Line), errType: ArgumentError}
+				} else if argState == BlockArg {
+					p.error = &Error{Message: fmt.Sprintf("Can't define block argument more than once. Line: %d", p.curToken.Line), errType: ArgumentError}
+				}
+
+				argState = BlockArg
+				break
+			}
+
 			switch argState {
 			case SplatArg:
 				p.error = &Error{Message: fmt.Sprintf("Can't define splat argument more than once. Line: %d", p.curToken.Line), errType: ArgumentError}
+			case BlockArg:
+				p.error = newArgumentError(SplatArg, BlockArg, exp.String(), p.curToken.Line)
 			}
 			argState = SplatArg
 		}
@@ -232,6 +330,33 @@ func (p *Parser) parseClassStatement() *ast.ClassStatement {
 	return stmt
 }
 
+// parseSingletonClassStatement parses the singleton class body, whose methods are defined on the object itself
+//
+// ```ruby
+// class Foo
+//   class << self
+//     def bar
+//       "bar"
+//     end
+//   end
+// end
+// ```
+func (p *Parser) parseSingletonClassStatement() *ast.SingletonClassStatement {
+	stmt := &ast.SingletonClassStatement{BaseNode: &ast.BaseNode{Token: p.curToken}}
+
+	p.nextToken() // <<
+	p.nextToken()
+	stmt.Object = p.parseExpression(NORMAL)
+
+	if p.error != nil {
+		return nil
+	}
+
+	stmt.Body = p.parseBlockStatement(token.End)
+
+	return stmt
+}
+
 func (p *Parser) parseModuleStatement() *ast.ModuleStatement {
 	stmt := &ast.ModuleStatement{BaseNode: &ast.BaseNode{Token: p.curToken}}
 
@@ -248,7 +373,7 @@ func (p *Parser) parseModuleStatement() *ast.ModuleStatement {
 func (p *Parser) parseReturnStatement() *ast.ReturnStatement {
 	stmt := &ast.ReturnStatement{BaseNode: &ast.BaseNode{Token: p.curToken}}
 
-	if !p.peekTokenAtSameLine() {
+	if !p.peekTokenAtSameLine() || p.peekTokenIsModifier() {
 		null := &ast.NilExpression{BaseNode: &ast.BaseNode{Token: p.curToken}}
 		stmt.ReturnValue = null
 		return stmt
@@ -261,12 +386,27 @@ func (p *Parser) parseReturnStatement() *ast.ReturnStatement {
 	return stmt
 }
 
+func (p *Parser) parseRetryStatement() *ast.RetryStatement {
+	stmt := &ast.RetryStatement{BaseNode: &ast.BaseNode{Token: p.curToken}}
+
+	if p.rescueDepth == 0 {
+		p.error = &Error{Message: fmt.Sprintf("retry can only be used in rescue clause. Line: %d", p.curToken.Line), errType: SyntaxError}
+	}
+
+	return stmt
+}
+
 func (p *Parser) parseExpressionStatement() *ast.ExpressionStatement {
 	stmt := &ast.ExpressionStatement{BaseNode: &ast.BaseNode{Token: p.curToken}}
-	if p.curTokenIs(token.Ident) || p.curTokenIs(token.InstanceVariable) {
+	if p.curTokenIs(token.Ident) || p.curTokenIs(token.InstanceVariable) || p.curTokenIs(token.ClassVariable) || p.curTokenIs(token.GlobalVariable) {
 		// This is used for identifying method call without parens
 		// Or multiple variable assignment
 		stmt.Expression = p.parseExpression(LOWEST)
+
+		// A method definition isn't an expression, so `private def foo` can't pass the method to `private`
+		if p.error == nil && !p.curTokenIs(token.Semicolon) && p.peekTokenIs(token.Def) && p.peekTokenAtSameLine() {
+			p.error = &Error{Message: fmt.Sprintf("Method definition can't be an argument, pass the method name after the definition instead. Line: %d", p.curToken.Line), errType: SyntaxError}
+		}
 	} else {
 		stmt.Expression = p.parseExpression(NORMAL)
 	}
@@ -280,6 +420,11 @@ func (p *Parser) parseBlockStatement(endTokens ...token.Type) *ast.BlockStatemen
 	bs := &ast.BlockStatement{BaseNode: &ast.BaseNode{Token: p.curToken}}
 	bs.Statements = []ast.Statement{}
 
+	// The body is parsed as statements even if the block is in call arguments like `foo(bar { baz x })`
+	oldState := p.fsm.Current()
+	p.fsm.Event(backToNormal)
+	defer p.fsm.Event(eventTable[oldState])
+
 	p.nextToken()
 
 	if p.curTokenIs(token.Semicolon) {
@@ -300,6 +445,11 @@ ParseBlockLoop:
 		}
 		stmt := p.parseStatement()
 
+		// Stop at the first error so it isn't buried by the errors that follow it
+		if p.error != nil {
+			return bs
+		}
+
 		if stmt != nil {
 			bs.Statements = append(bs.Statements, stmt)
 		}
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"fmt"
 	"github.com/goby-lang/goby/compiler/ast"
 	"github.com/goby-lang/goby/compiler/lexer"
 	"github.com/goby-lang/goby/compiler/token"
@@ -155,6 +156,44 @@ func TestClassStatementWithInheritance(t *testing.T) {
 	}
 }
 
+func TestSingletonClassStatement(t *testing.T) {
+	input := `
+	class Foo
+	  class << self
+	    def bar
+	      @@count = 1
+	    end
+	  end
+	end
+	`
+
+	l := lexer.New(input)
+	p := New(l)
+	program, err := p.ParseProgram()
+
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+
+	classStmt := program.Statements[0].(*ast.ClassStatement)
+	stmt, ok := classStmt.Body.Statements[0].(*ast.SingletonClassStatement)
+
+	if !ok {
+		t.Fatalf("expect statement to be a singleton class statement. got=%T", classStmt.Body.Statements[0])
+	}
+
+	if _, ok := stmt.Object.(*ast.SelfExpression); !ok {
+		t.Fatalf("expect object to be self. got=%T", stmt.Object)
+	}
+
+	defStmt := stmt.Body.Statements[0].(*ast.DefStatement)
+
+	testIdentifier(t, defStmt.Name, "bar")
+
+	body := defStmt.BlockStatement.Statements[0].(*ast.ExpressionStatement)
+	testAssignExpression(t, body.Expression, "@@count", testClassVariable, 1)
+}
+
 func TestDefStatement(t *testing.T) {
 	input := `
 	def add(x, y)
@@ -197,6 +236,112 @@ func TestDefStatement(t *testing.T) {
 	testIntegerLiteral(t, secondExpressionStmt.Expression, 123)
 }
 
+func TestDefStatementWithOperatorName(t *testing.T) {
+	tests := []struct {
+		input          string
+		expectedName   string
+		expectedParams int
+	}{
+		{`def ==(other); end`, "==", 1},
+		{`def []=(key, value); end`, "[]=", 2},
+		{`def [](key); end`, "[]", 1},
+		{`def <=>(other); end`, "<=>", 1},
+		{`def <<(item); end`, "<<", 1},
+		{`def -@; end`, "-@", 0},
+		{`def +@; end`, "+@", 0},
+		{`def ~@; end`, "~", 0},
+		{`def !; end`, "!", 0},
+		{`def self.+(other); end`, "+", 1},
+	}
+
+	for i, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program, err := p.ParseProgram()
+
+		if err != nil {
+			t.Fatalf("At case %d: %s", i, err.Message)
+		}
+
+		stmt := program.Statements[0].(*ast.DefStatement)
+
+		if stmt.Name.Value != tt.expectedName {
+			t.Fatalf("At case %d: expect method name to be %s. got=%s", i, tt.expectedName, stmt.Name.Value)
+		}
+
+		if len(stmt.Parameters) != tt.expectedParams {
+			t.Fatalf("At case %d: expect %d parameters. got=%d", i, tt.expectedParams, len(stmt.Parameters))
+		}
+	}
+}
+
+func TestDefStatementWithInvalidName(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`def @; end`, "Invalid method name: @. Line: 0"},
+		{`def $; end`, "Invalid method name: $. Line: 0"},
+		{`
+		class Foo
+		  def @
+		  end
+		end
+		`, "Invalid method name: @. Line: 2"},
+	}
+
+	for i, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		_, err := p.ParseProgram()
+
+		if err == nil {
+			t.Fatalf("At case %d expect a syntax error", i)
+		}
+
+		if err.Message != tt.expected {
+			t.Fatalf("At case %d expect error message to be %q. got=%q", i, tt.expected, err.Message)
+		}
+	}
+}
+
+func TestDefStatementAsArgument(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`
+		class Foo
+		  private def bar
+		  end
+		end
+		`, "Method definition can't be an argument, pass the method name after the definition instead. Line: 2"},
+		{`protected def bar; end`, "Method definition can't be an argument, pass the method name after the definition instead. Line: 0"},
+	}
+
+	for i, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		_, err := p.ParseProgram()
+
+		if err == nil {
+			t.Fatalf("At case %d expect a syntax error", i)
+		}
+
+		if err.Message != tt.expected {
+			t.Fatalf("At case %d expect error message to be %q. got=%q", i, tt.expected, err.Message)
+		}
+	}
+
+	// Statements separated by a semicolon are still allowed
+	l := lexer.New(`private; def bar; end`)
+	p := New(l)
+
+	if _, err := p.ParseProgram(); err != nil {
+		t.Fatalf("Expect no error. got=%q", err.Message)
+	}
+}
+
 func TestDefStatementWithYield(t *testing.T) {
 	input := `
 	def foo
@@ -282,3 +427,124 @@ func TestWhileStatement(t *testing.T) {
 	secondCall := secondStmt.Expression.(*ast.AssignExpression)
 	testIdentifier(t, secondCall.Variables[0], "i")
 }
+
+func TestUntilStatement(t *testing.T) {
+	input := `
+	until i > 10 do
+	  i += 1
+	end
+	`
+
+	l := lexer.New(input)
+	p := New(l)
+	program, err := p.ParseProgram()
+
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+
+	untilStatement := program.Statements[0].(*ast.WhileStatement)
+
+	if !untilStatement.IsUntil() || untilStatement.PostCondition {
+		t.Fatalf("Expect statement to be an until loop. got=%s", untilStatement.String())
+	}
+
+	testInfixExpression(t, untilStatement.Condition, "i", ">", 10)
+
+	if len(untilStatement.Body.Statements) != 1 {
+		t.Fatalf("Expect loop body to have 1 statement. got=%d", len(untilStatement.Body.Statements))
+	}
+}
+
+func TestModifierStatement(t *testing.T) {
+	tests := []struct {
+		input       string
+		unless      bool
+		consequence string
+		returnsNil  bool
+	}{
+		{`return 1 if x`, false, "*ast.ReturnStatement", false},
+		{`return if x`, false, "*ast.ReturnStatement", true},
+		{`foo(1) unless x`, true, "*ast.ExpressionStatement", false},
+		{`next if x`, false, "*ast.NextStatement", false},
+		{`break unless x`, true, "*ast.BreakStatement", false},
+	}
+
+	for i, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program, err := p.ParseProgram()
+
+		if err != nil {
+			t.Fatalf("At case %d got error: %s", i, err.Message)
+		}
+
+		ie, ok := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.IfExpression)
+
+		if !ok {
+			t.Fatalf("At case %d expect statement to be wrapped by an IfExpression. got=%s", i, program.Statements[0].String())
+		}
+
+		c := ie.Conditionals[0]
+		testIdentifier(t, c.Condition, "x")
+
+		if c.IsUnless() != tt.unless {
+			t.Fatalf("At case %d expect IsUnless to be %t", i, tt.unless)
+		}
+
+		stmt := c.Consequence.Statements[0]
+
+		if fmt.Sprintf("%T", stmt) != tt.consequence {
+			t.Fatalf("At case %d expect consequence to be %s. got=%T", i, tt.consequence, stmt)
+		}
+
+		if rs, ok := stmt.(*ast.ReturnStatement); ok {
+			if _, isNil := rs.ReturnValue.(*ast.NilExpression); isNil != tt.returnsNil {
+				t.Fatalf("At case %d got unexpected return value %s", i, rs.ReturnValue.String())
+			}
+		}
+	}
+}
+
+func TestLoopModifierStatement(t *testing.T) {
+	tests := []struct {
+		input         string
+		until         bool
+		postCondition bool
+	}{
+		{`i += 1 while x`, false, false},
+		{`i -= 1 until x`, true, false},
+		{`
+		begin
+		  i += 1
+		end while x
+		`, false, true},
+		{`
+		begin
+		  i += 1
+		end until x
+		`, true, true},
+	}
+
+	for i, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program, err := p.ParseProgram()
+
+		if err != nil {
+			t.Fatalf("At case %d got error: %s", i, err.Message)
+		}
+
+		ws, ok := program.Statements[0].(*ast.WhileStatement)
+
+		if !ok {
+			t.Fatalf("At case %d expect statement to be a WhileStatement. got=%T", i, program.Statements[0])
+		}
+
+		testIdentifier(t, ws.Condition, "x")
+
+		if ws.IsUntil() != tt.until || ws.PostCondition != tt.postCondition {
+			t.Fatalf("At case %d got unexpected loop: %s", i, ws.String())
+		}
+	}
+}
@@ -18,10 +18,24 @@ const (
 	Constant         = "CONSTANT"
 	Ident            = "IDENT"
 	InstanceVariable = "INSTANCE_VAR"
+	ClassVariable    = "CLASS_VAR"
+	GlobalVariable   = "GLOBAL_VAR"
 	Int              = "INT"
+	Float            = "FLOAT"
 	String           = "STRING"
+	Symbol           = "SYMBOL"
+	Regexp           = "REGEXP"
 	Comment          = "COMMENT"
 
+	// Percent literals like %w[foo bar] and %i[foo bar], whose literals are the words separated by whitespaces
+	WordArray   = "WORD_ARRAY"
+	SymbolArray = "SYMBOL_ARRAY"
+
+	// String fragments around `#{...}` in a double-quoted string like "foo#{bar}baz#{qux}quux"
+	InterpolationStart = "INTERPOLATION_START" // "foo
+	InterpolationMid   = "INTERPOLATION_MID"   // baz
+	InterpolationEnd   = "INTERPOLATION_END"   // quux"
+
 	Assign   = "="
 	Plus     = "+"
 	PlusEq   = "+="
@@ -35,16 +49,24 @@ const (
 	Incr     = "++"
 	Decr     = "--"
 	And      = "&&"
+	Amp      = "&"
+	Caret    = "^"
+	Tilde    = "~"
+	Question = "?"
 	Or       = "||"
 	OrEq     = "||="
 	Modulo   = "%"
 
-	Match = "=~"
-	LT    = "<"
-	LTE   = "<="
-	GT    = ">"
-	GTE   = ">="
-	COMP  = "<=>"
+	Match      = "=~"
+	HashRocket = "=>"
+	Arrow      = "->"
+	LT         = "<"
+	LTE        = "<="
+	GT         = ">"
+	GTE        = ">="
+	COMP       = "<=>"
+	LShift     = "<<"
+	RShift     = ">>"
 
 	Comma     = ","
 	Semicolon = ";"
@@ -58,18 +80,21 @@ const (
 	LBracket = "["
 	RBracket = "]"
 
-	Eq    = "=="
-	NotEq = "!="
-	Range = ".."
+	Eq     = "=="
+	CaseEq = "==="
+	NotEq  = "!="
+	Range  = ".."
 
 	True   = "TRUE"
 	False  = "FALSE"
 	Null   = "Null"
 	If     = "IF"
+	Unless = "UNLESS"
 	ElsIf  = "ELSIF"
 	Else   = "ELSE"
 	Case   = "CASE"
 	When   = "WHEN"
+	In     = "IN"
 	Then   = "THEN"
 	Return = "RETURN"
 	Next   = "NEXT"
@@ -78,12 +103,19 @@ const (
 	Self   = "SELF"
 	End    = "END"
 	While  = "WHILE"
+	Until  = "UNTIL"
 	Do     = "DO"
 	Yield  = "YIELD"
 	Class  = "CLASS"
 	Module = "MODULE"
+	Begin  = "BEGIN"
+	Rescue = "RESCUE"
+	Ensure = "ENSURE"
+	Retry  = "RETRY"
+	Super  = "SUPER"
 
 	ResolutionOperator = "::"
+	SafeNavigation     = "&."
 )
 
 var keywords = map[string]Type{
@@ -92,21 +124,29 @@ var keywords = map[string]Type{
 	"false":  False,
 	"nil":    Null,
 	"if":     If,
+	"unless": Unless,
 	"elsif":  ElsIf,
 	"else":   Else,
 	"case":   Case,
 	"when":   When,
+	"in":     In,
 	"then":   Then,
 	"return": Return,
 	"self":   Self,
 	"end":    End,
 	"while":  While,
+	"until":  Until,
 	"do":     Do,
 	"yield":  Yield,
 	"next":   Next,
 	"class":  Class,
 	"module": Module,
 	"break":  Break,
+	"begin":  Begin,
+	"rescue": Rescue,
+	"ensure": Ensure,
+	"retry":  Retry,
+	"super":  Super,
 }
 
 // LookupIdent is used for keyword identification
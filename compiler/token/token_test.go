@@ -18,6 +18,7 @@ func TestLookupIdentTrue(t *testing.T) {
 		"elsif":  ElsIf,
 		"else":   Else,
 		"when":   When,
+		"in":     In,
 		"case":   Case,
 		"then":   Then,
 		"return": Return,
@@ -2,6 +2,8 @@ package vm
 
 import (
 	"bytes"
+	"math/rand"
+	"sort"
 	"strings"
 
 	"github.com/goby-lang/goby/vm/classes"
@@ -38,19 +40,68 @@ func builtinArrayInstanceMethods() []*BuiltinMethodObject {
 			// Retrieves an object in an array using Integer index.
 			// The index starts from 0. It returns `null` if the given index is bigger than its size.
 			//
+			// With a Range, or with a start index and a length, it returns a new array with the elements in that part of the array.
+			// It returns `null` if the start index is out of range, and an empty array if it's right after the last element.
+			//
 			// ```ruby
 			// a = [1, 2, 3, "a", "b", "c"]
-			// a[0]  # => 1
-			// a[3]  # => "a"
-			// a[10] # => nil
-			// a[-1] # => "c"
-			// a[-3] # => "a"
-			// a[-7] # => nil
+			// a[0]     # => 1
+			// a[3]     # => "a"
+			// a[10]    # => nil
+			// a[-1]    # => "c"
+			// a[-3]    # => "a"
+			// a[-7]    # => nil
+			// a[1..2]  # => [2, 3]
+			// a[3..-1] # => ["a", "b", "c"]
+			// a[1, 3]  # => [2, 3, "a"]
+			// a[-2, 5] # => ["b", "c"]
+			// a[6, 1]  # => []
+			// a[7, 1]  # => nil
 			// ```
 			Name: "[]",
 			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
 					arr := receiver.(*ArrayObject)
+
+					switch len(args) {
+					case 1:
+						if r, ok := args[0].(*RangeObject); ok {
+							start, end := r.Start, r.End
+
+							if start < 0 {
+								start += len(arr.Elements)
+							}
+
+							if end < 0 {
+								end += len(arr.Elements)
+							}
+
+							if start < 0 {
+								return NULL
+							}
+
+							return arr.slice(t, start, end-start+1)
+						}
+					case 2:
+						start, ok := args[0].(*IntegerObject)
+
+						if !ok {
+							return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.IntegerClass, args[0].Class().Name)
+						}
+
+						length, ok := args[1].(*IntegerObject)
+
+						if !ok {
+							return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.IntegerClass, args[1].Class().Name)
+						}
+
+						if length.value < 0 {
+							return NULL
+						}
+
+						return arr.slice(t, start.value, length.value)
+					}
+
 					return arr.index(t, args, sourceLine)
 				}
 			},
@@ -114,6 +165,152 @@ func builtinArrayInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Returns a new array with the elements of self that aren't included in the given array.
+			// Elements are compared by their `hash` and `eql?`, like hash keys.
+			//
+			// ```ruby
+			// [1, 1, 2, 3, 4] - [1, 3] # => [2, 4]
+			// ```
+			//
+			// @param other [Array]
+			// @return [Array]
+			Name: "-",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					other, ok := args[0].(*ArrayObject)
+
+					if !ok {
+						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.ArrayClass, args[0].Class().Name)
+					}
+
+					arr := receiver.(*ArrayObject)
+					set, err := t.arraySet(other.Elements, sourceLine)
+
+					if err != nil {
+						return err
+					}
+
+					elements := []Object{}
+
+					for _, elem := range arr.Elements {
+						pair, err := set.find(t, elem, sourceLine)
+
+						if err != nil {
+							return err
+						}
+
+						if pair == nil {
+							elements = append(elements, elem)
+						}
+					}
+
+					return t.vm.initArrayObject(elements)
+				}
+			},
+		},
+		{
+			// Returns a new array with the elements that are included in both arrays, without duplicates.
+			// Elements are compared by their `hash` and `eql?`, like hash keys.
+			//
+			// ```ruby
+			// [1, 1, 2, 3] & [3, 1, 4] # => [1, 3]
+			// ```
+			//
+			// @param other [Array]
+			// @return [Array]
+			Name: "&",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					other, ok := args[0].(*ArrayObject)
+
+					if !ok {
+						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.ArrayClass, args[0].Class().Name)
+					}
+
+					arr := receiver.(*ArrayObject)
+					set, err := t.arraySet(other.Elements, sourceLine)
+
+					if err != nil {
+						return err
+					}
+
+					elements := []Object{}
+
+					for _, elem := range arr.Elements {
+						pair, err := set.delete(t, elem, sourceLine)
+
+						if err != nil {
+							return err
+						}
+
+						if pair != nil {
+							elements = append(elements, elem)
+						}
+					}
+
+					return t.vm.initArrayObject(elements)
+				}
+			},
+		},
+		{
+			// Returns a new array by joining the arrays, without duplicates.
+			// Elements are compared by their `hash` and `eql?`, like hash keys.
+			//
+			// ```ruby
+			// [1, 1, 2] | [2, 3] # => [1, 2, 3]
+			// ```
+			//
+			// @param other [Array]
+			// @return [Array]
+			Name: "|",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					other, ok := args[0].(*ArrayObject)
+
+					if !ok {
+						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.ArrayClass, args[0].Class().Name)
+					}
+
+					arr := receiver.(*ArrayObject)
+					elements := append(append([]Object{}, arr.Elements...), other.Elements...)
+
+					return t.uniqElements(elements, elements, sourceLine)
+				}
+			},
+		},
+		{
+			// Appends the given object to the array and returns the array, so appends can be chained.
+			//
+			// ```ruby
+			// a = [1, 2]
+			// a << 3       # => [1, 2, 3]
+			// a << 4 << 5  # => [1, 2, 3, 4, 5]
+			// ```
+			Name: "<<",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					arr := receiver.(*ArrayObject)
+					return arr.push(args)
+				}
+			},
+		},
 		{
 			// Assigns value to an array. It requires an index and a value as argument.
 			// The array will expand if the assigned index is bigger than its size.
@@ -269,6 +466,104 @@ func builtinArrayInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Returns an array of all combinations of the given number of elements from the array.
+			// The order of elements in each combination follows their order in the array.
+			//
+			// ```ruby
+			// [1, 2, 3].combination(2) # => [[1, 2], [1, 3], [2, 3]]
+			// [1, 2, 3].combination(0) # => [[]]
+			// [1, 2, 3].combination(4) # => []
+			// ```
+			//
+			// @param n [Integer]
+			// @return [Array]
+			Name: "combination",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					n, ok := args[0].(*IntegerObject)
+
+					if !ok {
+						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.IntegerClass, args[0].Class().Name)
+					}
+
+					arr := receiver.(*ArrayObject)
+					combinations := []Object{}
+
+					if n.value < 0 || n.value > len(arr.Elements) {
+						return t.vm.initArrayObject(combinations)
+					}
+
+					// indexes holds the positions of the current combination's elements
+					indexes := make([]int, n.value)
+
+					for i := range indexes {
+						indexes[i] = i
+					}
+
+					for {
+						combination := make([]Object, n.value)
+
+						for i, index := range indexes {
+							combination[i] = arr.Elements[index]
+						}
+
+						combinations = append(combinations, t.vm.initArrayObject(combination))
+
+						// Moves the rightmost index that can still move, and resets the indexes after it
+						i := n.value - 1
+
+						for i >= 0 && indexes[i] == len(arr.Elements)-n.value+i {
+							i--
+						}
+
+						if i < 0 {
+							break
+						}
+
+						indexes[i]++
+
+						for j := i + 1; j < n.value; j++ {
+							indexes[j] = indexes[j-1] + 1
+						}
+					}
+
+					return t.vm.initArrayObject(combinations)
+				}
+			},
+		},
+		{
+			// Returns a new array with the nil elements removed.
+			//
+			// ```ruby
+			// [1, nil, 2, nil].compact # => [1, 2]
+			// ```
+			//
+			// @return [Array]
+			Name: "compact",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					arr := receiver.(*ArrayObject)
+					elements := []Object{}
+
+					for _, elem := range arr.Elements {
+						if elem != NULL {
+							elements = append(elements, elem)
+						}
+					}
+
+					return t.vm.initArrayObject(elements)
+				}
+			},
+		},
 		{
 			// Appends any number of argument to the array.
 			//
@@ -437,6 +732,34 @@ func builtinArrayInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Returns a new array without the first n elements.
+			//
+			// ```ruby
+			// [1, 2, 3, 4].drop(2)  # => [3, 4]
+			// [1, 2, 3, 4].drop(10) # => []
+			// ```
+			//
+			// @param n [Integer]
+			// @return [Array]
+			Name: "drop",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					arr := receiver.(*ArrayObject)
+					n, err := t.countArgument(args, 0, sourceLine)
+
+					if err != nil {
+						return err
+					}
+
+					if n > len(arr.Elements) {
+						n = len(arr.Elements)
+					}
+
+					return t.vm.initArrayObject(append([]Object{}, arr.Elements[n:]...))
+				}
+			},
+		},
 		{
 			// Loop through each element with the given block.
 			//
@@ -475,6 +798,42 @@ func builtinArrayInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Yields each run of n consecutive elements as an array.
+			//
+			// ```ruby
+			// [1, 2, 3, 4].each_cons(2) do |pair|
+			//   puts(pair)
+			// end
+			// # => [1, 2]
+			// # => [2, 3]
+			// # => [3, 4]
+			// ```
+			//
+			// @param n [Integer]
+			// @return [Array] self
+			Name: "each_cons",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					arr := receiver.(*ArrayObject)
+					n, err := t.countArgument(args, 1, sourceLine)
+
+					if err != nil {
+						return err
+					}
+
+					if blockFrame == nil {
+						return t.vm.initErrorObject(errors.InternalError, sourceLine, errors.CantYieldWithoutBlockFormat)
+					}
+
+					for i := 0; i+n <= len(arr.Elements); i++ {
+						t.builtinMethodYield(blockFrame, t.vm.initArrayObject(append([]Object{}, arr.Elements[i:i+n]...)))
+					}
+
+					return arr
+				}
+			},
+		},
 		{
 			Name: "each_index",
 			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
@@ -502,44 +861,153 @@ func builtinArrayInstanceMethods() []*BuiltinMethodObject {
 			},
 		},
 		{
-			// Returns if the array"s length is 0 or not.
+			// Yields the elements in slices of n elements, the last slice can be shorter.
 			//
 			// ```ruby
-			// [1, 2, 3].empty? # => false
-			// [].empty? # => true
+			// [1, 2, 3, 4, 5].each_slice(2) do |slice|
+			//   puts(slice)
+			// end
+			// # => [1, 2]
+			// # => [3, 4]
+			// # => [5]
 			// ```
-			// @return [Boolean]
-			Name: "empty?",
+			//
+			// @param n [Integer]
+			// @return [Array] self
+			Name: "each_slice",
 			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					arr := receiver.(*ArrayObject)
+					n, err := t.countArgument(args, 1, sourceLine)
 
-					if len(args) != 0 {
-						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "Expect 0 argument. got=%d", len(args))
+					if err != nil {
+						return err
 					}
 
-					arr := receiver.(*ArrayObject)
+					if blockFrame == nil {
+						return t.vm.initErrorObject(errors.InternalError, sourceLine, errors.CantYieldWithoutBlockFormat)
+					}
 
-					if arr.length() == 0 {
-						return TRUE
+					for i := 0; i < len(arr.Elements); i += n {
+						end := i + n
+
+						if end > len(arr.Elements) {
+							end = len(arr.Elements)
+						}
+
+						t.builtinMethodYield(blockFrame, t.vm.initArrayObject(append([]Object{}, arr.Elements[i:end]...)))
 					}
 
-					return FALSE
+					return arr
 				}
 			},
 		},
 		{
-			// Returns the first element of the array.
-			Name: "first",
+			// Yields each element with its index.
+			//
+			// ```ruby
+			// ["a", "b"].each_with_index do |e, i|
+			//   puts(e + i.to_s)
+			// end
+			// # => "a0"
+			// # => "b1"
+			// ```
+			//
+			// @return [Array] self
+			Name: "each_with_index",
 			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
-					if len(args) > 1 {
-						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "Expect 0..1 argument. got=%d", len(args))
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					if blockFrame == nil {
+						return t.vm.initErrorObject(errors.InternalError, sourceLine, errors.CantYieldWithoutBlockFormat)
 					}
 
 					arr := receiver.(*ArrayObject)
-					arrLength := len(arr.Elements)
 
-					if arrLength == 0 {
+					for i, obj := range arr.Elements {
+						t.builtinMethodYield(blockFrame, obj, t.vm.initIntegerObject(i))
+					}
+
+					return arr
+				}
+			},
+		},
+		{
+			// Returns if the array"s length is 0 or not.
+			//
+			// ```ruby
+			// [1, 2, 3].empty? # => false
+			// [].empty? # => true
+			// ```
+			// @return [Boolean]
+			Name: "empty?",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "Expect 0 argument. got=%d", len(args))
+					}
+
+					arr := receiver.(*ArrayObject)
+
+					if arr.length() == 0 {
+						return TRUE
+					}
+
+					return FALSE
+				}
+			},
+		},
+		{
+			// Returns the first element that the block returns a truthy value for, or nil if there's none.
+			//
+			// ```ruby
+			// [1, 2, 3, 4].find do |e|
+			//   e > 2
+			// end
+			// # => 3
+			// ```
+			//
+			// @return [Object]
+			Name: "find",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					if blockFrame == nil {
+						return t.vm.initErrorObject(errors.InternalError, sourceLine, errors.CantYieldWithoutBlockFormat)
+					}
+
+					arr := receiver.(*ArrayObject)
+
+					for _, obj := range arr.Elements {
+						if isTruthy(t.builtinMethodYield(blockFrame, obj).Target) {
+							return obj
+						}
+					}
+
+					return NULL
+				}
+			},
+		},
+		{
+			// Returns the first element of the array.
+			Name: "first",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) > 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "Expect 0..1 argument. got=%d", len(args))
+					}
+
+					arr := receiver.(*ArrayObject)
+					arrLength := len(arr.Elements)
+
+					if arrLength == 0 {
 						return NULL
 					}
 
@@ -564,6 +1032,45 @@ func builtinArrayInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Returns a new array with the block's results, results that are arrays are joined into the new array.
+			//
+			// ```ruby
+			// [1, 2].flat_map do |e|
+			//   [e, e * 10]
+			// end
+			// # => [1, 10, 2, 20]
+			// ```
+			//
+			// @return [Array]
+			Name: "flat_map",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					if blockFrame == nil {
+						return t.vm.initErrorObject(errors.InternalError, sourceLine, errors.CantYieldWithoutBlockFormat)
+					}
+
+					arr := receiver.(*ArrayObject)
+					elements := []Object{}
+
+					for _, obj := range arr.Elements {
+						result := t.builtinMethodYield(blockFrame, obj).Target
+
+						if resultArr, ok := result.(*ArrayObject); ok {
+							elements = append(elements, resultArr.Elements...)
+						} else {
+							elements = append(elements, result)
+						}
+					}
+
+					return t.vm.initArrayObject(elements)
+				}
+			},
+		},
 		{
 			// Returns a new array that is a one-dimensional flattening of self.
 			//
@@ -589,6 +1096,105 @@ func builtinArrayInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Returns the hash value of the array, which is the same for arrays that have the same elements.
+			//
+			// ```ruby
+			// [1, "a"].hash == [1, "a"].hash # => true
+			// ```
+			//
+			// @return [Integer]
+			Name: "hash",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					return t.hashMethodResult(receiver, sourceLine)
+				}
+			},
+		},
+		{
+			// Returns true if the array has an element that is `==` to the given object.
+			//
+			// ```ruby
+			// [1, "a", [2]].include?([2]) # => true
+			// [1, 2].include?(3)          # => false
+			// ```
+			//
+			// @param object [Object]
+			// @return [Boolean]
+			Name: "include?",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					arr := receiver.(*ArrayObject)
+
+					for _, elem := range arr.Elements {
+						result := t.callMethod(elem, "==", sourceLine, args[0])
+
+						if err, ok := result.(*Error); ok {
+							return err
+						}
+
+						if isTruthy(result) {
+							return TRUE
+						}
+					}
+
+					return FALSE
+				}
+			},
+		},
+		{
+			// Returns the index of the first element that is `==` to the given object,
+			// or the first element that the block returns a truthy value for. Returns nil if there's none.
+			//
+			// ```ruby
+			// ["a", "b", "c"].index("b") # => 1
+			// [1, 2, 3].index do |e|
+			//   e > 1
+			// end
+			// # => 1
+			// ```
+			//
+			// @param object [Object]
+			// @return [Integer]
+			Name: "index",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) > 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentRangeFormat, 0, 1, len(args))
+					}
+
+					if len(args) == 0 && blockFrame == nil {
+						return t.vm.initErrorObject(errors.InternalError, sourceLine, errors.CantYieldWithoutBlockFormat)
+					}
+
+					arr := receiver.(*ArrayObject)
+
+					for i, elem := range arr.Elements {
+						var result Object
+
+						if len(args) == 1 {
+							result = t.callMethod(elem, "==", sourceLine, args[0])
+						} else {
+							result = t.builtinMethodYield(blockFrame, elem).Target
+						}
+
+						if err, ok := result.(*Error); ok {
+							return err
+						}
+
+						if isTruthy(result) {
+							return t.vm.initIntegerObject(i)
+						}
+					}
+
+					return NULL
+				}
+			},
+		},
 		{
 			// Returns a string by concatenating each element to string, separated by given separator.
 			// If separator is nil, it uses empty string.
@@ -716,6 +1322,56 @@ func builtinArrayInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Returns the largest element compared with `<=>`, or by the block's result of comparing two elements.
+			// Returns nil if the array is empty.
+			//
+			// ```ruby
+			// [3, 1, 2].max            # => 3
+			// ["bb", "a", "ccc"].max do |a, b|
+			//   b.length <=> a.length
+			// end
+			// # => "a"
+			// ```
+			//
+			// @return [Object]
+			Name: "max",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					arr := receiver.(*ArrayObject)
+					return arr.extremum(t, blockFrame, 1, sourceLine)
+				}
+			},
+		},
+		{
+			// Returns the smallest element compared with `<=>`, or by the block's result of comparing two elements.
+			// Returns nil if the array is empty.
+			//
+			// ```ruby
+			// [3, 1, 2].min            # => 1
+			// ["bb", "a", "ccc"].min do |a, b|
+			//   b.length <=> a.length
+			// end
+			// # => "ccc"
+			// ```
+			//
+			// @return [Object]
+			Name: "min",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					arr := receiver.(*ArrayObject)
+					return arr.extremum(t, blockFrame, -1, sourceLine)
+				}
+			},
+		},
 		{
 			// Removes the last element in the array and returns it.
 			//
@@ -737,6 +1393,51 @@ func builtinArrayInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Returns all combinations of an element from self and an element from each given array.
+			//
+			// ```ruby
+			// [1, 2].product(["a", "b"]) # => [[1, "a"], [1, "b"], [2, "a"], [2, "b"]]
+			// [1, 2].product             # => [[1], [2]]
+			// ```
+			//
+			// @param arrays [Array]
+			// @return [Array]
+			Name: "product",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					arr := receiver.(*ArrayObject)
+					products := [][]Object{{}}
+
+					for _, list := range append([]Object{arr}, args...) {
+						listArr, ok := list.(*ArrayObject)
+
+						if !ok {
+							return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.ArrayClass, list.Class().Name)
+						}
+
+						newProducts := [][]Object{}
+
+						for _, product := range products {
+							for _, elem := range listArr.Elements {
+								newProduct := append(append([]Object{}, product...), elem)
+								newProducts = append(newProducts, newProduct)
+							}
+						}
+
+						products = newProducts
+					}
+
+					elements := make([]Object, len(products))
+
+					for i, product := range products {
+						elements[i] = t.vm.initArrayObject(product)
+					}
+
+					return t.vm.initArrayObject(elements)
+				}
+			},
+		},
 		{
 			// Appends the given object to the array and returns the array.
 			//
@@ -906,6 +1607,46 @@ func builtinArrayInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Returns a random element, or an array of n random elements without repeating positions.
+			// Returns nil, or an empty array, if the array is empty.
+			//
+			// ```ruby
+			// [1, 2, 3].sample    # => 2
+			// [1, 2, 3].sample(2) # => [3, 1]
+			// ```
+			//
+			// @param n [Integer]
+			// @return [Object]
+			Name: "sample",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					arr := receiver.(*ArrayObject)
+
+					if len(args) == 0 {
+						if len(arr.Elements) == 0 {
+							return NULL
+						}
+
+						return arr.Elements[rand.Intn(len(arr.Elements))]
+					}
+
+					n, err := t.countArgument(args, 0, sourceLine)
+
+					if err != nil {
+						return err
+					}
+
+					shuffled := arr.shuffle()
+
+					if n > len(shuffled) {
+						n = len(shuffled)
+					}
+
+					return t.vm.initArrayObject(shuffled[:n])
+				}
+			},
+		},
 		{
 			// Loop through each element with the given block.
 			// Return a new array with each element that returns true from yield.
@@ -965,18 +1706,296 @@ func builtinArrayInstanceMethods() []*BuiltinMethodObject {
 			},
 		},
 		{
-			// Inserts the specified element in the first position of the array.
+			// Returns a new array with the elements in random order.
 			//
 			// ```ruby
-			// a = [1, 2]
-			// a.unshift(0) # => [0, 1, 2]
-			// a            # => [0, 1, 2]
+			// [1, 2, 3].shuffle # => [2, 3, 1]
 			// ```
-			Name: "unshift",
+			//
+			// @return [Array]
+			Name: "shuffle",
 			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
-					arr := receiver.(*ArrayObject)
-					return arr.unshift(args)
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					arr := receiver.(*ArrayObject)
+					return t.vm.initArrayObject(arr.shuffle())
+				}
+			},
+		},
+		{
+			// Returns a new array with the elements sorted with `<=>`, so objects that define `<=>` can be sorted.
+			// With a block, the block compares two elements and returns a negative number, 0 or a positive number like `<=>`.
+			// The sort is stable, elements that are equal keep their order.
+			//
+			// ```ruby
+			// [3, 1, 2].sort # => [1, 2, 3]
+			// ["b", "c", "a"].sort do |a, b|
+			//   b <=> a
+			// end
+			// # => ["c", "b", "a"]
+			// ```
+			//
+			// @return [Array]
+			Name: "sort",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					arr := receiver.(*ArrayObject)
+					elements := append([]Object{}, arr.Elements...)
+
+					if err := t.sortObjects(elements, blockFrame, sourceLine); err != nil {
+						return err
+					}
+
+					return t.vm.initArrayObject(elements)
+				}
+			},
+		},
+		{
+			// Returns a new array with the elements sorted by the block's results, which are compared with `<=>`.
+			// The sort is stable, elements that have equal results keep their order.
+			//
+			// ```ruby
+			// ["ccc", "a", "bb"].sort_by do |e|
+			//   e.length
+			// end
+			// # => ["a", "bb", "ccc"]
+			// ```
+			//
+			// @return [Array]
+			Name: "sort_by",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					if blockFrame == nil {
+						return t.vm.initErrorObject(errors.InternalError, sourceLine, errors.CantYieldWithoutBlockFormat)
+					}
+
+					arr := receiver.(*ArrayObject)
+					keys := make([]Object, len(arr.Elements))
+
+					for i, obj := range arr.Elements {
+						keys[i] = t.builtinMethodYield(blockFrame, obj).Target
+					}
+
+					// Sorts the positions by their keys, so the elements can be reordered in the same way
+					indexes := make([]int, len(arr.Elements))
+
+					for i := range indexes {
+						indexes[i] = i
+					}
+
+					var err *Error
+
+					sort.SliceStable(indexes, func(i, j int) bool {
+						if err != nil {
+							return false
+						}
+
+						var result int
+						result, err = t.compareObjects(keys[indexes[i]], keys[indexes[j]], sourceLine)
+						return result < 0
+					})
+
+					if err != nil {
+						return err
+					}
+
+					elements := make([]Object, len(indexes))
+
+					for i, index := range indexes {
+						elements[i] = arr.Elements[index]
+					}
+
+					return t.vm.initArrayObject(elements)
+				}
+			},
+		},
+		{
+			// Returns the sum of the elements added with `+`, starting from the given value or 0.
+			// With a block, it sums the block's results of the elements.
+			//
+			// ```ruby
+			// [1, 2, 3].sum          # => 6
+			// [1, 2, 3].sum(10)      # => 16
+			// [0.1, 0.2].sum         # => 0.3
+			// ["a", "b"].sum("")     # => "ab"
+			// [1, 2].sum do |e|
+			//   e * 10
+			// end
+			// # => 30
+			// ```
+			//
+			// @param init [Object]
+			// @return [Object]
+			Name: "sum",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) > 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentRangeFormat, 0, 1, len(args))
+					}
+
+					arr := receiver.(*ArrayObject)
+					var sum Object = t.vm.initIntegerObject(0)
+
+					if len(args) == 1 {
+						sum = args[0]
+					}
+
+					for _, obj := range arr.Elements {
+						if blockFrame != nil {
+							obj = t.builtinMethodYield(blockFrame, obj).Target
+						}
+
+						sum = t.callMethod(sum, "+", sourceLine, obj)
+
+						if err, ok := sum.(*Error); ok {
+							return err
+						}
+					}
+
+					return sum
+				}
+			},
+		},
+		{
+			// Returns a new array with the first n elements.
+			//
+			// ```ruby
+			// [1, 2, 3, 4].take(2)  # => [1, 2]
+			// [1, 2, 3, 4].take(10) # => [1, 2, 3, 4]
+			// ```
+			//
+			// @param n [Integer]
+			// @return [Array]
+			Name: "take",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					arr := receiver.(*ArrayObject)
+					n, err := t.countArgument(args, 0, sourceLine)
+
+					if err != nil {
+						return err
+					}
+
+					if n > len(arr.Elements) {
+						n = len(arr.Elements)
+					}
+
+					return t.vm.initArrayObject(append([]Object{}, arr.Elements[:n]...))
+				}
+			},
+		},
+		{
+			// Swaps the rows and the columns of an array of arrays, which must have the same length.
+			//
+			// ```ruby
+			// [[1, 2], [3, 4], [5, 6]].transpose # => [[1, 3, 5], [2, 4, 6]]
+			// ```
+			//
+			// @return [Array]
+			Name: "transpose",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					arr := receiver.(*ArrayObject)
+					rows := make([]*ArrayObject, len(arr.Elements))
+
+					for i, elem := range arr.Elements {
+						row, ok := elem.(*ArrayObject)
+
+						if !ok {
+							return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.ArrayClass, elem.Class().Name)
+						}
+
+						rows[i] = row
+
+						if len(row.Elements) != len(rows[0].Elements) {
+							return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "Expect element size to be %d. got: %d", len(rows[0].Elements), len(row.Elements))
+						}
+					}
+
+					columns := []Object{}
+
+					if len(rows) == 0 {
+						return t.vm.initArrayObject(columns)
+					}
+
+					for j := range rows[0].Elements {
+						column := make([]Object, len(rows))
+
+						for i, row := range rows {
+							column[i] = row.Elements[j]
+						}
+
+						columns = append(columns, t.vm.initArrayObject(column))
+					}
+
+					return t.vm.initArrayObject(columns)
+				}
+			},
+		},
+		{
+			// Returns a new array without duplicate elements, the first one of the duplicates is kept.
+			// Elements are compared by their `hash` and `eql?`, like hash keys.
+			// With a block, elements are compared by the block's results.
+			//
+			// ```ruby
+			// [1, 2, 1, 3, 2].uniq # => [1, 2, 3]
+			// ["a", "B", "A"].uniq do |e|
+			//   e.downcase
+			// end
+			// # => ["a", "B"]
+			// ```
+			//
+			// @return [Array]
+			Name: "uniq",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					arr := receiver.(*ArrayObject)
+					keys := arr.Elements
+
+					if blockFrame != nil {
+						keys = make([]Object, len(arr.Elements))
+
+						for i, obj := range arr.Elements {
+							keys[i] = t.builtinMethodYield(blockFrame, obj).Target
+						}
+					}
+
+					return t.uniqElements(arr.Elements, keys, sourceLine)
+				}
+			},
+		},
+		{
+			// Inserts the specified element in the first position of the array.
+			//
+			// ```ruby
+			// a = [1, 2]
+			// a.unshift(0) # => [0, 1, 2]
+			// a            # => [0, 1, 2]
+			// ```
+			Name: "unshift",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					arr := receiver.(*ArrayObject)
+					return arr.unshift(args)
 				}
 			},
 		},
@@ -1013,6 +2032,52 @@ func builtinArrayInstanceMethods() []*BuiltinMethodObject {
 						}
 					}
 
+					return t.vm.initArrayObject(elements)
+				}
+			},
+		},
+		{
+			// Merges the elements of self with the elements at the same position of the given arrays.
+			// Missing elements are filled with nil.
+			//
+			// ```ruby
+			// [1, 2, 3].zip([4, 5, 6], [7, 8]) # => [[1, 4, 7], [2, 5, 8], [3, 6, nil]]
+			// ```
+			//
+			// @param arrays [Array]
+			// @return [Array]
+			Name: "zip",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					arr := receiver.(*ArrayObject)
+					others := make([]*ArrayObject, len(args))
+
+					for i, arg := range args {
+						other, ok := arg.(*ArrayObject)
+
+						if !ok {
+							return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.ArrayClass, arg.Class().Name)
+						}
+
+						others[i] = other
+					}
+
+					elements := make([]Object, len(arr.Elements))
+
+					for i, obj := range arr.Elements {
+						tuple := []Object{obj}
+
+						for _, other := range others {
+							if i < len(other.Elements) {
+								tuple = append(tuple, other.Elements[i])
+							} else {
+								tuple = append(tuple, NULL)
+							}
+						}
+
+						elements[i] = t.vm.initArrayObject(tuple)
+					}
+
 					return t.vm.initArrayObject(elements)
 				}
 			},
@@ -1052,12 +2117,7 @@ func (a *ArrayObject) toString() string {
 
 	elements := []string{}
 	for _, e := range a.Elements {
-		_, isString := e.(*StringObject)
-		if isString {
-			elements = append(elements, "\""+e.toString()+"\"")
-		} else {
-			elements = append(elements, e.toString())
-		}
+		elements = append(elements, inspectString(e))
 	}
 
 	out.WriteString("[")
@@ -1183,7 +2243,7 @@ func (a *ArrayObject) normalizeIndex(objectIndex *IntegerObject) int {
 		return -1
 	}
 
-	if index < 0 && -index > aLength {
+	if index < -aLength {
 		return -1
 	}
 
@@ -1260,3 +2320,197 @@ func (a *ArrayObject) unshift(objs []Object) *ArrayObject {
 	a.Elements = append(objs, a.Elements...)
 	return a
 }
+
+// slice returns a new array with at most length elements from the start index, which can be negative to count from the end.
+// It returns NULL if the start index is out of range; a negative length gives an empty array.
+func (a *ArrayObject) slice(t *thread, start int, length int) Object {
+	aLength := len(a.Elements)
+
+	if start < 0 {
+		start += aLength
+	}
+
+	if start < 0 || start > aLength {
+		return NULL
+	}
+
+	end := start + length
+
+	if end > aLength {
+		end = aLength
+	}
+
+	if end < start {
+		end = start
+	}
+
+	return t.vm.initArrayObject(append([]Object{}, a.Elements[start:end]...))
+}
+
+// shuffle returns a copy of the elements in random order
+func (a *ArrayObject) shuffle() []Object {
+	elems := append([]Object{}, a.Elements...)
+
+	rand.Shuffle(len(elems), func(i, j int) {
+		elems[i], elems[j] = elems[j], elems[i]
+	})
+
+	return elems
+}
+
+// extremum returns the largest element when sign is 1, or the smallest one when sign is -1.
+// Elements are compared with `<=>`, or by yielding them to the block if it's given.
+func (a *ArrayObject) extremum(t *thread, blockFrame *normalCallFrame, sign int, sourceLine int) Object {
+	if len(a.Elements) == 0 {
+		return NULL
+	}
+
+	result := a.Elements[0]
+
+	for _, elem := range a.Elements[1:] {
+		order, err := t.compareWithBlock(elem, result, blockFrame, sourceLine)
+
+		if err != nil {
+			return err
+		}
+
+		if order*sign > 0 {
+			result = elem
+		}
+	}
+
+	return result
+}
+
+// Other helper functions ----------------------------------------------
+
+// countArgument checks that the only argument is an Integer that isn't less than min, and returns its value.
+func (t *thread) countArgument(args []Object, min int, sourceLine int) (int, *Error) {
+	if len(args) != 1 {
+		return 0, t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 1, len(args))
+	}
+
+	n, ok := args[0].(*IntegerObject)
+
+	if !ok {
+		return 0, t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.IntegerClass, args[0].Class().Name)
+	}
+
+	if n.value < min {
+		return 0, t.vm.initErrorObject(errors.ArgumentError, sourceLine, "Expect argument to be greater than or equal to %d. got: %d", min, n.value)
+	}
+
+	return n.value, nil
+}
+
+// arraySet returns a hash whose keys are the given elements, so they can be looked up by their `hash` and `eql?`.
+func (t *thread) arraySet(elements []Object, sourceLine int) (*HashObject, *Error) {
+	set := t.vm.initEmptyHashObject()
+
+	for _, elem := range elements {
+		if err := set.set(t, elem, TRUE, sourceLine); err != nil {
+			return nil, err
+		}
+	}
+
+	return set, nil
+}
+
+// uniqElements returns an array with the elements whose keys haven't appeared before, keys[i] being the key of elements[i].
+func (t *thread) uniqElements(elements []Object, keys []Object, sourceLine int) Object {
+	seen := t.vm.initEmptyHashObject()
+	result := []Object{}
+
+	for i, elem := range elements {
+		pair, err := seen.find(t, keys[i], sourceLine)
+
+		if err != nil {
+			return err
+		}
+
+		if pair != nil {
+			continue
+		}
+
+		if err := seen.set(t, keys[i], TRUE, sourceLine); err != nil {
+			return err
+		}
+
+		result = append(result, elem)
+	}
+
+	return t.vm.initArrayObject(result)
+}
+
+// sortObjects sorts the objects in place with a stable sort, comparing them with `<=>` or with the block if it's given.
+func (t *thread) sortObjects(objs []Object, blockFrame *normalCallFrame, sourceLine int) *Error {
+	var err *Error
+
+	sort.SliceStable(objs, func(i, j int) bool {
+		if err != nil {
+			return false
+		}
+
+		var order int
+		order, err = t.compareWithBlock(objs[i], objs[j], blockFrame, sourceLine)
+		return order < 0
+	})
+
+	return err
+}
+
+// compareWithBlock compares two objects by yielding them to the block, or with `<=>` if there's no block.
+func (t *thread) compareWithBlock(left, right Object, blockFrame *normalCallFrame, sourceLine int) (int, *Error) {
+	if blockFrame == nil {
+		return t.compareObjects(left, right, sourceLine)
+	}
+
+	result := t.builtinMethodYield(blockFrame, left, right).Target
+	return t.comparisonResult(result, left, right, sourceLine)
+}
+
+// compareObjects returns a negative number, 0 or a positive number when left is less than, equal to or greater than right.
+// Integers and strings are compared directly, other objects are compared by calling their `<=>` method.
+func (t *thread) compareObjects(left, right Object, sourceLine int) (int, *Error) {
+	switch l := left.(type) {
+	case *IntegerObject:
+		if r, ok := right.(*IntegerObject); ok {
+			switch {
+			case l.value < r.value:
+				return -1, nil
+			case l.value > r.value:
+				return 1, nil
+			}
+
+			return 0, nil
+		}
+	case *StringObject:
+		if r, ok := right.(*StringObject); ok {
+			return strings.Compare(l.value, r.value), nil
+		}
+	}
+
+	if left.findMethod("<=>") == nil {
+		return t.comparisonResult(NULL, left, right, sourceLine)
+	}
+
+	result := t.callMethod(left, "<=>", sourceLine, right)
+	return t.comparisonResult(result, left, right, sourceLine)
+}
+
+// comparisonResult converts the result of `<=>` to an int, it fails if the objects can't be compared.
+func (t *thread) comparisonResult(result Object, left, right Object, sourceLine int) (int, *Error) {
+	switch r := result.(type) {
+	case *Error:
+		return 0, r
+	case *IntegerObject:
+		return r.value, nil
+	}
+
+	return 0, t.vm.initErrorObject(errors.ArgumentError, sourceLine, "comparison of %s with %s failed", left.Class().Name, right.toString())
+}
+
+// isTruthy returns false for nil and false, and true for every other object
+func isTruthy(obj Object) bool {
+	return obj != NULL && obj != FALSE
+}
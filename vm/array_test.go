@@ -282,6 +282,48 @@ func TestArrayPlusOperator(t *testing.T) {
 	}
 }
 
+func TestArrayAppendOperator(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []interface{}
+	}{
+		{`[1, 2] << 3`, []interface{}{1, 2, 3}},
+		{`
+			a = []
+			a << 1 << "b"
+			a
+		`, []interface{}{1, "b"}},
+		{`
+			a = [1]
+			b = a << 2
+			b.push(3)
+			a
+		`, []interface{}{1, 2, 3}},
+	}
+
+	for i, tt := range tests {
+		vm := initTestVM()
+		evaluated := vm.testEval(t, tt.input, getFilename())
+		testArrayObject(t, i, evaluated, tt.expected)
+		vm.checkCFP(t, i, 0)
+		vm.checkSP(t, i, 1)
+	}
+}
+
+func TestArrayAppendOperatorFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`[1].send("<<", 2, 3)`, "ArgumentError: Expect 1 arguments. got: 2", 1, 2},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, tt.expectedCFP)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestArrayPlusOperatorFail(t *testing.T) {
 	testsFail := []errorTestCase{
 		{`[1, 2] + true`, "TypeError: Expect argument to be Array. got: Boolean", 1, 1},
@@ -1573,3 +1615,512 @@ func TestArrayIncludeP(t *testing.T) {
 		v.checkSP(t, i, 1)
 	}
 }
+
+func TestArrayIndexWithRangeAndLength(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`[1, 2, 3, 4, 5][1..2].to_s`, "[2, 3]"},
+		{`[1, 2, 3, 4, 5][3..-1].to_s`, "[4, 5]"},
+		{`[1, 2, 3, 4, 5][-2..-1].to_s`, "[4, 5]"},
+		{`[1, 2, 3, 4, 5][2..10].to_s`, "[3, 4, 5]"},
+		{`[1, 2, 3, 4, 5][3..1].to_s`, "[]"},
+		{`[1, 2, 3, 4, 5][5..6].to_s`, "[]"},
+		{`[1, 2, 3, 4, 5][6..7]`, nil},
+		{`[1, 2, 3, 4, 5][-6..2]`, nil},
+		{`[1, 2, 3, 4, 5][1, 3].to_s`, "[2, 3, 4]"},
+		{`[1, 2, 3, 4, 5][-2, 5].to_s`, "[4, 5]"},
+		{`[1, 2, 3, 4, 5][0, 0].to_s`, "[]"},
+		{`[1, 2, 3, 4, 5][5, 1].to_s`, "[]"},
+		{`[1, 2, 3, 4, 5][6, 1]`, nil},
+		{`[1, 2, 3, 4, 5][1, -1]`, nil},
+		{`
+		a = [1, 2, 3]
+		b = a[0, 2]
+		b.push(4)
+		a.to_s
+		`, "[1, 2, 3]"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestArrayIndexWithRangeAndLengthFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`[1, 2]["a", 1]`, "TypeError: Expect argument to be Integer. got: String", 1, 1},
+		{`[1, 2][1, "a"]`, "TypeError: Expect argument to be Integer. got: String", 1, 1},
+		{`[1, 2][1, 2, 3]`, "ArgumentError: Expect 1 arguments. got=3", 1, 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, tt.expectedCFP)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestArraySetOperators(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`([1, 1, 2, 3, 4] - [1, 3]).to_s`, "[2, 4]"},
+		{`([1, "a", [2]] - ["a", [2]]).to_s`, "[1]"},
+		{`([] - [1]).to_s`, "[]"},
+		{`([1, 1, 2, 3] & [3, 1, 4]).to_s`, "[1, 3]"},
+		{`([1, 2] & []).to_s`, "[]"},
+		{`([1, 2, 2] | [2, 3, 1]).to_s`, "[1, 2, 3]"},
+		{`([] | []).to_s`, "[]"},
+		{`
+		class Point
+		  attr_reader :x
+		  def initialize(x)
+		    @x = x
+		  end
+		  def hash
+		    @x
+		  end
+		  def eql?(other)
+		    @x == other.x
+		  end
+		end
+
+		([Point.new(1), Point.new(2)] - [Point.new(1)]).length
+		`, 1},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestArraySetOperatorsFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`[1] - 1`, "TypeError: Expect argument to be Array. got: Integer", 1, 1},
+		{`[1] & "a"`, "TypeError: Expect argument to be Array. got: String", 1, 1},
+		{`[1] | nil`, "TypeError: Expect argument to be Array. got: Null", 1, 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, tt.expectedCFP)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestArraySortMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`[3, 1, 2].sort.to_s`, "[1, 2, 3]"},
+		{`["b", "c", "a"].sort.to_s`, `["a", "b", "c"]`},
+		{`[2.5, 1, 1.5].sort.to_s`, "[1, 1.5, 2.5]"},
+		{`[].sort.to_s`, "[]"},
+		{`
+		a = [3, 1, 2]
+		a.sort
+		a.to_s
+		`, "[3, 1, 2]"},
+		{`
+		["b", "c", "a"].sort do |a, b|
+		  b <=> a
+		end.to_s
+		`, `["c", "b", "a"]`},
+		{`
+		[[2, "a"], [1, "b"], [2, "c"], [1, "d"]].sort do |a, b|
+		  a[0] <=> b[0]
+		end.to_s
+		`, `[[1, "b"], [1, "d"], [2, "a"], [2, "c"]]`},
+		{`
+		class Version
+		  attr_reader :major
+		  def initialize(major)
+		    @major = major
+		  end
+		  def <=>(other)
+		    @major <=> other.major
+		  end
+		end
+
+		[Version.new(3), Version.new(1), Version.new(2)].sort.map do |v|
+		  v.major
+		end.to_s
+		`, "[1, 2, 3]"},
+		{`
+		["ccc", "a", "bb"].sort_by do |e|
+		  e.length
+		end.to_s
+		`, `["a", "bb", "ccc"]`},
+		{`
+		["bb", "a", "cc", "d"].sort_by do |e|
+		  e.length
+		end.to_s
+		`, `["a", "d", "bb", "cc"]`},
+		{`
+		[].sort_by do |e|
+		  e
+		end.to_s
+		`, "[]"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestArraySortMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`[Object.new, Object.new].sort`, "ArgumentError: comparison of Object with <Instance of: Object> failed", 1, 1},
+		{`[1, 2].sort do |a, b|
+		  nil
+		end`, "ArgumentError: comparison of Integer with 1 failed", 1, 2},
+		{`[1, 2].sort(1)`, "ArgumentError: Expect 0 arguments. got: 1", 1, 1},
+		{`[1, 2].sort_by`, "InternalError: Can't yield without a block", 1, 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, tt.expectedCFP)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestArrayMinMaxMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`[3, 1, 2].max`, 3},
+		{`[3, 1, 2].min`, 1},
+		{`["b", "c", "a"].max`, "c"},
+		{`[].max`, nil},
+		{`[].min`, nil},
+		{`
+		["bb", "a", "ccc"].max do |a, b|
+		  a.length <=> b.length
+		end
+		`, "ccc"},
+		{`
+		["bb", "a", "ccc"].min do |a, b|
+		  a.length <=> b.length
+		end
+		`, "a"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestArraySumMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`[1, 2, 3].sum`, 6},
+		{`[1, 2, 3].sum(10)`, 16},
+		{`[].sum`, 0},
+		{`[0.5, 0.25].sum`, 0.75},
+		{`["a", "b"].sum("")`, "ab"},
+		{`([[1], [2]].sum([])).to_s`, "[1, 2]"},
+		{`
+		[1, 2].sum do |e|
+		  e * 10
+		end
+		`, 30},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestArraySumMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`["a"].sum`, "TypeError: Expect argument to be Numeric. got: String", 1, 2},
+		{`[1].sum(1, 2)`, "ArgumentError: Expect 0 to 1 arguments. got: 2", 1, 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, tt.expectedCFP)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestArrayUniqMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`[1, 2, 1, 3, 2].uniq.to_s`, "[1, 2, 3]"},
+		{`[1, "1", :a, "a", [1], [1]].uniq.to_s`, `[1, "1", :a, "a", [1]]`},
+		{`[].uniq.to_s`, "[]"},
+		{`
+		["a", "B", "A", "b"].uniq do |e|
+		  e.downcase
+		end.to_s
+		`, `["a", "B"]`},
+		{`[1, nil, 2, nil].compact.to_s`, "[1, 2]"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestArrayCombiningMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`[1, 2, 3].zip([4, 5, 6], [7, 8]).to_s`, "[[1, 4, 7], [2, 5, 8], [3, 6, nil]]"},
+		{`[1, 2].zip.to_s`, "[[1], [2]]"},
+		{`[[1, 2], [3, 4], [5, 6]].transpose.to_s`, "[[1, 3, 5], [2, 4, 6]]"},
+		{`[].transpose.to_s`, "[]"},
+		{`[1, 2].product([3, 4]).to_s`, "[[1, 3], [1, 4], [2, 3], [2, 4]]"},
+		{`[1, 2].product([3], [4, 5]).to_s`, "[[1, 3, 4], [1, 3, 5], [2, 3, 4], [2, 3, 5]]"},
+		{`[1, 2].product([]).to_s`, "[]"},
+		{`[1, 2, 3].combination(2).to_s`, "[[1, 2], [1, 3], [2, 3]]"},
+		{`[1, 2, 3].combination(0).to_s`, "[[]]"},
+		{`[1, 2, 3].combination(4).to_s`, "[]"},
+		{`
+		[[1, 2], [3]].flat_map do |e|
+		  e
+		end.to_s
+		`, "[1, 2, 3]"},
+		{`
+		[1, 2].flat_map do |e|
+		  [e, e * 10]
+		end.to_s
+		`, "[1, 10, 2, 20]"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestArrayCombiningMethodsFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`[1].zip(1)`, "TypeError: Expect argument to be Array. got: Integer", 1, 1},
+		{`[1, [2]].transpose`, "TypeError: Expect argument to be Array. got: Integer", 1, 1},
+		{`[[1, 2], [3]].transpose`, "ArgumentError: Expect element size to be 2. got: 1", 1, 1},
+		{`[1].product("a")`, "TypeError: Expect argument to be Array. got: String", 1, 1},
+		{`[1].combination`, "ArgumentError: Expect 1 arguments. got: 0", 1, 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, tt.expectedCFP)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestArrayIteratingMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		result = []
+		[1, 2, 3, 4, 5].each_slice(2) do |slice|
+		  result.push(slice)
+		end
+		result.to_s
+		`, "[[1, 2], [3, 4], [5]]"},
+		{`
+		result = []
+		[1, 2, 3].each_cons(2) do |a, b|
+		  result.push(a + b)
+		end
+		result.to_s
+		`, "[3, 5]"},
+		{`
+		result = []
+		[1, 2].each_cons(3) do |c|
+		  result.push(c)
+		end
+		result.to_s
+		`, "[]"},
+		{`
+		result = []
+		["a", "b"].each_with_index do |e, i|
+		  result.push(e + i.to_s)
+		end
+		result.to_s
+		`, `["a0", "b1"]`},
+		{`
+		[].each_with_index do |e, i|
+		  e
+		end.to_s
+		`, "[]"},
+		{`
+		[1, 2, 3, 4].find do |e|
+		  e > 2
+		end
+		`, 3},
+		{`
+		[1, 2].find do |e|
+		  e > 2
+		end
+		`, nil},
+		{`["a", "b", "c"].index("b")`, 1},
+		{`["a", "b", "c"].index("d")`, nil},
+		{`
+		[1, 2, 3].index do |e|
+		  e > 1
+		end
+		`, 1},
+		{`[1, "a", [2]].include?([2])`, true},
+		{`[1, 2].include?(3)`, false},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestArrayIteratingMethodsFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`[1].each_slice(0) do |s|
+		end`, "ArgumentError: Expect argument to be greater than or equal to 1. got: 0", 1, 2},
+		{`[1].each_cons(2)`, "InternalError: Can't yield without a block", 1, 1},
+		{`[1].index`, "InternalError: Can't yield without a block", 1, 1},
+		{`[1].index(1, 2)`, "ArgumentError: Expect 0 to 1 arguments. got: 2", 1, 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, tt.expectedCFP)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestArrayTakeDropMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`[1, 2, 3, 4].take(2).to_s`, "[1, 2]"},
+		{`[1, 2, 3, 4].take(10).to_s`, "[1, 2, 3, 4]"},
+		{`[1, 2, 3, 4].take(0).to_s`, "[]"},
+		{`[1, 2, 3, 4].drop(3).to_s`, "[4]"},
+		{`[1, 2, 3, 4].drop(10).to_s`, "[]"},
+		{`[1, 2, 3].sample(2).length`, 2},
+		{`[1, 2, 3].sample(5).sort.to_s`, "[1, 2, 3]"},
+		{`[].sample`, nil},
+		{`[1, 2, 3].include?([1, 2, 3].sample)`, true},
+		{`[1, 2, 3].shuffle.sort.to_s`, "[1, 2, 3]"},
+		{`
+		a = [1, 2, 3]
+		a.shuffle
+		a.to_s
+		`, "[1, 2, 3]"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestArrayTakeDropMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`[1].take(-1)`, "ArgumentError: Expect argument to be greater than or equal to 0. got: -1", 1, 1},
+		{`[1].drop("a")`, "TypeError: Expect argument to be Integer. got: String", 1, 1},
+		{`[1].sample(1, 2)`, "ArgumentError: Expect 1 arguments. got: 2", 1, 1},
+		{`[1].shuffle(1)`, "ArgumentError: Expect 0 arguments. got: 1", 1, 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, tt.expectedCFP)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestArrayHashMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`[1, "a", :b].hash == [1, "a", :b].hash`, true},
+		{`[1, 2].hash == [2, 1].hash`, false},
+		{`[].hash == [].hash`, true},
+		{`
+		class Foo
+		  def initialize(id)
+		    @id = id
+		  end
+
+		  def hash
+		    @id.hash
+		  end
+		end
+
+		[Foo.new(1)].hash == [Foo.new(1)].hash
+		`, true},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
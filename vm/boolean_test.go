@@ -186,7 +186,7 @@ func TestBooleanAssignmentByOperation(t *testing.T) {
 		{`a = false; a ||= "string";  a;`, "string"},
 		{`a = false; a ||= false;     a;`, false},
 		{`a = false; a ||= (1..4);    a.to_s;`, "(1..4)"},
-		{`a = false; a ||= { b: 1 };  a["b"];`, 1},
+		{`a = false; a ||= { b: 1 };  a[:b];`, 1},
 		{`a = false; a ||= Object;    a.name;`, "Object"},
 		{`a = false; a ||= [1, 2, 3]; a[0];`, 1},
 		{`a = false; a ||= [1, 2, 3]; a[1];`, 2},
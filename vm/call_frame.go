@@ -55,6 +55,39 @@ type normalCallFrame struct {
 	instructionSet *instructionSet
 	// program counter
 	pc int
+	// rescue handlers set by begin expressions, the innermost one is the last
+	rescueHandlers []*rescueHandler
+	// set on a lambda's block frame, so a `return` in the lambda only leaves the lambda
+	isLambda bool
+}
+
+// rescueHandler records where to resume and how to restore the thread when an error is rescued
+type rescueHandler struct {
+	pc  int
+	sp  int
+	cfp int
+	// the error being rescued, the handler can't rescue other errors until it's popped
+	err *Error
+	// set by `set_ensure`, the handler runs an ensure body
+	ensure bool
+	// the `return` in a block that runs the ensure body, it continues after the body
+	blockReturn *blockReturn
+}
+
+// available returns true if the handler isn't handling an error or a return
+func (h *rescueHandler) available() bool {
+	return h.err == nil && h.blockReturn == nil
+}
+
+// ensureHandler returns the innermost available handler that runs an ensure body
+func (n *normalCallFrame) ensureHandler() *rescueHandler {
+	for i := len(n.rescueHandlers) - 1; i >= 0; i-- {
+		if h := n.rescueHandlers[i]; h.ensure && h.available() {
+			return h
+		}
+	}
+
+	return nil
 }
 
 func (n *normalCallFrame) instructionsCount() int {
@@ -49,8 +49,15 @@ func (co *callObject) methodName() string {
 }
 
 func (co *callObject) argTypes() []int {
-	if co.argSet == nil {
-		return []int{}
+	// Arguments passed without types, like from `send` or `method_missing`, are all normal arguments
+	if co.argSet == nil || len(co.argSet.Types()) == 0 {
+		types := make([]int, co.argCount)
+
+		for i := range types {
+			types[i] = bytecode.NormalArg
+		}
+
+		return types
 	}
 
 	return co.argSet.Types()
@@ -120,6 +127,11 @@ func (co *callObject) assignKeywordArguments(stack []*Pointer) (err error) {
 func (co *callObject) assignSplatArgument(stack []*Pointer, arr *ArrayObject) {
 	index := len(co.paramTypes()) - 1
 
+	// Block parameter is the only one that can be defined after splat parameter
+	if co.method.isBlockArgIncluded() {
+		index--
+	}
+
 	for co.argIndex < co.argCount {
 		arr.Elements = append(arr.Elements, stack[co.argPosition()].Target)
 		co.argIndex++
@@ -128,6 +140,21 @@ func (co *callObject) assignSplatArgument(stack []*Pointer, arr *ArrayObject) {
 	co.callFrame.insertLCL(index, 0, arr)
 }
 
+// assignBlockArgument assigns the method's block as a proc to the block parameter, or nil if there's no block
+func (co *callObject) assignBlockArgument(vm *VM) {
+	for paramIndex, paramType := range co.paramTypes() {
+		if paramType == bytecode.BlockArg {
+			var block Object = NULL
+
+			if co.callFrame.blockFrame != nil {
+				block = vm.initProcObject(co.callFrame.blockFrame, false)
+			}
+
+			co.callFrame.insertLCL(paramIndex, 0, block)
+		}
+	}
+}
+
 func (co *callObject) hasKeywordParam(name string) (index int, result bool) {
 	for paramIndex, paramType := range co.paramTypes() {
 		paramName := co.paramNames()[paramIndex]
@@ -5,12 +5,16 @@ import (
 	"io/ioutil"
 	"path"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/goby-lang/goby/vm/classes"
 	"github.com/goby-lang/goby/vm/errors"
 )
 
+// methodMissing is the name of the method that's called when the receiver doesn't have the called method
+const methodMissing = "method_missing"
+
 // RClass represents normal (not built in) class object
 type RClass struct {
 	// Name is the class's name
@@ -27,12 +31,55 @@ type RClass struct {
 	isModule    bool
 	constants   map[string]*Pointer
 	scope       *RClass
+	// defaultVisibility is the visibility of methods defined after `public`, `private` or `protected` without arguments
+	defaultVisibility int
+	// classVariables holds variables like `@@count`, it's created when the first one is assigned
+	classVariables      *environment
+	classVariablesMutex sync.RWMutex
+	// includedModule is the module that a proxy class created by `include` or `extend` stands for
+	includedModule *RClass
 	*baseObj
 }
 
 // Class methods --------------------------------------------------------
 func builtinClassCommonClassMethods() []*BuiltinMethodObject {
 	return []*BuiltinMethodObject{
+		{
+			// Returns true if the object is an instance of the receiver or its subclasses, or the modules they include.
+			// It's used by `case` expressions to match objects with classes.
+			//
+			// ```ruby
+			// Integer === 1    # => true
+			// Object === "a"   # => true
+			// String === 1     # => false
+			//
+			// case 1
+			// when String then "string"
+			// when Integer then "integer"
+			// end              # => "integer"
+			// ```
+			//
+			// @param object [Object]
+			// @return [Boolean]
+			Name: "===",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					c := receiver.(*RClass)
+
+					for _, ancestor := range args[0].Class().ancestors() {
+						if ancestor == c {
+							return TRUE
+						}
+					}
+
+					return FALSE
+				}
+			},
+		},
 		{
 			// Returns an array that contains ancestor classes/modules of the receiver,
 			// left to right.
@@ -87,11 +134,11 @@ func builtinClassCommonClassMethods() []*BuiltinMethodObject {
 		{
 			// Creates instance variables and corresponding methods that return the value of
 			// each instance variable and assign an argument to each instance variable.
-			// Only string literal can be used for now.
+			// Attribute names can be symbols or strings. The methods are private or protected after `private` or `protected` like the ones defined with `def`.
 			//
 			// ```ruby
 			// class Foo
-			//   attr_accessor("bar", "buz")
+			//   attr_accessor(:bar, :buz)
 			// end
 			// ```
 			// is equivalent to:
@@ -113,11 +160,15 @@ func builtinClassCommonClassMethods() []*BuiltinMethodObject {
 			// end
 			// ```
 			//
-			// @param *args [String] One or more quoted method names for 'getter/setter'
+			// @param *args [Symbol/String] One or more method names for 'getter/setter'
 			// @return [Null]
 			Name: "attr_accessor",
 			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if err := t.checkAttrNames(args, sourceLine); err != nil {
+						return err
+					}
+
 					r := receiver.(*RClass)
 					r.setAttrAccessor(args)
 
@@ -129,11 +180,11 @@ func builtinClassCommonClassMethods() []*BuiltinMethodObject {
 			// Creates instance variables and corresponding methods that return the value of each
 			// instance variable.
 			//
-			// Only string literal can be used for now.
+			// Attribute names can be symbols or strings. The methods are private or protected after `private` or `protected` like the ones defined with `def`.
 			//
 			// ```ruby
 			// class Foo
-			//   attr_reader("bar", "buz")
+			//   attr_reader(:bar, :buz)
 			// end
 			// ```
 			// is equivalent to:
@@ -149,11 +200,15 @@ func builtinClassCommonClassMethods() []*BuiltinMethodObject {
 			// end
 			// ```
 			//
-			// @param *args [String] One or more quoted method names for 'getter'
+			// @param *args [Symbol/String] One or more method names for 'getter'
 			// @return [Null]
 			Name: "attr_reader",
 			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if err := t.checkAttrNames(args, sourceLine); err != nil {
+						return err
+					}
+
 					r := receiver.(*RClass)
 					r.setAttrReader(args)
 
@@ -165,11 +220,11 @@ func builtinClassCommonClassMethods() []*BuiltinMethodObject {
 			// Creates instance variables and corresponding methods that assign an argument to each
 			// instance variable. No return value.
 			//
-			// Only string literal can be used for now.
+			// Attribute names can be symbols or strings. The methods are private or protected after `private` or `protected` like the ones defined with `def`.
 			//
 			// ```ruby
 			// class Foo
-			//   attr_writer("bar", "buz")
+			//   attr_writer(:bar, :buz)
 			// end
 			// ```
 			// is equivalent to:
@@ -185,11 +240,15 @@ func builtinClassCommonClassMethods() []*BuiltinMethodObject {
 			// end
 			// ```
 			//
-			// @param *args [String] One or more quoted method names for 'setter'
+			// @param *args [Symbol/String] One or more method names for 'setter'
 			// @return [Null]
 			Name: "attr_writer",
 			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if err := t.checkAttrNames(args, sourceLine); err != nil {
+						return err
+					}
+
 					r := receiver.(*RClass)
 					r.setAttrWriter(args)
 
@@ -236,13 +295,7 @@ func builtinClassCommonClassMethods() []*BuiltinMethodObject {
 					}
 
 					class = receiver.SingletonClass()
-
-					if class.alreadyInherit(module) {
-						return class
-					}
-
-					module.superClass = class.superClass
-					class.superClass = module
+					class.includeModule(module)
 
 					return class
 				}
@@ -310,12 +363,7 @@ func builtinClassCommonClassMethods() []*BuiltinMethodObject {
 						class = r.SingletonClass()
 					}
 
-					if class.alreadyInherit(module) {
-						return class
-					}
-
-					module.superClass = class.superClass
-					class.superClass = module
+					class.includeModule(module)
 
 					return class
 				}
@@ -388,6 +436,102 @@ func builtinClassCommonClassMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Makes the given instance methods private, or makes the methods defined after it private without any arguments.
+			// Private methods can only be called without a receiver or on `self`, but `send` can still call them.
+			// Since a method definition isn't an expression, `private def foo` is a syntax error, use `private :foo` after the definition.
+			//
+			// ```ruby
+			// class Foo
+			//   def bar
+			//     baz
+			//   end
+			//
+			//   private
+			//
+			//   def baz
+			//     10
+			//   end
+			// end
+			//
+			// Foo.new.bar           # => 10
+			// Foo.new.baz           # => UndefinedMethodError: Private method 'baz' called for <Instance of: Foo>
+			// Foo.new.send(:baz)    # => 10
+			// ```
+			//
+			// @param method names [Symbol/String]
+			// @return [Object] the given method names
+			Name: "private",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					return t.setMethodVisibility(receiver, args, privateMethod, sourceLine)
+				}
+			},
+		},
+		{
+			// Makes the given instance methods protected, or makes the methods defined after it protected without any arguments.
+			// Protected methods can only be called by instances of the class (or module) that defines them.
+			//
+			// ```ruby
+			// class Foo
+			//   def initialize(n)
+			//     @n = n
+			//   end
+			//
+			//   def bigger?(other)
+			//     n > other.n
+			//   end
+			//
+			//   def n
+			//     @n
+			//   end
+			//
+			//   protected :n
+			// end
+			//
+			// Foo.new(2).bigger?(Foo.new(1)) # => true
+			// Foo.new(2).n                   # => UndefinedMethodError: Protected method 'n' called for <Instance of: Foo>
+			// ```
+			//
+			// @param method names [Symbol/String]
+			// @return [Object] the given method names
+			Name: "protected",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					return t.setMethodVisibility(receiver, args, protectedMethod, sourceLine)
+				}
+			},
+		},
+		{
+			// Makes the given instance methods public, or makes the methods defined after it public without any arguments.
+			//
+			// ```ruby
+			// class Foo
+			//   private
+			//
+			//   def bar
+			//     10
+			//   end
+			//
+			//   public
+			//
+			//   def baz
+			//     bar
+			//   end
+			// end
+			//
+			// Foo.new.baz # => 10
+			// ```
+			//
+			// @param method names [Symbol/String]
+			// @return [Object] the given method names
+			Name: "public",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					return t.setMethodVisibility(receiver, args, publicMethod, sourceLine)
+				}
+			},
+		},
 		{
 			// Returns the superclass object of the receiver.
 			//
@@ -470,13 +614,13 @@ func builtinClassCommonInstanceMethods() []*BuiltinMethodObject {
 			Name: "==",
 			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
-					className := receiver.Class().Name
-					compareClassName := args[0].Class().Name
+					equal, err := t.objectsEqual(receiver, args[0], sourceLine)
 
-					if className == compareClassName && reflect.DeepEqual(receiver, args[0]) {
-						return TRUE
+					if err != nil {
+						return err
 					}
-					return FALSE
+
+					return toBooleanObject(equal)
 				}
 			},
 		},
@@ -499,7 +643,8 @@ func builtinClassCommonInstanceMethods() []*BuiltinMethodObject {
 			},
 		},
 		{
-			// General method for comparing inequality of the objects
+			// General method for comparing inequality of the objects, it returns the opposite of `==`.
+			// So classes that define `==` get `!=` for free.
 			//
 			// ```ruby
 			// 123 != 123   # => false
@@ -522,13 +667,47 @@ func builtinClassCommonInstanceMethods() []*BuiltinMethodObject {
 			Name: "!=",
 			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
-					className := receiver.Class().Name
-					compareClassName := args[0].Class().Name
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
 
-					if className == compareClassName && reflect.DeepEqual(receiver, args[0]) {
-						return FALSE
+					result := t.callMethod(receiver, "==", sourceLine, args[0])
+
+					switch result {
+					case FALSE, NULL:
+						return TRUE
 					}
-					return TRUE
+
+					if _, ok := result.(*Error); ok {
+						return result
+					}
+
+					return FALSE
+				}
+			},
+		},
+		{
+			// Returns true if the given object matches self, it's used by `case ... when` and the value patterns of `case ... in` expressions.
+			// By default it's the same as `==`, and classes can override it to match objects in their own ways.
+			//
+			// ```ruby
+			// 1 === 1      # => true
+			// "a" === "b"  # => false
+			//
+			// case 1
+			// when 1 then "one"
+			// end          # => "one"
+			// ```
+			//
+			// @return [Boolean]
+			Name: "===",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					return t.callMethod(receiver, "==", sourceLine, args[0])
 				}
 			},
 		},
@@ -558,6 +737,11 @@ func builtinClassCommonInstanceMethods() []*BuiltinMethodObject {
 				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
 					cf := t.callFrameStack.callFrames[t.cfp-2]
 
+					// Inside blocks like `each do ... end`, it checks the block of the method that the blocks are defined in
+					for cf.BlockFrame() != nil && cf.EP() != nil && cf.BlockFrame().ep == cf.EP() {
+						cf = cf.EP()
+					}
+
 					if cf.BlockFrame() == nil {
 						return FALSE
 					}
@@ -588,8 +772,79 @@ func builtinClassCommonInstanceMethods() []*BuiltinMethodObject {
 					case Object:
 						return r.Class()
 					default:
-						return &Error{Message: "Can't call class on %T" + string(r.Class().ReturnName())}
+						return &Error{Message: "Can't call class on %T" + string(r.Class().ReturnName()), raised: true}
+					}
+				}
+			},
+		},
+		{
+			// Returns the string formatted with the arguments, following Ruby's format directives:
+			// `%[flags][width][.precision]verb`.
+			//
+			// - Verbs: `%s` (to_s), `%p` (inspect), `%d`/`%i`, `%x`/`%X` (hexadecimal), `%o` (octal), `%b`/`%B` (binary),
+			//   `%f`, `%e`/`%E` (exponent), `%g`/`%G` (shortest of `%f` and `%e`), `%c` (character) and `%%` (percent sign)
+			// - Flags: `-` left-justifies, `+` shows the sign, a space goes before positive numbers, `0` pads numbers with zeros
+			//   and `#` uses an alternative form like `0x` before hexadecimal numbers
+			// - The width and the precision can be `*` to take them from the arguments, and `%2$s` refers to the second argument
+			// - With a hash argument, `%<name>d` formats the value of the `name` key and `%{name}` inserts it as a string
+			// - Without the `+` or space flag, `%x`, `%o` and `%b` show negative numbers in two's complement after `..`
+			//
+			// ```ruby
+			// format("%05.2f", 3.14159)          # => "03.14"
+			// format("%-10s|", "Goby")           # => "Goby      |"
+			// format("%+d %x %o %b", 5, 255, 8, 5) # => "+5 ff 10 101"
+			// format("%#x %e", 255, 12345.678)   # => "0xff 1.234568e+04"
+			// format("%x %+x", -255, -255)       # => "..f01 -ff"
+			// format("%p %s", "a", nil)          # => "\"a\" "
+			// format("%{name} is %<age>03d", { name: "Goby", age: 5 }) # => "Goby is 005"
+			// ```
+			//
+			// @param format [String]
+			// @param arguments [Object]
+			// @return [String]
+			Name: "format",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					return t.formatArguments(args, sourceLine)
+				}
+			},
+		},
+		{
+			// Returns the hash value of the object, which is used for finding the object as a hash key.
+			// Objects that are the same hash key have the same hash value, other objects are only the same as themselves.
+			// A class can define its own `hash` with `eql?` to make its instances the same key when their values are the same.
+			//
+			// ```ruby
+			// Object.new.hash == Object.new.hash # => false
+			// 1.0.hash == 1.0.hash               # => true
+			//
+			// class Point
+			//   attr_reader :x
+			//
+			//   def initialize(x)
+			//     @x = x
+			//   end
+			//
+			//   def hash
+			//     @x.hash
+			//   end
+			//
+			//   def eql?(other)
+			//     @x == other.x
+			//   end
+			// end
+			// ```
+			//
+			// @return [Integer]
+			Name: "hash",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					// A user-defined `hash` calling `super` gets the hash value of the object itself
+					if _, ok := receiver.findMethod("hash").(*MethodObject); ok {
+						return t.vm.initIntegerObject(hashKey{kind: receiver.Class().Name, value: receiver.id()}.hashValue())
 					}
+
+					return t.hashMethodResult(receiver, sourceLine)
 				}
 			},
 		},
@@ -663,13 +918,13 @@ func builtinClassCommonInstanceMethods() []*BuiltinMethodObject {
 					if len(args) != 1 {
 						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "Expect 1 arguments. got: %d", len(args))
 					}
-					arg, isStr := args[0].(*StringObject)
+					arg, isStr := symbolOrStringValue(args[0])
 
 					if !isStr {
-						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.StringClass, args[0].Class().Name)
+						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.SymbolClass+" or "+classes.StringClass, args[0].Class().Name)
 					}
 
-					obj, ok := receiver.instanceVariableGet(arg.value)
+					obj, ok := receiver.instanceVariableGet(arg)
 
 					if !ok {
 						return NULL
@@ -703,14 +958,14 @@ func builtinClassCommonInstanceMethods() []*BuiltinMethodObject {
 						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "Expect 2 arguments. got: %d", len(args))
 					}
 
-					argName, isStr := args[0].(*StringObject)
+					argName, isStr := symbolOrStringValue(args[0])
 					obj := args[1]
 
 					if !isStr {
-						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.StringClass, args[0].Class().Name)
+						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.SymbolClass+" or "+classes.StringClass, args[0].Class().Name)
 					}
 
-					receiver.instanceVariableSet(argName.value, obj)
+					receiver.instanceVariableSet(argName, obj)
 
 					return obj
 				}
@@ -725,6 +980,73 @@ func builtinClassCommonInstanceMethods() []*BuiltinMethodObject {
 		//
 		// @param class [Class] Receiver
 		// @return [Array]
+		{
+			// Returns a lambda created from the given block.
+			// A lambda checks the number of the arguments when it's called.
+			//
+			// ```ruby
+			// add = lambda do |a, b|
+			//   a + b
+			// end
+			// add.call(1, 2) # => 3
+			// add.call(1)    # => ArgumentError
+			// ```
+			//
+			// @return [Proc]
+			Name: "lambda",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if blockFrame == nil {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.CantCreateProcWithoutBlockFormat)
+					}
+
+					return t.vm.initProcObject(blockFrame, true)
+				}
+			},
+		},
+		{
+			// Is called when the receiver doesn't have the called method, with the method's name and arguments.
+			// Keyword arguments are passed as a hash at last.
+			// By default it raises an UndefinedMethodError, and it can be overridden for delegating method calls dynamically.
+			//
+			// ```ruby
+			// class Proxy
+			//   def initialize(target)
+			//     @target = target
+			//   end
+			//
+			//   def method_missing(name, *args)
+			//     @target.send(name, *args)
+			//   end
+			//
+			//   def respond_to_missing?(name, include_all)
+			//     @target.respond_to?(name)
+			//   end
+			// end
+			//
+			// Proxy.new([1, 2]).length      # => 2
+			// Proxy.new([]).respond_to?(:push) # => true
+			// ```
+			//
+			// @param name [Symbol], args [Object]
+			// @return [Object]
+			Name: methodMissing,
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) < 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentMoreFormat, 1, len(args))
+					}
+
+					name, ok := symbolOrStringValue(args[0])
+
+					if !ok {
+						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.SymbolClass, args[0].Class().Name)
+					}
+
+					return t.vm.initErrorObject(errors.UndefinedMethodError, sourceLine, "Undefined Method '%+v' for %+v", name, receiver.toString())
+				}
+			},
+		},
 		{
 			Name: "methods",
 			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
@@ -739,6 +1061,13 @@ func builtinClassCommonInstanceMethods() []*BuiltinMethodObject {
 						for _, name := range klass.Methods.names() {
 							if set[name] == nil {
 								set[name] = true
+								method, _ := klass.Methods.get(name)
+
+								// Private methods are hidden, but they still override the methods with the same name
+								if methodVisibility(method) == privateMethod {
+									continue
+								}
+
 								methods = append(methods, t.vm.initStringObject(name))
 							}
 						}
@@ -781,6 +1110,52 @@ func builtinClassCommonInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Prints the string formatted with the arguments like `format`, without adding a newline.
+			//
+			// ```ruby
+			// printf("%s: %d\n", "count", 3) # prints "count: 3"
+			// ```
+			//
+			// @param format [String]
+			// @param arguments [Object]
+			// @return [Null]
+			Name: "printf",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					result := t.formatArguments(args, sourceLine)
+
+					if err, ok := result.(*Error); ok {
+						return err
+					}
+
+					fmt.Print(result.toString())
+					return NULL
+				}
+			},
+		},
+		{
+			// Returns a proc created from the given block, same as `Proc.new`.
+			//
+			// ```ruby
+			// p = proc do |a, b|
+			//   b
+			// end
+			// p.call(1) # => nil
+			// ```
+			//
+			// @return [Proc]
+			Name: "proc",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if blockFrame == nil {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.CantCreateProcWithoutBlockFormat)
+					}
+
+					return t.vm.initProcObject(blockFrame, false)
+				}
+			},
+		},
 		{
 			// Puts string literals or objects into stdout with a tailing line feed, converting into String
 			// if needed.
@@ -794,8 +1169,9 @@ func builtinClassCommonInstanceMethods() []*BuiltinMethodObject {
 			// # => String
 			// puts("foo" + "bar")
 			// # => foobar
+			// puts("#{1 + 2} foo")
+			// # => 3 foo
 			// ```
-			// TODO: interpolation is needed to be implemented.
 			//
 			// @param *args [Class] String literals, or other objects that can be converted into String.
 			// @return [Null]
@@ -810,6 +1186,65 @@ func builtinClassCommonInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Raises an error, which can be rescued by `begin ... rescue ... end`.
+			// An error class can be given with or without a message, and an rescued error can be raised again.
+			// The error class is instantiated with its `new`, so the message is passed to the class's `initialize`.
+			// Without arguments, it raises the error being rescued again.
+			//
+			// ```ruby
+			// begin
+			//   raise(ArgumentError, "wrong argument")
+			// rescue ArgumentError => e
+			//   puts(e.message) # => wrong argument
+			//   raise(e)
+			// end
+			//
+			// begin
+			//   raise("foo")
+			// rescue
+			//   raise # raises the "foo" error again
+			// end
+			// ```
+			//
+			// @param error [Class] Error class, an error object or a message
+			// @param message [String] Error message
+			// @return [Error]
+			Name: "raise",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					switch len(args) {
+					case 0:
+						if err := t.rescuedError(); err != nil {
+							t.vm.raiseErrorObject(err, sourceLine)
+							return err
+						}
+
+						return t.vm.initErrorObject(errors.InternalError, sourceLine, "unhandled exception")
+					case 1:
+						switch arg := args[0].(type) {
+						case *Error:
+							t.vm.raiseErrorObject(arg, sourceLine)
+							return arg
+						case *RClass:
+							return t.raiseErrorClass(arg, sourceLine)
+						default:
+							return t.vm.initErrorObject(errors.InternalError, sourceLine, "%s", arg.toString())
+						}
+					case 2:
+						errClass, ok := args[0].(*RClass)
+
+						if !ok {
+							return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.ClassClass, args[0].Class().Name)
+						}
+
+						return t.raiseErrorClass(errClass, sourceLine, args[1])
+					}
+
+					return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "Expect at most 2 arguments. got: %d", len(args))
+				}
+			},
+		},
 		{
 			// Loads the given Goby library name without extension (mainly for modules), returning `true`
 			// if successful and `false` if the feature is already loaded.
@@ -869,7 +1304,7 @@ func builtinClassCommonInstanceMethods() []*BuiltinMethodObject {
 					file, err := ioutil.ReadFile(filepath + ".gb")
 
 					if err != nil {
-						return t.vm.initErrorObject(errors.InternalError, sourceLine, err.Error())
+						return t.vm.initErrorObject(errors.InternalError, sourceLine, "%s", err.Error())
 					}
 
 					t.vm.execRequiredFile(filepath, file)
@@ -878,6 +1313,80 @@ func builtinClassCommonInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Returns true if the receiver responds to the given method name, which can be a symbol or a string.
+			//
+			// ```ruby
+			// 1.respond_to?(:to_s)  # => true
+			// 1.respond_to?("foo")  # => false
+			// ```
+			//
+			// @param name [Symbol/String]
+			// @return [Boolean]
+			Name: "respond_to?",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					name, ok := symbolOrStringValue(args[0])
+
+					if !ok {
+						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.SymbolClass+" or "+classes.StringClass, args[0].Class().Name)
+					}
+
+					if method := receiver.findMethod(name); method != nil {
+						return toBooleanObject(methodVisibility(method) == publicMethod)
+					}
+
+					result := t.callMethod(receiver, "respond_to_missing?", sourceLine, t.vm.initSymbolObject(name), FALSE)
+
+					switch result := result.(type) {
+					case *Error:
+						return result
+					case *NullObject:
+						return FALSE
+					case *BooleanObject:
+						return result
+					default:
+						return TRUE
+					}
+				}
+			},
+		},
+		{
+			// Is called by `respond_to?` when the receiver doesn't have the method, and returns false by default.
+			// Override it together with `method_missing` to make `respond_to?` consistent with it.
+			//
+			// ```ruby
+			// class Foo
+			//   def method_missing(name)
+			//     name.to_s
+			//   end
+			//
+			//   def respond_to_missing?(name, include_all)
+			//     name.to_s.start_with?("foo")
+			//   end
+			// end
+			//
+			// Foo.new.respond_to?(:foo_bar) # => true
+			// Foo.new.respond_to?(:bar)     # => false
+			// ```
+			//
+			// @param name [Symbol], include_all [Boolean]
+			// @return [Boolean]
+			Name: "respond_to_missing?",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 2 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 2, len(args))
+					}
+
+					return FALSE
+				}
+			},
+		},
 		// Invoke the specified instance method or class method.
 		// - Method name should be either a symbol or String (required).
 		// - You can pass one or more arguments (option).
@@ -921,13 +1430,13 @@ func builtinClassCommonInstanceMethods() []*BuiltinMethodObject {
 						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "no method name given")
 					}
 
-					name, ok := args[0].(*StringObject)
+					name, ok := symbolOrStringValue(args[0])
 
 					if !ok {
-						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.StringClass, args[0].Class().Name)
+						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.SymbolClass+" or "+classes.StringClass, args[0].Class().Name)
 					}
 
-					t.sendMethod(name.value, len(args)-1, blockFrame, sourceLine)
+					t.sendMethod(name, len(args)-1, blockFrame, sourceLine)
 
 					return t.stack.top().Target
 				}
@@ -981,6 +1490,23 @@ func builtinClassCommonInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Returns the string formatted with the arguments, same as `format`.
+			//
+			// ```ruby
+			// sprintf("%03d", 7) # => "007"
+			// ```
+			//
+			// @param format [String]
+			// @param arguments [Object]
+			// @return [String]
+			Name: "sprintf",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					return t.formatArguments(args, sourceLine)
+				}
+			},
+		},
 		{
 			Name: "thread",
 			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
@@ -1049,6 +1575,19 @@ func (vm *VM) createRClass(className string) *RClass {
 	}
 }
 
+// singletonClassOf returns the object's singleton class, which is created if the object doesn't have one yet
+func (vm *VM) singletonClassOf(obj Object) *RClass {
+	if c := obj.SingletonClass(); c != nil {
+		return c
+	}
+
+	singletonClass := vm.createRClass(fmt.Sprintf("#<Class:#<%s:%d>>", obj.Class().Name, obj.id()))
+	singletonClass.isSingleton = true
+	obj.SetSingletonClass(singletonClass)
+
+	return singletonClass
+}
+
 func initClassClass() *RClass {
 	classClass := &RClass{
 		Name:      classes.ClassClass,
@@ -1100,6 +1639,13 @@ func initObjectClass(c *RClass) *RClass {
 	objectClass.setBuiltinMethods(builtinClassCommonInstanceMethods(), true)
 	objectClass.setBuiltinMethods(builtinClassCommonInstanceMethods(), false)
 
+	// Class methods are looked up through Object's singleton class before Class, so Class#=== has to override Object#=== there
+	for _, m := range builtinClassCommonClassMethods() {
+		if m.Name == "===" {
+			singletonClass.Methods.set(m.Name, m)
+		}
+	}
+
 	return objectClass
 }
 
@@ -1176,6 +1722,17 @@ func (c *RClass) lookupMethod(methodName string) Object {
 	return method
 }
 
+// methodLookupChain returns the classes and modules that lookupMethod searches through, in order
+func (c *RClass) methodLookupChain() []*RClass {
+	chain := []*RClass{c}
+
+	for klass := c; klass.superClass != nil && klass.superClass != klass && klass.Name != classes.ClassClass; klass = klass.superClass {
+		chain = append(chain, klass.superClass)
+	}
+
+	return chain
+}
+
 func (c *RClass) lookupConstant(constName string, findInScope bool) *Pointer {
 	constant, ok := c.constants[constName]
 
@@ -1210,7 +1767,7 @@ func (c *RClass) getClassConstant(constName string) (class *RClass) {
 }
 
 func (c *RClass) alreadyInherit(constant *RClass) bool {
-	if c.superClass == constant {
+	if c.superClass == constant || c.superClass.includedModule == constant {
 		return true
 	}
 
@@ -1236,12 +1793,12 @@ func (c *RClass) setAttrWriter(args interface{}) {
 	switch args := args.(type) {
 	case []Object:
 		for _, attr := range args {
-			attrName := attr.(*StringObject).value
-			c.Methods.set(attrName+"=", generateAttrWriteMethod(attrName))
+			attrName, _ := symbolOrStringValue(attr)
+			c.setAttrMethod(generateAttrWriteMethod(attrName))
 		}
 	case []string:
 		for _, attrName := range args {
-			c.Methods.set(attrName+"=", generateAttrWriteMethod(attrName))
+			c.setAttrMethod(generateAttrWriteMethod(attrName))
 		}
 	}
 
@@ -1251,24 +1808,79 @@ func (c *RClass) setAttrReader(args interface{}) {
 	switch args := args.(type) {
 	case []Object:
 		for _, attr := range args {
-			attrName := attr.(*StringObject).value
-			c.Methods.set(attrName, generateAttrReadMethod(attrName))
+			attrName, _ := symbolOrStringValue(attr)
+			c.setAttrMethod(generateAttrReadMethod(attrName))
 		}
 	case []string:
 		for _, attrName := range args {
-			c.Methods.set(attrName, generateAttrReadMethod(attrName))
+			c.setAttrMethod(generateAttrReadMethod(attrName))
 		}
 	case string:
-		c.Methods.set(args, generateAttrReadMethod(args))
+		c.setAttrMethod(generateAttrReadMethod(args))
 	}
 
 }
 
+// setAttrMethod defines the attribute method with the visibility set by `public`, `private` or `protected` without arguments,
+// like the methods defined with `def`
+func (c *RClass) setAttrMethod(method *BuiltinMethodObject) {
+	method.visibility = c.defaultVisibility
+	c.Methods.set(method.Name, method)
+}
+
 func (c *RClass) setAttrAccessor(args interface{}) {
 	c.setAttrReader(args)
 	c.setAttrWriter(args)
 }
 
+// getClassVariable looks up the class variable through the class's ancestors
+func (c *RClass) getClassVariable(name string) (Object, bool) {
+	for _, ancestor := range c.ancestors() {
+		ancestor.classVariablesMutex.RLock()
+		v, ok := ancestor.lookupOwnClassVariable(name)
+		ancestor.classVariablesMutex.RUnlock()
+
+		if ok {
+			return v, true
+		}
+	}
+
+	return nil, false
+}
+
+// setClassVariable updates the variable of the ancestor that has it, or defines it on the class itself
+func (c *RClass) setClassVariable(name string, value Object) {
+	owner := c
+
+	for _, ancestor := range c.ancestors() {
+		ancestor.classVariablesMutex.RLock()
+		_, ok := ancestor.lookupOwnClassVariable(name)
+		ancestor.classVariablesMutex.RUnlock()
+
+		if ok {
+			owner = ancestor
+			break
+		}
+	}
+
+	owner.classVariablesMutex.Lock()
+	defer owner.classVariablesMutex.Unlock()
+
+	if owner.classVariables == nil {
+		owner.classVariables = newEnvironment()
+	}
+
+	owner.classVariables.set(name, value)
+}
+
+func (c *RClass) lookupOwnClassVariable(name string) (Object, bool) {
+	if c.classVariables == nil {
+		return nil, false
+	}
+
+	return c.classVariables.get(name)
+}
+
 func (c *RClass) ancestors() []*RClass {
 	klasses := []*RClass{c}
 	for {
@@ -1276,14 +1888,159 @@ func (c *RClass) ancestors() []*RClass {
 			break
 		}
 		c = c.superClass
-		klasses = append(klasses, c)
+
+		if c.includedModule != nil {
+			klasses = append(klasses, c.includedModule)
+		} else {
+			klasses = append(klasses, c)
+		}
 	}
 
 	return klasses
 }
 
+// includeModule inserts the module, and the modules it includes, between the class and its superclass.
+// Each class gets its own proxy of the module that shares the module's methods and constants,
+// so the same module can be included by classes that have different superclasses.
+func (c *RClass) includeModule(module *RClass) {
+	var modules []*RClass
+
+	for m := module; m.isModule || m.includedModule != nil; m = m.superClass {
+		if m.includedModule != nil {
+			modules = append(modules, m.includedModule)
+		} else {
+			modules = append(modules, m)
+		}
+	}
+
+	for i := len(modules) - 1; i >= 0; i-- {
+		m := modules[i]
+
+		if c.alreadyInherit(m) {
+			continue
+		}
+
+		c.superClass = &RClass{
+			Name:           m.Name,
+			Methods:        m.Methods,
+			constants:      m.constants,
+			isModule:       true,
+			superClass:     c.superClass,
+			includedModule: m,
+			baseObj:        m.baseObj,
+		}
+	}
+}
+
 // Other helper functions -----------------------------------------------
 
+// classVariableScope returns the class whose class variables can be accessed with the self,
+// which is the class itself in class bodies and class methods, or the object's class in instance methods
+func classVariableScope(self Object) *RClass {
+	if c, ok := self.(*RClass); ok {
+		return c
+	}
+
+	return self.Class()
+}
+
+// objectsEqual returns true if the objects are of the same class and have the same value.
+// Hashes are equal regardless of the order of their pairs, even when they're inside arrays.
+// The error is raised by the `hash` or `eql?` methods of the hashes' keys.
+func (t *thread) objectsEqual(left, right Object, sourceLine int) (bool, *Error) {
+	if left.Class().Name != right.Class().Name {
+		return false, nil
+	}
+
+	switch l := left.(type) {
+	case *IntegerObject:
+		r, ok := right.(*IntegerObject)
+		return ok && l.equal(r), nil
+	case *HashObject:
+		r, ok := right.(*HashObject)
+
+		if !ok {
+			return false, nil
+		}
+
+		return l.equal(t, r, sourceLine)
+	case *ArrayObject:
+		r, ok := right.(*ArrayObject)
+
+		if !ok || len(l.Elements) != len(r.Elements) {
+			return false, nil
+		}
+
+		for i, elem := range l.Elements {
+			equal, err := t.objectsEqual(elem, r.Elements[i], sourceLine)
+
+			if err != nil || !equal {
+				return false, err
+			}
+		}
+
+		return true, nil
+	case *ConcurrentArrayObject:
+		r, ok := right.(*ConcurrentArrayObject)
+
+		if !ok {
+			return false, nil
+		}
+
+		return t.objectsEqual(l.InternalArray, r.InternalArray, sourceLine)
+	}
+
+	return reflect.DeepEqual(left, right), nil
+}
+
+// setMethodVisibility sets the visibility of the class's instance methods with the given names.
+// Without any names, it sets the visibility of the methods defined after it in the class body instead.
+func (t *thread) setMethodVisibility(receiver Object, args []Object, visibility int, sourceLine int) Object {
+	c, ok := receiver.(*RClass)
+
+	if !ok {
+		return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.ClassClass, receiver.Class().Name)
+	}
+
+	if len(args) == 0 {
+		c.defaultVisibility = visibility
+		return NULL
+	}
+
+	for _, arg := range args {
+		name, ok := symbolOrStringValue(arg)
+
+		if !ok {
+			return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.SymbolClass+" or "+classes.StringClass, arg.Class().Name)
+		}
+
+		method := c.lookupMethod(name)
+
+		if method == nil {
+			return t.vm.initErrorObject(errors.NameError, sourceLine, "Undefined method '%s' for %s", name, c.Name)
+		}
+
+		c.Methods.set(name, withVisibility(method, visibility))
+	}
+
+	if len(args) == 1 {
+		return args[0]
+	}
+
+	return t.vm.initArrayObject(args)
+}
+
+// checkAttrNames returns a TypeError if any of the given attribute names is neither a symbol nor a string
+func (t *thread) checkAttrNames(args []Object, sourceLine int) *Error {
+	for _, attr := range args {
+		if _, ok := symbolOrStringValue(attr); !ok {
+			return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.SymbolClass+" or "+classes.StringClass, attr.Class().Name)
+		}
+	}
+
+	return nil
+}
+
 func generateAttrWriteMethod(attrName string) *BuiltinMethodObject {
 	return &BuiltinMethodObject{
 		Name: attrName + "=",
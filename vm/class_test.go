@@ -267,6 +267,32 @@ func TestAncestors(t *testing.T) {
 		end
 		C3.ancestors == [C3, C2, M, C, Object]
 		`, true},
+		{`
+		module M
+		end
+		class C
+		end
+		class C2 < C
+		  include M
+		end
+		class C3
+		  include M
+		end
+		C2.ancestors == [C2, M, C, Object] && C3.ancestors == [C3, M, Object]
+		`, true},
+		{`
+		module M1
+		end
+		module M2
+		  include M1
+		end
+		class C
+		  include M2
+		end
+		C.ancestors == [C, M2, M1, Object]
+		`, true},
+		{`Array.ancestors == [Array, Enumerable, Object]`, true},
+		{`Integer.ancestors == [Integer, Comparable, Object]`, true},
 	}
 	for i, tt := range tests {
 		v := initTestVM()
@@ -778,6 +804,55 @@ func TestClassGeneralComparisonOperation(t *testing.T) {
 	}
 }
 
+func TestCaseEqualityOperation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`Integer === 1`, true},
+		{`Object === "a"`, true},
+		{`String === 1`, false},
+		{`Integer === Integer`, false},
+		{`Class === Integer`, true},
+		{`
+		class Animal; end
+		class Dog < Animal; end
+		Animal === Dog.new
+		`, true},
+		{`
+		class Animal; end
+		class Dog < Animal; end
+		Dog === Animal.new
+		`, false},
+		{`
+		module Walkable; end
+		class Dog
+		  include Walkable
+		end
+		Walkable === Dog.new
+		`, true},
+		{`1 === 1`, true},
+		{`"a" === "b"`, false},
+		{`[1, 2] === [1, 2]`, true},
+		{`
+		class Foo
+		  def ==(other)
+		    true
+		  end
+		end
+		Foo.new === 1
+		`, true},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestGeneralAssignmentByOperation(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -788,7 +863,7 @@ func TestGeneralAssignmentByOperation(t *testing.T) {
 		{`a = "Goby"; a ||= "Fish";               a;`, "Goby"},
 		{`a = (1..3); a ||= [1, 2, 3];          a.to_s;`, "(1..3)"},
 		{`a = false;  a ||= 123;                  a;`, 123},
-		{`a = nil;    a ||= { b: 1 };             a["b"];`, 1},
+		{`a = nil;    a ||= { b: 1 };             a[:b];`, 1},
 		{`a = false;  a ||= false;                a;`, false},
 		{`a = nil;    a ||= false;                a;`, false},
 		{`a = false;  a ||= nil;                  a;`, nil},
@@ -991,6 +1066,195 @@ func TestClassSingletonClassClassMethod(t *testing.T) {
 	}
 }
 
+func TestSingletonClassStatement(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		class Foo
+		  class << self
+		    def bar
+		      "bar"
+		    end
+		  end
+		end
+
+		Foo.bar
+		`, "bar"},
+		{`
+		class Foo
+		  class << self
+		    attr_accessor :config
+		  end
+		end
+
+		Foo.config = 10
+		Foo.config
+		`, 10},
+		{`
+		class Foo
+		  class << self
+		    def bar
+		      "bar"
+		    end
+		  end
+		end
+
+		class Baz < Foo; end
+		Baz.bar
+		`, "bar"},
+		{`
+		class Foo; end
+		foo = Foo.new
+
+		class << foo
+		  def bar
+		    "bar"
+		  end
+		end
+
+		foo.bar
+		`, "bar"},
+		{`
+		class Foo; end
+		foo = Foo.new
+
+		def foo.bar
+		  1
+		end
+
+		def foo.baz
+		  2
+		end
+
+		foo.bar + foo.baz
+		`, 3},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestOperatorMethodDefinition(t *testing.T) {
+	vector := `
+	class Vector
+	  attr_reader :x, :y
+
+	  def initialize(x, y)
+	    @x = x
+	    @y = y
+	  end
+
+	  def +(other)
+	    Vector.new(@x + other.x, @y + other.y)
+	  end
+
+	  def -(other)
+	    Vector.new(@x - other.x, @y - other.y)
+	  end
+
+	  def -@
+	    Vector.new(-@x, -@y)
+	  end
+
+	  def +@
+	    self
+	  end
+
+	  def ~@
+	    Vector.new(@y, @x)
+	  end
+
+	  def ==(other)
+	    @x == other.x && @y == other.y
+	  end
+
+	  def <=>(other)
+	    (@x * @x + @y * @y) <=> (other.x * other.x + other.y * other.y)
+	  end
+
+	  def [](i)
+	    if i == 0
+	      @x
+	    else
+	      @y
+	    end
+	  end
+
+	  def []=(i, value)
+	    if i == 0
+	      @x = value
+	    else
+	      @y = value
+	    end
+	  end
+
+	  def <<(n)
+	    Vector.new(@x * n, @y * n)
+	  end
+
+	  def !
+	    @x == 0 && @y == 0
+	  end
+
+	  def to_s
+	    "(" + @x.to_s + ", " + @y.to_s + ")"
+	  end
+	end
+	`
+
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`(Vector.new(1, 2) + Vector.new(3, 4)).to_s`, "(4, 6)"},
+		{`(Vector.new(1, 2) - Vector.new(3, 5)).to_s`, "(-2, -3)"},
+		{`(-Vector.new(1, -2)).to_s`, "(-1, 2)"},
+		{`Vector.new(1, -2).+@.to_s`, "(1, -2)"},
+		{`(~Vector.new(1, 2)).to_s`, "(2, 1)"},
+		{`Vector.new(1, 2) == Vector.new(1, 2)`, true},
+		{`Vector.new(1, 2) == Vector.new(2, 1)`, false},
+		{`Vector.new(1, 2) != Vector.new(2, 1)`, true},
+		{`Vector.new(1, 2) != Vector.new(1, 2)`, false},
+		{`Vector.new(1, 2) <=> Vector.new(2, 1)`, 0},
+		{`Vector.new(1, 2) <=> Vector.new(3, 4)`, -1},
+		{`Vector.new(1, 2)[1]`, 2},
+		{`
+		v = Vector.new(1, 2)
+		v[0] = 10
+		v.to_s
+		`, "(10, 2)"},
+		{`(Vector.new(1, 2) << 3).to_s`, "(3, 6)"},
+		{`!Vector.new(0, 0)`, true},
+		{`!Vector.new(0, 1)`, false},
+		{`Vector.new(1, 2).+(Vector.new(1, 1)).to_s`, "(2, 3)"},
+		{`Vector.new(1, 2).send(:+, Vector.new(1, 1)).to_s`, "(2, 3)"},
+		{`
+		class Vector
+		  def self.+(other)
+		    "class plus"
+		  end
+		end
+
+		Vector + 1
+		`, "class plus"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, vector+tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestObjectId(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -1032,3 +1296,55 @@ func TestObjectId(t *testing.T) {
 		v.checkSP(t, i, 1)
 	}
 }
+
+func TestGeneralHashMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`o = Object.new; o.hash == o.hash`, true},
+		{`Object.new.hash == Object.new.hash`, false},
+		{`1.5.hash == 1.5.hash`, true},
+		{`nil.hash == nil.hash`, true},
+		{`
+		class Foo
+		  def hash
+		    super
+		  end
+		end
+
+		f = Foo.new
+		f.hash == f.hash && f.hash != Foo.new.hash
+		`, true},
+		{`
+		class Point
+		  attr_reader :x, :y
+
+		  def initialize(x, y)
+		    @x = x
+		    @y = y
+		  end
+
+		  def hash
+		    [@x, @y].hash
+		  end
+
+		  def eql?(other)
+		    @x == other.x && @y == other.y
+		  end
+		end
+
+		h = { Point.new(1, 2) => "a" }
+		h[Point.new(1, 2)] = "b"
+		h.length.to_s + h[Point.new(1, 2)]
+		`, "1b"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
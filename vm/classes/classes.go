@@ -6,6 +6,7 @@ const (
 	IntegerClass   = "Integer"
 	FloatClass     = "Float"
 	StringClass    = "String"
+	SymbolClass    = "Symbol"
 	ArrayClass     = "Array"
 	HashClass      = "Hash"
 	BooleanClass   = "Boolean"
@@ -19,4 +20,9 @@ const (
 	RegexpClass    = "Regexp"
 	MatchDataClass = "MatchData"
 	GoMapClass     = "GoMap"
+	DecimalClass   = "Decimal"
+	ProcClass      = "Proc"
+
+	ComparableModule = "Comparable"
+	EnumerableModule = "Enumerable"
 )
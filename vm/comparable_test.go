@@ -0,0 +1,71 @@
+package vm
+
+import (
+	"testing"
+)
+
+func TestComparableModule(t *testing.T) {
+	version := `
+	class Version
+	  include Comparable
+
+	  attr_reader :number
+
+	  def initialize(number)
+	    @number = number
+	  end
+
+	  def <=>(other)
+	    if other.is_a?(Version)
+	      @number <=> other.number
+	    end
+	  end
+	end
+	`
+
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`Version.new(1) < Version.new(2)`, true},
+		{`Version.new(2) < Version.new(2)`, false},
+		{`Version.new(2) <= Version.new(2)`, true},
+		{`Version.new(3) > Version.new(2)`, true},
+		{`Version.new(1) >= Version.new(2)`, false},
+		{`Version.new(2) == Version.new(2)`, true},
+		{`Version.new(2) != Version.new(3)`, true},
+		{`Version.new(2).between?(Version.new(1), Version.new(3))`, true},
+		{`Version.new(4).between?(Version.new(1), Version.new(3))`, false},
+		{`Version.new(4).clamp(Version.new(1), Version.new(3)).number`, 3},
+		{`Version.new(0).clamp(Version.new(1), Version.new(3)).number`, 1},
+		{`Version.new(2).clamp(Version.new(1), Version.new(3)).number`, 2},
+		{`Version.new(1).is_a?(Comparable)`, true},
+		{`Comparable === Version.new(1)`, true},
+		{`
+		begin
+		  Version.new(1) < 1
+		rescue ArgumentError => e
+		  e.message
+		end
+		`, "comparison of Version with 1 failed"},
+		{`
+		begin
+		  Version.new(2).clamp(Version.new(3), Version.new(1))
+		rescue ArgumentError => e
+		  e.message
+		end
+		`, "min argument must be less than or equal to max argument"},
+		{`5.clamp(1, 3)`, 3},
+		{`1.5.between?(1, 2)`, true},
+		{`"b".between?("a", "c")`, true},
+		{`"z".clamp("a", "c")`, "c"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, version+tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
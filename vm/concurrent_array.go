@@ -118,6 +118,7 @@ func initConcurrentArrayClass(vm *VM) {
 
 	array.setBuiltinMethods(builtinConcurrentArrayInstanceMethods(), false)
 	array.setBuiltinMethods(builtinConcurrentArrayClassMethods(), true)
+	array.includeModule(vm.objectClass.getClassConstant(classes.EnumerableModule))
 
 	concurrent.setClassConstant(array)
 }
@@ -142,6 +143,21 @@ func (cac *ConcurrentArrayObject) Value() interface{} {
 
 // Helper functions -----------------------------------------------------
 
+// callInternalMethod calls the internal array's method while holding the lock. The lock is
+// released with defer, because an error raised inside a yielded block unwinds with a panic.
+func (cac *ConcurrentArrayObject) callInternalMethod(t *thread, methodName string, requireWriteLock bool, args []Object, blockFrame *normalCallFrame, sourceLine int) Object {
+	if requireWriteLock {
+		cac.Lock()
+		defer cac.Unlock()
+	} else {
+		cac.RLock()
+		defer cac.RUnlock()
+	}
+
+	arrayMethodObject := cac.InternalArray.findMethod(methodName).(*BuiltinMethodObject)
+	return arrayMethodObject.Fn(cac.InternalArray, sourceLine)(t, args, blockFrame)
+}
+
 func DefineForwardedConcurrentArrayMethod(methodName string, requireWriteLock bool) *BuiltinMethodObject {
 	return &BuiltinMethodObject{
 		Name: methodName,
@@ -149,20 +165,7 @@ func DefineForwardedConcurrentArrayMethod(methodName string, requireWriteLock bo
 			return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
 				concurrentArray := receiver.(*ConcurrentArrayObject)
 
-				if requireWriteLock {
-					concurrentArray.Lock()
-				} else {
-					concurrentArray.RLock()
-				}
-
-        arrayMethodObject := concurrentArray.InternalArray.findMethod(methodName).(*BuiltinMethodObject)
-        result := arrayMethodObject.Fn(concurrentArray.InternalArray, sourceLine)(t, args, blockFrame)
-
-				if requireWriteLock {
-					concurrentArray.Unlock()
-				} else {
-					concurrentArray.RUnlock()
-				}
+				result := concurrentArray.callInternalMethod(t, methodName, requireWriteLock, args, blockFrame, sourceLine)
 
 				switch result.(type) {
 				case *ArrayObject:
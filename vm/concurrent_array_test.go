@@ -1237,6 +1237,46 @@ func TestConcurrentArrayMapMethod(t *testing.T) {
 	}
 }
 
+func TestConcurrentArrayRescueFromBlock(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []interface{}
+	}{
+		{`
+		require 'concurrent/array'
+		a = Concurrent::Array.new([1, 2, 3])
+		begin
+		  a.map do |i|
+		    raise ArgumentError, "boom"
+		  end
+		rescue ArgumentError => e
+		end
+		a.push(4)
+		a
+		`, []interface{}{1, 2, 3, 4}},
+		{`
+		require 'concurrent/array'
+		a = Concurrent::Array.new([1, 2, 3])
+		begin
+		  a.each do |i|
+		    raise "boom"
+		  end
+		rescue
+		end
+		a.push(4)
+		a
+		`, []interface{}{1, 2, 3, 4}},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		testConcurrentArrayObject(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestConcurrentArrayPopMethod(t *testing.T) {
 	tests := []struct {
 		input    string
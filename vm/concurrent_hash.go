@@ -2,7 +2,6 @@ package vm
 
 import (
 	"bytes"
-	"fmt"
 	"strings"
 	"sync"
 
@@ -19,17 +18,22 @@ import (
 // - size can't be retrieved;
 // - for the reasons above, the Hash APIs implemented are minimal.
 //
+// Keys follow the same rules as Hash's keys.
+//
 // For details, see https://golang.org/pkg/sync/#Map.
 //
 // ```ruby
 // require 'concurrent/hash'
-// hash = Concurrent::Hash.new({ "a": 1, "b": 2 })
-// hash["a"]  # => 1
+// hash = Concurrent::Hash.new({ a: 1, b: 2 })
+// hash[:a]  # => 1
 // ```
 //
 type ConcurrentHashObject struct {
 	*baseObj
+	// internalMap holds the buckets of pairs by their keys' hashKey, a bucket is replaced instead of modified
 	internalMap sync.Map
+	// writeMutex serializes the writes, so two writes to the same bucket don't lose each other's pairs
+	writeMutex sync.Mutex
 }
 
 // Class methods --------------------------------------------------------
@@ -44,7 +48,7 @@ func builtinConcurrentHashClassMethods() []*BuiltinMethodObject {
 					}
 
 					if len(args) == 0 {
-						return t.vm.initConcurrentHashObject(make(map[string]Object))
+						return t.vm.initConcurrentHashObject(nil)
 					}
 
 					arg := args[0]
@@ -54,7 +58,7 @@ func builtinConcurrentHashClassMethods() []*BuiltinMethodObject {
 						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.HashClass, arg.Class().Name)
 					}
 
-					return t.vm.initConcurrentHashObject(hashArg.Pairs)
+					return t.vm.initConcurrentHashObject(hashArg.pairs)
 				}
 			},
 		},
@@ -70,9 +74,9 @@ func builtinConcurrentHashInstanceMethods() []*BuiltinMethodObject {
 			//
 			// ```Ruby
 			// h = Concurrent::Hash.new({ a: 1, b: "2" })
-			// h['a'] #=> 1
-			// h['b'] #=> "2"
-			// h['c'] #=> nil
+			// h[:a] #=> 1
+			// h[:b] #=> "2"
+			// h[:c] #=> nil
 			// ```
 			//
 			// @return [Object]
@@ -84,22 +88,18 @@ func builtinConcurrentHashInstanceMethods() []*BuiltinMethodObject {
 						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "Expect 1 argument. got: %d", len(args))
 					}
 
-					i := args[0]
-					key, ok := i.(*StringObject)
-
-					if !ok {
-						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.StringClass, i.Class().Name)
-					}
-
 					h := receiver.(*ConcurrentHashObject)
+					pair, err := h.load(t, args[0], sourceLine)
 
-					value, ok := h.internalMap.Load(key.value)
+					if err != nil {
+						return err
+					}
 
-					if !ok {
+					if pair == nil {
 						return NULL
 					}
 
-					return value.(Object)
+					return pair.value
 				}
 			},
 		},
@@ -109,7 +109,7 @@ func builtinConcurrentHashInstanceMethods() []*BuiltinMethodObject {
 			//
 			// ```Ruby
 			// h = Concurrent::Hash.new{ a: 1, b: "2" })
-			// h['a'] = 2          #=> 2
+			// h[:a] = 2          #=> 2
 			// h                   #=> { a: 2, b: "2" }
 			// ```
 			//
@@ -124,16 +124,13 @@ func builtinConcurrentHashInstanceMethods() []*BuiltinMethodObject {
 						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "Expect 2 arguments. got: %d", len(args))
 					}
 
-					k := args[0]
-					key, ok := k.(*StringObject)
+					h := receiver.(*ConcurrentHashObject)
+					err := h.store(t, args[0], args[1], sourceLine)
 
-					if !ok {
-						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.StringClass, k.Class().Name)
+					if err != nil {
+						return err
 					}
 
-					h := receiver.(*ConcurrentHashObject)
-					h.internalMap.Store(key.value, args[1])
-
 					return args[1]
 				}
 			},
@@ -143,7 +140,7 @@ func builtinConcurrentHashInstanceMethods() []*BuiltinMethodObject {
 			//
 			// ```Ruby
 			// h = Concurrent::Hash.new({ a: 1, b: 2, c: 3 })
-			// h.delete("b") # => NULL
+			// h.delete(:b) # => NULL
 			// h             # => { a: 1, c: 3 }
 			// ```
 			//
@@ -156,21 +153,18 @@ func builtinConcurrentHashInstanceMethods() []*BuiltinMethodObject {
 					}
 
 					h := receiver.(*ConcurrentHashObject)
-					d := args[0]
-					deleteKeyObject, ok := d.(*StringObject)
+					err := h.delete(t, args[0], sourceLine)
 
-					if !ok {
-						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.StringClass, d.Class().Name)
+					if err != nil {
+						return err
 					}
 
-					h.internalMap.Delete(deleteKeyObject.value)
-
 					return NULL
 				}
 			},
 		},
 		{
-			// Calls block once for each key in the hash (in sorted key order), passing the
+			// Calls block once for each key in the hash, passing the
 			// key-value pair as parameters.
 			// Note that iteration is not deterministic under all circumstances; see
 			// https://golang.org/pkg/sync/#Map.
@@ -201,11 +195,11 @@ func builtinConcurrentHashInstanceMethods() []*BuiltinMethodObject {
 					framePopped := false
 
 					iterator := func(key, value interface{}) bool {
-						keyObject := t.vm.initStringObject(key.(string))
-
-						t.builtinMethodYield(blockFrame, keyObject, value.(Object))
+						for _, pair := range value.([]*hashPair) {
+							t.builtinMethodYield(blockFrame, pair.key, pair.value)
 
-						framePopped = true
+							framePopped = true
+						}
 
 						return true
 					}
@@ -225,8 +219,8 @@ func builtinConcurrentHashInstanceMethods() []*BuiltinMethodObject {
 			//
 			// ```Ruby
 			// h = Concurrent::Hash.new({ a: 1, b: "2" })
-			// h.has_key?("a") # => true
-			// h.has_key?("e") # => false
+			// h.has_key?(:a) # => true
+			// h.has_key?(:e) # => false
 			// ```
 			//
 			// @return [Boolean]
@@ -238,18 +232,13 @@ func builtinConcurrentHashInstanceMethods() []*BuiltinMethodObject {
 					}
 
 					h := receiver.(*ConcurrentHashObject)
-					i := args[0]
-					input, ok := i.(*StringObject)
-
-					if !ok {
-						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.StringClass, i.Class().Name)
-					}
+					pair, err := h.load(t, args[0], sourceLine)
 
-					if _, ok := h.internalMap.Load(input.value); ok {
-						return TRUE
+					if err != nil {
+						return err
 					}
 
-					return FALSE
+					return toBooleanObject(pair != nil)
 				}
 			},
 		},
@@ -304,11 +293,18 @@ func builtinConcurrentHashInstanceMethods() []*BuiltinMethodObject {
 
 // Functions for initialization -----------------------------------------
 
-func (vm *VM) initConcurrentHashObject(pairs map[string]Object) *ConcurrentHashObject {
+func (vm *VM) initConcurrentHashObject(pairs []*hashPair) *ConcurrentHashObject {
 	var internalMap sync.Map
 
-	for key, value := range pairs {
-		internalMap.Store(key, value)
+	buckets := map[hashKey][]*hashPair{}
+
+	// The hash's keys are already unique, so only the pairs that share a hashKey need to be grouped
+	for _, p := range pairs {
+		buckets[p.hashKey] = append(buckets[p.hashKey], &hashPair{hashKey: p.hashKey, key: p.key, value: p.value})
+	}
+
+	for hk, bucket := range buckets {
+		internalMap.Store(hk, bucket)
 	}
 
 	concurrent := vm.loadConstant("Concurrent", true)
@@ -343,17 +339,10 @@ func (h *ConcurrentHashObject) toString() string {
 	var pairs []string
 
 	iterator := func(key, value interface{}) bool {
-		var template string
-
-		switch value.(type) {
-		case *StringObject:
-			template = "%s: \"%s\""
-		default:
-			template = "%s: %s"
+		for _, pair := range value.([]*hashPair) {
+			pairs = append(pairs, hashPairString(pair.key, inspectString(pair.value)))
 		}
 
-		pairs = append(pairs, fmt.Sprintf(template, key, value.(Object).toString()))
-
 		return true
 	}
 
@@ -366,21 +355,112 @@ func (h *ConcurrentHashObject) toString() string {
 	return out.String()
 }
 
-// toJSON returns the object's name as the JSON string format
+// toJSON returns the object's name as the JSON string format.
+// Keys with the same string form are merged, see mergeStringKeys.
+// Since the hash doesn't keep the insertion order, which of the merged values is kept isn't specified.
 func (h *ConcurrentHashObject) toJSON() string {
 	var out bytes.Buffer
 	var values []string
+	var hashPairs []*hashPair
 	out.WriteString("{")
 
 	iterator := func(key, value interface{}) bool {
-		values = append(values, generateJSONFromPair(key.(string), value.(Object)))
-
+		hashPairs = append(hashPairs, value.([]*hashPair)...)
 		return true
 	}
 
 	h.internalMap.Range(iterator)
+	keys, pairs := mergeStringKeys(hashPairs)
+
+	for _, k := range keys {
+		values = append(values, generateJSONFromPair(k, pairs[k]))
+	}
 
 	out.WriteString(strings.Join(values, ","))
 	out.WriteString("}")
 	return out.String()
 }
+
+// load returns the pair of the given key, or nil if the hash doesn't have the key
+func (h *ConcurrentHashObject) load(t *thread, key Object, sourceLine int) (*hashPair, *Error) {
+	hk, err := t.hashKeyOf(key, sourceLine)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t.findHashPair(h.bucket(hk), hk, key, sourceLine)
+}
+
+// store associates the value with the given key
+func (h *ConcurrentHashObject) store(t *thread, key, value Object, sourceLine int) *Error {
+	hk, err := t.hashKeyOf(key, sourceLine)
+
+	if err != nil {
+		return err
+	}
+
+	h.writeMutex.Lock()
+	defer h.writeMutex.Unlock()
+
+	bucket := h.bucket(hk)
+	pair, err := t.findHashPair(bucket, hk, key, sourceLine)
+
+	if err != nil {
+		return err
+	}
+
+	newBucket := []*hashPair{}
+
+	// An existing key keeps its original key object
+	if pair != nil {
+		key = pair.key
+		bucket = removeHashPair(bucket, pair)
+	}
+
+	newBucket = append(newBucket, bucket...)
+	newBucket = append(newBucket, &hashPair{hashKey: hk, key: key, value: value})
+	h.internalMap.Store(hk, newBucket)
+
+	return nil
+}
+
+// delete removes the pair of the given key if the hash has the key
+func (h *ConcurrentHashObject) delete(t *thread, key Object, sourceLine int) *Error {
+	hk, err := t.hashKeyOf(key, sourceLine)
+
+	if err != nil {
+		return err
+	}
+
+	h.writeMutex.Lock()
+	defer h.writeMutex.Unlock()
+
+	bucket := h.bucket(hk)
+	pair, err := t.findHashPair(bucket, hk, key, sourceLine)
+
+	if err != nil || pair == nil {
+		return err
+	}
+
+	bucket = removeHashPair(bucket, pair)
+
+	if len(bucket) == 0 {
+		h.internalMap.Delete(hk)
+	} else {
+		h.internalMap.Store(hk, bucket)
+	}
+
+	return nil
+}
+
+// bucket returns the pairs whose keys have the given hashKey
+func (h *ConcurrentHashObject) bucket(hk hashKey) []*hashPair {
+	value, ok := h.internalMap.Load(hk)
+
+	if !ok {
+		return nil
+	}
+
+	return value.([]*hashPair)
+}
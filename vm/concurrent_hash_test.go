@@ -125,7 +125,7 @@ func TestConcurrentHashAccessOperation(t *testing.T) {
 		`, 100},
 		{`
 		require 'concurrent/hash'
-		{}["foo"]
+		{}[:foo]
 		`, nil},
 		{`
 		require 'concurrent/hash'
@@ -133,7 +133,7 @@ func TestConcurrentHashAccessOperation(t *testing.T) {
 		`, "foo"},
 		{`
 		require 'concurrent/hash'
-		Concurrent::Hash.new({ bar: "foo" })["bar"]
+		Concurrent::Hash.new({ bar: "foo" })[:bar]
 		`, "foo"},
 		{`
 		require 'concurrent/hash'
@@ -141,49 +141,91 @@ func TestConcurrentHashAccessOperation(t *testing.T) {
 		`, 2},
 		{`
 		require 'concurrent/hash'
-		Concurrent::Hash.new({ foo: 2, bar: "foo" })["foo"]
+		Concurrent::Hash.new({ foo: 2, bar: "foo" })[:foo]
 		`, 2},
 		{`
 		require 'concurrent/hash'
 		h = Concurrent::Hash.new({ bar: "Foo" })
-		h["bar"]
+		h[:bar]
 		`, "Foo"},
 		{`
 		require 'concurrent/hash'
 		h = Concurrent::Hash.new({ bar: 1, foo: 2 })
-		h["foo"] = h["bar"]
-		h["foo"]
+		h[:foo] = h[:bar]
+		h[:foo]
 
 		`, 1},
 		{`
 		require 'concurrent/hash'
 		h = Concurrent::Hash.new({})
-		h["foo"] = 100
-		h["foo"]
+		h[:foo] = 100
+		h[:foo]
 		`, 100},
 		{`
 		require 'concurrent/hash'
 		h = Concurrent::Hash.new({})
-		h["foo"] = Concurrent::Hash.new({ bar: 100 })
-		h["foo"]["bar"]
+		h[:foo] = Concurrent::Hash.new({ bar: 100 })
+		h[:foo][:bar]
 		`, 100},
 		{`
 		require 'concurrent/hash'
 		h = Concurrent::Hash.new({ foo: { bar: [1, 2, 3] }})
-		h["foo"]["bar"][0] + h["foo"]["bar"][1]
+		h[:foo][:bar][0] + h[:foo][:bar][1]
 		`, 3},
 		{`
 		require 'concurrent/hash'
 		h = Concurrent::Hash.new({})
-		h["foo"] = 100
-		h["bar"]
+		h[:foo] = 100
+		h[:bar]
 		`, nil},
 		{`
 		require 'concurrent/hash'
 		h = Concurrent::Hash.new({ foo: 1, bar: 5, baz: 10 })
-		h["foo"] = h["bar"] * h["baz"]
-		h["foo"]
+		h[:foo] = h[:bar] * h[:baz]
+		h[:foo]
 		`, 50},
+		{`
+		require 'concurrent/hash'
+		h = Concurrent::Hash.new({ foo: 1 })
+		h[1] = 2
+		h[[1, :a]] = 3
+		h[:foo] + h[1] + h[[1, :a]]
+		`, 6},
+		{`
+		require 'concurrent/hash'
+		h = Concurrent::Hash.new({})
+		h[nil] = 1
+		h.has_key?(nil)
+		`, true},
+		{`
+		require 'concurrent/hash'
+		class Key
+		  def initialize(id)
+		    @id = id
+		  end
+		  def id
+		    @id
+		  end
+		  def hash
+		    1
+		  end
+		  def eql?(other)
+		    @id == other.id
+		  end
+		end
+
+		h = Concurrent::Hash.new({})
+		h[Key.new(1)] = "a"
+		h[Key.new(2)] = "b"
+		h[Key.new(1)] = "c"
+		h.delete(Key.new(2))
+		h[Key.new(1)] + h[Key.new(2)].to_s
+		`, "c"},
+		{`
+		require 'concurrent/hash'
+		h = Concurrent::Hash.new({ "a" => 1, a: 2 })
+		h["a"] + h[:a]
+		`, 3},
 	}
 
 	for i, tt := range tests {
@@ -200,12 +242,6 @@ func TestConcurrentHashAccessOperationFail(t *testing.T) {
 		{`
 		require 'concurrent/hash'
 		Concurrent::Hash.new({ a: 1, b: 2 })[]`, "ArgumentError: Expect 1 argument. got: 0", 3, 3},
-		{`
-		require 'concurrent/hash'
-		Concurrent::Hash.new({ a: 1, b: 2 })[true]`, "TypeError: Expect argument to be String. got: Boolean", 3, 3},
-		{`
-		require 'concurrent/hash'
-		Concurrent::Hash.new({ a: 1, b: 2 })[true] = 1`, "TypeError: Expect argument to be String. got: Boolean", 3, 3},
 	}
 
 	for i, tt := range testsFail {
@@ -225,56 +261,56 @@ func TestConcurrentHashDeleteMethod(t *testing.T) {
 		{`
 		require 'concurrent/hash'
 		h = Concurrent::Hash.new({ a: 1, b: "Hello", c: true })
-		h.delete("a")
-		h["a"]
+		h.delete(:a)
+		h[:a]
 		`, nil},
 		{`
 		require 'concurrent/hash'
 		h = Concurrent::Hash.new({ a: 1, b: "Hello", c: true })
-		h.delete("a")
-		h["b"]
+		h.delete(:a)
+		h[:b]
 		`, "Hello"},
 		{`
 		require 'concurrent/hash'
 		h = Concurrent::Hash.new({ a: 1, b: "Hello", c: true })
-		h.delete("a")
-		h["c"]
+		h.delete(:a)
+		h[:c]
 		`, true},
 		{`
 		require 'concurrent/hash'
 		h = Concurrent::Hash.new({ a: 1, b: "Hello", c: true })
-		h.delete("b")
-		h["a"]
+		h.delete(:b)
+		h[:a]
 		`, 1},
 		{`
 		require 'concurrent/hash'
 		h = Concurrent::Hash.new({ a: 1, b: "Hello", c: true })
-		h.delete("b")
-		h["b"]
+		h.delete(:b)
+		h[:b]
 		`, nil},
 		{`
 		require 'concurrent/hash'
 		h = Concurrent::Hash.new({ a: 1, b: "Hello", c: true })
-		h.delete("b")
-		h["c"]
+		h.delete(:b)
+		h[:c]
 		`, true},
 		{`
 		require 'concurrent/hash'
 		h = Concurrent::Hash.new({ a: 1, b: "Hello", c: true })
-		h.delete("c")
-		h["a"]
+		h.delete(:c)
+		h[:a]
 		`, 1},
 		{`
 		require 'concurrent/hash'
 		h = Concurrent::Hash.new({ a: 1, b: "Hello", c: true })
-		h.delete("c")
-		h["b"]
+		h.delete(:c)
+		h[:b]
 		`, "Hello"},
 		{`
 		require 'concurrent/hash'
 		h = Concurrent::Hash.new({ a: 1, b: "Hello", c: true })
-		h.delete("c")
-		h["c"]
+		h.delete(:c)
+		h[:c]
 		`, nil},
 	}
 
@@ -295,12 +331,6 @@ func TestConcurrentHashDeleteMethodFail(t *testing.T) {
 		{`
 		require 'concurrent/hash'
 		Concurrent::Hash.new({ a: 1, b: "Hello", c: true }).delete("a", "b")`, "ArgumentError: Expect 1 argument. got: 2", 3, 3},
-		{`
-		require 'concurrent/hash'
-		Concurrent::Hash.new({ a: 1, b: "Hello", c: true }).delete(123)`, "TypeError: Expect argument to be String. got: Integer", 3, 3},
-		{`
-		require 'concurrent/hash'
-		Concurrent::Hash.new({ a: 1, b: "Hello", c: true }).delete(true)`, "TypeError: Expect argument to be String. got: Boolean", 3, 3},
 	}
 
 	for i, tt := range testsFail {
@@ -320,7 +350,7 @@ func TestConcurrentHashEachMethod(t *testing.T) {
 		// return value
 		{`
 		require 'concurrent/hash'
-		Concurrent::Hash.new({ b: "2" }).each do end
+		Concurrent::Hash.new({ "b" => "2" }).each do end
 		`, map[string]interface{}{"b": "2"}},
 		// empty hash
 		{`
@@ -345,7 +375,7 @@ func TestConcurrentHashEachMethod(t *testing.T) {
 		{`
 		require 'concurrent/hash'
 		output = []
-		h = Concurrent::Hash.new({ b: "2" })
+		h = Concurrent::Hash.new({ "b" => "2" })
 		h.each do |k, v|
 			output.push([k, v])
 		end
@@ -388,7 +418,7 @@ func TestConcurrentHashHasKeyMethod(t *testing.T) {
 	}{
 		{`
 		require 'concurrent/hash'
-		Concurrent::Hash.new({ a: "Hello", b: 123, c: true }).has_key?("a")`, true},
+		Concurrent::Hash.new({ a: "Hello", b: 123, c: true }).has_key?(:a)`, true},
 		{`
 		require 'concurrent/hash'
 		Concurrent::Hash.new({ a: "Hello", b: 123, c: true }).has_key?("d")`, false},
@@ -411,12 +441,6 @@ func TestConcurrentHashHasKeyMethodFail(t *testing.T) {
 		{`
 		require 'concurrent/hash'
 		Concurrent::Hash.new({ a: 1, b: 2 }).has_key?(true, { hello: "World" })`, "ArgumentError: Expect 1 argument. got: 2", 3, 3},
-		{`
-		require 'concurrent/hash'
-		Concurrent::Hash.new({ a: 1, b: 2 }).has_key?(true)`, "TypeError: Expect argument to be String. got: Boolean", 3, 3},
-		{`
-		require 'concurrent/hash'
-		Concurrent::Hash.new({ a: 1, b: 2 }).has_key?(123)`, "TypeError: Expect argument to be String. got: Integer", 3, 3},
 	}
 
 	for i, tt := range testsFail {
@@ -618,6 +642,12 @@ func TestConcurrentHashToStringMethod(t *testing.T) {
 		{`
 		require 'concurrent/hash'
 		Concurrent::Hash.new({ b: "Hello" }).to_s`, "{ b: \"Hello\" }"},
+		{`
+		require 'concurrent/hash'
+		Concurrent::Hash.new({ "b" => 1 }).to_s`, "{ \"b\" => 1 }"},
+		{`
+		require 'concurrent/hash'
+		Concurrent::Hash.new({ "b" => 1, b: 1 }).to_json`, `{"b":1}`},
 	}
 
 	for i, tt := range tests {
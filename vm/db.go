@@ -55,7 +55,7 @@ func builtinDBClassMethods() []*BuiltinMethodObject {
 					conn, err := sqlx.Open(driverName.value, dataSource.value)
 
 					if err != nil {
-						return t.vm.initErrorObject(errors.InternalError, sourceLine, err.Error())
+						return t.vm.initErrorObject(errors.InternalError, sourceLine, "%s", err.Error())
 					}
 
 					connObj := t.vm.initObjectFromGoType(conn)
@@ -88,7 +88,7 @@ func builtinDBInstanceMethods() []*BuiltinMethodObject {
 					conn, err := getDBConn(t, receiver)
 
 					if err != nil {
-						return t.vm.initErrorObject(errors.InternalError, sourceLine, err.Error())
+						return t.vm.initErrorObject(errors.InternalError, sourceLine, "%s", err.Error())
 					}
 
 					err = conn.Close()
@@ -114,20 +114,20 @@ func builtinDBInstanceMethods() []*BuiltinMethodObject {
 					conn, err := getDBConn(t, receiver)
 
 					if err != nil {
-						return t.vm.initErrorObject(errors.InternalError, sourceLine, err.Error())
+						return t.vm.initErrorObject(errors.InternalError, sourceLine, "%s", err.Error())
 					}
 
 					queryString := args[0].(*StringObject).value
 					execArgs := []interface{}{}
 
 					for _, arg := range args[1:] {
-						execArgs = append(execArgs, arg.Value())
+						execArgs = append(execArgs, dbArgValue(arg))
 					}
 
 					_, err = conn.Exec(queryString, execArgs...)
 
 					if err != nil {
-						return t.vm.initErrorObject(errors.InternalError, sourceLine, err.Error())
+						return t.vm.initErrorObject(errors.InternalError, sourceLine, "%s", err.Error())
 					}
 
 					return TRUE
@@ -172,14 +172,14 @@ func builtinDBInstanceMethods() []*BuiltinMethodObject {
 					conn, err := getDBConn(t, receiver)
 
 					if err != nil {
-						return t.vm.initErrorObject(errors.InternalError, sourceLine, err.Error())
+						return t.vm.initErrorObject(errors.InternalError, sourceLine, "%s", err.Error())
 					}
 
 					queryString := args[0].(*StringObject).value
 					execArgs := []interface{}{}
 
 					for _, arg := range args[1:] {
-						execArgs = append(execArgs, arg.Value())
+						execArgs = append(execArgs, dbArgValue(arg))
 					}
 
 					// The reason I implement this way: https://github.com/lib/pq/issues/24
@@ -188,7 +188,7 @@ func builtinDBInstanceMethods() []*BuiltinMethodObject {
 					err = conn.QueryRow(fmt.Sprintf("%s RETURNING id", queryString), execArgs...).Scan(&id)
 
 					if err != nil {
-						return t.vm.initErrorObject(errors.InternalError, sourceLine, err.Error())
+						return t.vm.initErrorObject(errors.InternalError, sourceLine, "%s", err.Error())
 					}
 
 					return t.vm.initIntegerObject(id)
@@ -223,6 +223,8 @@ func builtinDBInstanceMethods() []*BuiltinMethodObject {
 			//
 			// ```
 			//
+			// Values of NUMERIC columns are returned as `Decimal`s.
+			//
 			// @return [Array]
 			//
 			Name: "query",
@@ -235,20 +237,35 @@ func builtinDBInstanceMethods() []*BuiltinMethodObject {
 					conn, err := getDBConn(t, receiver)
 
 					if err != nil {
-						return t.vm.initErrorObject(errors.InternalError, sourceLine, err.Error())
+						return t.vm.initErrorObject(errors.InternalError, sourceLine, "%s", err.Error())
 					}
 
 					queryString := args[0].(*StringObject).value
 					execArgs := []interface{}{}
 
 					for _, arg := range args[1:] {
-						execArgs = append(execArgs, arg.Value())
+						execArgs = append(execArgs, dbArgValue(arg))
 					}
 
 					rows, err := conn.Queryx(queryString, execArgs...)
 
 					if err != nil {
-						return t.vm.initErrorObject(errors.InternalError, sourceLine, err.Error())
+						return t.vm.initErrorObject(errors.InternalError, sourceLine, "%s", err.Error())
+					}
+
+					columnTypes, err := rows.ColumnTypes()
+
+					if err != nil {
+						return t.vm.initErrorObject(errors.InternalError, sourceLine, "%s", err.Error())
+					}
+
+					// NUMERIC columns are returned as decimals so they keep their exact values
+					numericColumns := map[string]bool{}
+
+					for _, ct := range columnTypes {
+						if ct.DatabaseTypeName() == "NUMERIC" {
+							numericColumns[ct.Name()] = true
+						}
 					}
 
 					results := []Object{}
@@ -259,12 +276,20 @@ func builtinDBInstanceMethods() []*BuiltinMethodObject {
 						err = rows.MapScan(row)
 
 						if err != nil {
-							return t.vm.initErrorObject(errors.InternalError, sourceLine, err.Error())
+							return t.vm.initErrorObject(errors.InternalError, sourceLine, "%s", err.Error())
 						}
 
 						data := map[string]Object{}
 
 						for k, v := range row {
+							if bytes, ok := v.([]byte); ok && numericColumns[k] {
+								if value, ok := parseDecimal(string(bytes)); ok {
+									t.vm.loadDecimalClass()
+									data[k] = t.vm.initDecimalObject(value)
+									continue
+								}
+							}
+
 							data[k] = t.vm.initObjectFromGoType(v)
 						}
 
@@ -294,6 +319,15 @@ func initDBClass(vm *VM) {
 
 // Other helper functions -----------------------------------------------
 
+// dbArgValue returns the Go value passed to the database driver, decimals are passed as strings to keep their precision
+func dbArgValue(arg Object) interface{} {
+	if d, ok := arg.(*DecimalObject); ok {
+		return d.toString()
+	}
+
+	return arg.Value()
+}
+
 func getDBConn(t *thread, receiver Object) (*sqlx.DB, error) {
 	connection, _ := receiver.instanceVariableGet("@connection")
 	connObj, _ := connection.instanceVariableGet("@conn_obj")
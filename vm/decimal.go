@@ -0,0 +1,816 @@
+package vm
+
+import (
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/goby-lang/goby/vm/classes"
+	"github.com/goby-lang/goby/vm/errors"
+)
+
+// DecimalObject represents an exact decimal number with arbitrary precision.
+// Unlike Float, decimal fractions like `0.1` are stored exactly, so it's suitable for things like money.
+//
+// ```ruby
+// require "decimal"
+//
+// Decimal.new("0.1") + Decimal.new("0.2") == Decimal.new("0.3") # => true
+// Decimal.new("19.99") * 3                                   # => 59.97
+// Decimal.new("10") / 4                                      # => 2.5
+// ```
+//
+// Decimals work with Integers on both sides of the operators. Floats are converted to decimals by their shortest representation.
+//
+// Division keeps 20 decimal places, the last place is rounded with `Decimal.rounding_mode`, which is `:half_up` by default.
+// The supported rounding modes are `:half_up`, `:half_even`, `:half_down`, `:up`, `:down`, `:ceiling` and `:floor`.
+//
+// ```ruby
+// Decimal.new("2").round        # => 2
+// Decimal.new("2.5").round      # => 3
+// Decimal.rounding_mode = :half_even
+// Decimal.new("2.5").round      # => 2
+// Decimal.new("2.5").round(0, :up) # => 3
+// ```
+type DecimalObject struct {
+	*baseObj
+	value *big.Rat
+}
+
+// decimalDivisionScale is the number of decimal places kept by divisions
+const decimalDivisionScale = 20
+
+const defaultDecimalRoundingMode = "half_up"
+
+var decimalRoundingModes = map[string]bool{
+	"half_up":   true,
+	"half_even": true,
+	"half_down": true,
+	"up":        true,
+	"down":      true,
+	"ceiling":   true,
+	"floor":     true,
+}
+
+var decimalStringRegexp = regexp.MustCompile(`^[+-]?\d+(\.\d+)?([eE][+-]?\d+)?$`)
+
+// Class methods --------------------------------------------------------
+func builtinDecimalClassMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Returns a decimal of the given String, Integer, Float or Decimal.
+			//
+			// ```ruby
+			// Decimal.new("1.25") # => 1.25
+			// Decimal.new(3)      # => 3
+			// Decimal.new(0.1)    # => 0.1
+			// ```
+			//
+			// @param value [Object] the value of the decimal
+			// @return [Decimal]
+			Name: "new",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					if s, ok := args[0].(*StringObject); ok {
+						value, ok := parseDecimal(s.value)
+
+						if !ok {
+							return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "Invalid value for Decimal: %q", s.value)
+						}
+
+						return t.vm.initDecimalObject(value)
+					}
+
+					value, err := t.decimalValueOf(args[0], sourceLine)
+
+					if err != nil {
+						return err
+					}
+
+					return t.vm.initDecimalObject(value)
+				}
+			},
+		},
+		{
+			// Returns the rounding mode used by `round` and divisions.
+			//
+			// ```ruby
+			// Decimal.rounding_mode # => :half_up
+			// ```
+			//
+			// @return [Symbol]
+			Name: "rounding_mode",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					return t.vm.initSymbolObject(decimalRoundingMode(receiver.(*RClass)))
+				}
+			},
+		},
+		{
+			// Sets the rounding mode used by `round` and divisions.
+			//
+			// ```ruby
+			// Decimal.rounding_mode = :half_even
+			// ```
+			//
+			// @param mode [Symbol] one of `:half_up`, `:half_even`, `:half_down`, `:up`, `:down`, `:ceiling` or `:floor`
+			// @return [Symbol]
+			Name: "rounding_mode=",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					mode, err := t.checkDecimalRoundingMode(args[0], sourceLine)
+
+					if err != nil {
+						return err
+					}
+
+					receiver.instanceVariableSet("@rounding_mode", t.vm.initSymbolObject(mode))
+
+					return args[0]
+				}
+			},
+		},
+	}
+}
+
+// Instance methods -----------------------------------------------------
+func builtinDecimalInstanceMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Returns the sum of self and a Numeric.
+			//
+			// ```ruby
+			// Decimal.new("1.1") + 2 # => 3.1
+			// ```
+			//
+			// @return [Decimal]
+			Name: "+",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					return receiver.(*DecimalObject).arithmeticOperation(t, "+", args[0], sourceLine)
+				}
+			},
+		},
+		{
+			// Returns the subtraction of a Numeric from self.
+			//
+			// ```ruby
+			// Decimal.new("1.1") - 2 # => -0.9
+			// ```
+			//
+			// @return [Decimal]
+			Name: "-",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					return receiver.(*DecimalObject).arithmeticOperation(t, "-", args[0], sourceLine)
+				}
+			},
+		},
+		{
+			// Returns the negation of self, it's called by the unary minus.
+			//
+			// ```ruby
+			// -Decimal.new("1.1") # => -1.1
+			// ```
+			//
+			// @return [Decimal]
+			Name: "-@",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					return t.vm.initDecimalObject(new(big.Rat).Neg(receiver.(*DecimalObject).value))
+				}
+			},
+		},
+		{
+			// Returns self multiplying a Numeric.
+			//
+			// ```ruby
+			// Decimal.new("1.1") * 3 # => 3.3
+			// ```
+			//
+			// @return [Decimal]
+			Name: "*",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					return receiver.(*DecimalObject).arithmeticOperation(t, "*", args[0], sourceLine)
+				}
+			},
+		},
+		{
+			// Returns self divided by a Numeric, the result keeps 20 decimal places.
+			//
+			// ```ruby
+			// Decimal.new("1") / 4 # => 0.25
+			// Decimal.new("2") / 3 # => 0.66666666666666666667
+			// ```
+			//
+			// @return [Decimal]
+			Name: "/",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					return receiver.(*DecimalObject).arithmeticOperation(t, "/", args[0], sourceLine)
+				}
+			},
+		},
+		{
+			// Returns the remainder of self divided by a Numeric.
+			// Like Integer's `%`, the result has the same sign as self.
+			//
+			// ```ruby
+			// Decimal.new("5.5") % 2 # => 1.5
+			// ```
+			//
+			// @return [Decimal]
+			Name: "%",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					return receiver.(*DecimalObject).arithmeticOperation(t, "%", args[0], sourceLine)
+				}
+			},
+		},
+		{
+			// Returns self to the power of an Integer.
+			//
+			// ```ruby
+			// Decimal.new("1.5") ** 2 # => 2.25
+			// ```
+			//
+			// @return [Decimal]
+			Name: "**",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					return receiver.(*DecimalObject).arithmeticOperation(t, "**", args[0], sourceLine)
+				}
+			},
+		},
+		{
+			// Returns if self is larger than a Numeric.
+			//
+			// ```ruby
+			// Decimal.new("1.5") > 1 # => true
+			// ```
+			//
+			// @return [Boolean]
+			Name: ">",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					comparison := func(result int) bool {
+						return result > 0
+					}
+
+					return receiver.(*DecimalObject).numericComparison(t, args[0], comparison, sourceLine)
+				}
+			},
+		},
+		{
+			// Returns if self is larger than or equals to a Numeric.
+			//
+			// ```ruby
+			// Decimal.new("1.0") >= 1 # => true
+			// ```
+			//
+			// @return [Boolean]
+			Name: ">=",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					comparison := func(result int) bool {
+						return result >= 0
+					}
+
+					return receiver.(*DecimalObject).numericComparison(t, args[0], comparison, sourceLine)
+				}
+			},
+		},
+		{
+			// Returns if self is smaller than a Numeric.
+			//
+			// ```ruby
+			// Decimal.new("0.5") < 1 # => true
+			// ```
+			//
+			// @return [Boolean]
+			Name: "<",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					comparison := func(result int) bool {
+						return result < 0
+					}
+
+					return receiver.(*DecimalObject).numericComparison(t, args[0], comparison, sourceLine)
+				}
+			},
+		},
+		{
+			// Returns if self is smaller than or equals to a Numeric.
+			//
+			// ```ruby
+			// Decimal.new("1.0") <= 1 # => true
+			// ```
+			//
+			// @return [Boolean]
+			Name: "<=",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					comparison := func(result int) bool {
+						return result <= 0
+					}
+
+					return receiver.(*DecimalObject).numericComparison(t, args[0], comparison, sourceLine)
+				}
+			},
+		},
+		{
+			// Returns 1 if self is larger than the given Numeric, -1 if smaller. Otherwise 0.
+			//
+			// ```ruby
+			// Decimal.new("1.5") <=> 2 # => -1
+			// ```
+			//
+			// @return [Integer]
+			Name: "<=>",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					right, err := t.decimalValueOf(args[0], sourceLine)
+
+					if err != nil {
+						return err
+					}
+
+					return t.vm.initIntegerObject(receiver.(*DecimalObject).value.Cmp(right))
+				}
+			},
+		},
+		{
+			// Returns if self is equal to an Object.
+			// If the Object is a Numeric, a comparison is performed, otherwise, the result is always false.
+			//
+			// ```ruby
+			// Decimal.new("1.0") == 1   # => true
+			// Decimal.new("0.1") == 0.1 # => true
+			// Decimal.new("1") == "1"   # => false
+			// ```
+			//
+			// @return [Boolean]
+			Name: "==",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					return toBooleanObject(receiver.(*DecimalObject).equalityTest(args[0]))
+				}
+			},
+		},
+		{
+			// Returns if self is not equal to an Object.
+			//
+			// ```ruby
+			// Decimal.new("1.1") != 1 # => true
+			// ```
+			//
+			// @return [Boolean]
+			Name: "!=",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					return toBooleanObject(!receiver.(*DecimalObject).equalityTest(args[0]))
+				}
+			},
+		},
+		{
+			// Rounds self to the given decimal places, with the given rounding mode or `Decimal.rounding_mode`.
+			// Negative places round to tens, hundreds and so on.
+			//
+			// ```ruby
+			// Decimal.new("1.235").round(2)              # => 1.24
+			// Decimal.new("1.235").round(2, :half_even)  # => 1.24
+			// Decimal.new("1.225").round(2, :half_even)  # => 1.22
+			// Decimal.new("1.221").round(2, :up)         # => 1.23
+			// Decimal.new("1250").round(-2)              # => 1300
+			// ```
+			//
+			// @param places [Integer] decimal places to keep, 0 by default
+			// @param mode [Symbol] rounding mode
+			// @return [Decimal]
+			Name: "round",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) > 2 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "Expect 2 or less arguments. got: %d", len(args))
+					}
+
+					d := receiver.(*DecimalObject)
+					places := 0
+					mode := decimalRoundingMode(d.Class())
+
+					if len(args) > 0 {
+						p, ok := args[0].(*IntegerObject)
+
+						if !ok {
+							return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.IntegerClass, args[0].Class().Name)
+						}
+
+						places = p.value
+					}
+
+					if len(args) > 1 {
+						m, err := t.checkDecimalRoundingMode(args[1], sourceLine)
+
+						if err != nil {
+							return err
+						}
+
+						mode = m
+					}
+
+					return t.vm.initDecimalObject(roundRat(d.value, places, mode))
+				}
+			},
+		},
+		{
+			// Returns the Float closest to self.
+			//
+			// ```ruby
+			// Decimal.new("1.25").to_f # => 1.25
+			// ```
+			//
+			// @return [Float]
+			Name: "to_f",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					return t.vm.initFloatObject(receiver.(*DecimalObject).floatValue())
+				}
+			},
+		},
+		{
+			// Returns the Integer part of self.
+			//
+			// ```ruby
+			// Decimal.new("-1.75").to_i # => -1
+			// ```
+			//
+			// @return [Integer]
+			Name: "to_i",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					r := receiver.(*DecimalObject).value
+
+					return t.vm.initBigIntegerObject(new(big.Int).Quo(r.Num(), r.Denom()))
+				}
+			},
+		},
+		{
+			// Returns self.
+			//
+			// ```ruby
+			// Decimal.new("1.5").to_d # => 1.5
+			// ```
+			//
+			// @return [Decimal]
+			Name: "to_d",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					return receiver
+				}
+			},
+		},
+		{
+			// Returns the exact decimal representation of self.
+			//
+			// ```ruby
+			// Decimal.new("1.50").to_s # => "1.5"
+			// Decimal.new("1e3").to_s  # => "1000"
+			// ```
+			//
+			// @return [String]
+			Name: "to_s",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					return t.vm.initStringObject(receiver.toString())
+				}
+			},
+		},
+		{
+			// Returns if self is zero.
+			//
+			// ```ruby
+			// Decimal.new("0.0").zero? # => true
+			// ```
+			//
+			// @return [Boolean]
+			Name: "zero?",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					return toBooleanObject(receiver.(*DecimalObject).value.Sign() == 0)
+				}
+			},
+		},
+	}
+}
+
+// Internal functions ===================================================
+
+// Functions for initialization -----------------------------------------
+
+func (vm *VM) initDecimalObject(value *big.Rat) *DecimalObject {
+	return &DecimalObject{
+		baseObj: &baseObj{class: vm.topLevelClass(classes.DecimalClass)},
+		value:   value,
+	}
+}
+
+func initDecimalClass(vm *VM) {
+	dc := vm.initializeClass(classes.DecimalClass, false)
+	dc.setBuiltinMethods(builtinDecimalInstanceMethods(), false)
+	dc.setBuiltinMethods(builtinDecimalClassMethods(), true)
+	dc.includeModule(vm.objectClass.getClassConstant(classes.ComparableModule))
+	vm.objectClass.setClassConstant(dc)
+}
+
+// loadDecimalClass initializes the Decimal class if it hasn't been required, it's for returning decimals from other libraries
+func (vm *VM) loadDecimalClass() {
+	if vm.objectClass.constants[classes.DecimalClass] == nil {
+		initDecimalClass(vm)
+	}
+}
+
+// Polymorphic helper functions -----------------------------------------
+
+// Value returns the object
+func (d *DecimalObject) Value() interface{} {
+	return d.value
+}
+
+// Numeric interface
+func (d *DecimalObject) floatValue() float64 {
+	f, _ := d.value.Float64()
+	return f
+}
+
+// toString returns the exact decimal representation of the value
+func (d *DecimalObject) toString() string {
+	s := d.value.FloatString(decimalPlaces(d.value))
+
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+
+	return s
+}
+
+// toJSON returns the value as a JSON number
+func (d *DecimalObject) toJSON() string {
+	return d.toString()
+}
+
+// Apply the given operator to self and another Numeric.
+// It's also used by Integer's operators when the right hand side is a Decimal.
+func (d *DecimalObject) arithmeticOperation(t *thread, operator string, rightObject Object, sourceLine int) Object {
+	if operator == "**" {
+		exponent, ok := rightObject.(*IntegerObject)
+
+		if !ok {
+			return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.IntegerClass, rightObject.Class().Name)
+		}
+
+		if exponent.bigValue != nil {
+			return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "Exponent is too large: %s", exponent.toString())
+		}
+
+		num := new(big.Int).Exp(d.value.Num(), big.NewInt(int64(abs(exponent.value))), nil)
+		denom := new(big.Int).Exp(d.value.Denom(), big.NewInt(int64(abs(exponent.value))), nil)
+
+		if exponent.value >= 0 {
+			return t.vm.initDecimalObject(new(big.Rat).SetFrac(num, denom))
+		}
+
+		if num.Sign() == 0 {
+			return t.vm.initErrorObject(errors.ZeroDivisionError, sourceLine, "Divided by 0")
+		}
+
+		result := new(big.Rat).SetFrac(denom, num)
+		return t.vm.initDecimalObject(roundRat(result, decimalDivisionScale, decimalRoundingMode(d.Class())))
+	}
+
+	right, err := t.decimalValueOf(rightObject, sourceLine)
+
+	if err != nil {
+		return err
+	}
+
+	result := new(big.Rat)
+
+	switch operator {
+	case "+":
+		result.Add(d.value, right)
+	case "-":
+		result.Sub(d.value, right)
+	case "*":
+		result.Mul(d.value, right)
+	case "/", "%":
+		if right.Sign() == 0 {
+			return t.vm.initErrorObject(errors.ZeroDivisionError, sourceLine, "Divided by 0")
+		}
+
+		result.Quo(d.value, right)
+
+		if operator == "/" {
+			result = roundRat(result, decimalDivisionScale, decimalRoundingMode(d.Class()))
+			break
+		}
+
+		quotient := new(big.Int).Quo(result.Num(), result.Denom())
+		result.Sub(d.value, new(big.Rat).Mul(new(big.Rat).SetInt(quotient), right))
+	}
+
+	return t.vm.initDecimalObject(result)
+}
+
+// Apply the given comparison to the result of comparing self with another Numeric.
+func (d *DecimalObject) numericComparison(t *thread, rightObject Object, comparison func(result int) bool, sourceLine int) Object {
+	right, err := t.decimalValueOf(rightObject, sourceLine)
+
+	if err != nil {
+		return err
+	}
+
+	return toBooleanObject(comparison(d.value.Cmp(right)))
+}
+
+// Apply an equality test, returning true if the objects are considered equal, and false otherwise.
+func (d *DecimalObject) equalityTest(rightObject Object) bool {
+	switch right := rightObject.(type) {
+	case *DecimalObject:
+		return d.value.Cmp(right.value) == 0
+	case *IntegerObject:
+		return d.value.Cmp(new(big.Rat).SetInt(right.bigInt())) == 0
+	case *FloatObject:
+		value, ok := floatToRat(right.value)
+		return ok && d.value.Cmp(value) == 0
+	default:
+		return false
+	}
+}
+
+// Other helper functions -----------------------------------------------
+
+// decimalValueOf converts a Numeric to the value of a decimal
+func (t *thread) decimalValueOf(obj Object, sourceLine int) (*big.Rat, *Error) {
+	switch obj := obj.(type) {
+	case *DecimalObject:
+		return obj.value, nil
+	case *IntegerObject:
+		return new(big.Rat).SetInt(obj.bigInt()), nil
+	case *FloatObject:
+		value, ok := floatToRat(obj.value)
+
+		if !ok {
+			return nil, t.vm.initErrorObject(errors.ArgumentError, sourceLine, "Can't convert %s to Decimal", obj.toString())
+		}
+
+		return value, nil
+	default:
+		return nil, t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, "Numeric", obj.Class().Name)
+	}
+}
+
+// checkDecimalRoundingMode returns the name of the given rounding mode, or an error if it's not supported
+func (t *thread) checkDecimalRoundingMode(obj Object, sourceLine int) (string, *Error) {
+	mode, ok := obj.(*SymbolObject)
+
+	if !ok {
+		return "", t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.SymbolClass, obj.Class().Name)
+	}
+
+	if !decimalRoundingModes[mode.value] {
+		return "", t.vm.initErrorObject(errors.ArgumentError, sourceLine, "Unknown rounding mode: %s", inspectString(mode))
+	}
+
+	return mode.value, nil
+}
+
+// decimalRoundingMode returns the rounding mode set by `Decimal.rounding_mode=`
+func decimalRoundingMode(decimalClass *RClass) string {
+	if mode, ok := decimalClass.instanceVariableGet("@rounding_mode"); ok {
+		return mode.(*SymbolObject).value
+	}
+
+	return defaultDecimalRoundingMode
+}
+
+// parseDecimal parses strings like "-12.5" or "1.5e3", the second value is false if the string isn't a decimal
+func parseDecimal(s string) (*big.Rat, bool) {
+	s = strings.Replace(s, "_", "", -1)
+
+	if !decimalStringRegexp.MatchString(s) {
+		return nil, false
+	}
+
+	return new(big.Rat).SetString(s)
+}
+
+// floatToRat converts a float to the decimal of its shortest representation, so 0.1 becomes exactly 0.1
+func floatToRat(f float64) (*big.Rat, bool) {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return nil, false
+	}
+
+	return new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+}
+
+// roundRat rounds the value to the given decimal places with the rounding mode
+func roundRat(value *big.Rat, places int, mode string) *big.Rat {
+	factor := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(places))), nil))
+
+	if places < 0 {
+		factor.Inv(factor)
+	}
+
+	scaled := new(big.Rat).Mul(value, factor)
+	quotient, remainder := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+
+	if remainder.Sign() != 0 {
+		// Compares the dropped part with a half
+		half := new(big.Int).Abs(remainder)
+		half.Mul(half, big.NewInt(2))
+		halfComparison := half.Cmp(scaled.Denom())
+
+		var awayFromZero bool
+
+		switch mode {
+		case "up":
+			awayFromZero = true
+		case "down":
+			awayFromZero = false
+		case "ceiling":
+			awayFromZero = scaled.Sign() > 0
+		case "floor":
+			awayFromZero = scaled.Sign() < 0
+		case "half_down":
+			awayFromZero = halfComparison > 0
+		case "half_even":
+			awayFromZero = halfComparison > 0 || (halfComparison == 0 && quotient.Bit(0) == 1)
+		default:
+			awayFromZero = halfComparison >= 0
+		}
+
+		if awayFromZero {
+			quotient.Add(quotient, big.NewInt(int64(scaled.Sign())))
+		}
+	}
+
+	result := new(big.Rat).SetInt(quotient)
+	return result.Quo(result, factor)
+}
+
+// decimalPlaces returns the number of decimal places needed to represent the value exactly.
+// Decimals are always terminating, so their denominators are 2^twos * 5^fives and need max(twos, fives) places.
+func decimalPlaces(value *big.Rat) int {
+	denom := new(big.Int).Set(value.Denom())
+	remainder := new(big.Int)
+	twos, fives := 0, 0
+
+	for denom.Bit(0) == 0 {
+		denom.Rsh(denom, 1)
+		twos++
+	}
+
+	for {
+		quotient, r := new(big.Int).QuoRem(denom, big.NewInt(5), remainder)
+
+		if r.Sign() != 0 {
+			break
+		}
+
+		denom = quotient
+		fives++
+	}
+
+	if twos > fives {
+		return twos
+	}
+
+	return fives
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
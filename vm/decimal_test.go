@@ -0,0 +1,168 @@
+package vm
+
+import (
+	"testing"
+)
+
+func TestDecimalEvaluation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`Decimal.new("1.25").to_s`, "1.25"},
+		{`Decimal.new("1.50").to_s`, "1.5"},
+		{`Decimal.new("-0.001").to_s`, "-0.001"},
+		{`Decimal.new("1.5e3").to_s`, "1500"},
+		{`Decimal.new("1_000.25").to_s`, "1000.25"},
+		{`Decimal.new(3).to_s`, "3"},
+		{`Decimal.new(0.1).to_s`, "0.1"},
+		{`Decimal.new(Decimal.new("0.5")).to_s`, "0.5"},
+		{`Decimal.new("1").class.name`, "Decimal"},
+		{`Decimal.new("0.1").to_f`, 0.1},
+		{`Decimal.new("-1.75").to_i`, -1},
+		{`Decimal.new("123456789012345678901234567890.5").to_i.to_s`, "123456789012345678901234567890"},
+		{`Decimal.new("0.0").zero?`, true},
+		{`{ a: Decimal.new("1.10") }.to_json`, `{"a":1.1}`},
+		{`{ Decimal.new("1.0") => 1 }[Decimal.new("1")]`, 1},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, "require 'decimal'\n"+tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestDecimalArithmeticOperation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`(Decimal.new("0.1") + Decimal.new("0.2")).to_s`, "0.3"},
+		{`(Decimal.new("1.1") - 2).to_s`, "-0.9"},
+		{`(Decimal.new("19.99") * 3).to_s`, "59.97"},
+		{`(Decimal.new("10") / 4).to_s`, "2.5"},
+		{`(Decimal.new("2") / 3).to_s`, "0.66666666666666666667"},
+		{`(Decimal.new("5.5") % 2).to_s`, "1.5"},
+		{`(Decimal.new("-5.5") % 2).to_s`, "-1.5"},
+		{`(Decimal.new("1.5") ** 2).to_s`, "2.25"},
+		{`(Decimal.new("2") ** -1).to_s`, "0.5"},
+		{`(Decimal.new("0.1") + 0.2).to_s`, "0.3"},
+		{`(1 + Decimal.new("0.5")).to_s`, "1.5"},
+		{`(1 - Decimal.new("0.5")).to_s`, "0.5"},
+		{`(3 * Decimal.new("0.5")).to_s`, "1.5"},
+		{`(3 / Decimal.new("2")).to_s`, "1.5"},
+		{`(7 % Decimal.new("2.5")).to_s`, "2"},
+		{`(2 ** 100 * Decimal.new("0.5")).to_s`, "633825300114114700748351602688"},
+		{`0.5 + Decimal.new("0.5")`, 1.0},
+		{`(-Decimal.new("1.1")).to_s`, "-1.1"},
+		{`(-Decimal.new("-0.25")).to_s`, "0.25"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, "require 'decimal'\n"+tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestDecimalComparison(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`Decimal.new("0.1") + Decimal.new("0.2") == Decimal.new("0.3")`, true},
+		{`Decimal.new("1.0") == 1`, true},
+		{`Decimal.new("0.1") == 0.1`, true},
+		{`Decimal.new("1.5").between?(1, 2)`, true},
+		{`Decimal.new("2.5").clamp(1, 2)`, 2},
+		{`Decimal.new("1") == "1"`, false},
+		{`Decimal.new("1.1") != 1`, true},
+		{`Decimal.new("1.5") > 1`, true},
+		{`Decimal.new("1.0") >= 1`, true},
+		{`Decimal.new("0.5") < Decimal.new("0.25")`, false},
+		{`Decimal.new("1.0") <= 1.5`, true},
+		{`Decimal.new("1") <=> 2`, -1},
+		{`Decimal.new("1") <=> 1`, 0},
+		{`1 == Decimal.new("1.0")`, true},
+		{`1 != Decimal.new("1.5")`, true},
+		{`1 < Decimal.new("1.5")`, true},
+		{`2 >= Decimal.new("2.5")`, false},
+		{`2 <=> Decimal.new("1")`, 1},
+		{`0.5 == Decimal.new("0.5")`, true},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, "require 'decimal'\n"+tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestDecimalRoundMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`Decimal.new("2.5").round.to_s`, "3"},
+		{`Decimal.new("-2.5").round.to_s`, "-3"},
+		{`Decimal.new("2.4").round.to_s`, "2"},
+		{`Decimal.new("1.235").round(2).to_s`, "1.24"},
+		{`Decimal.new("1.225").round(2, :half_even).to_s`, "1.22"},
+		{`Decimal.new("1.235").round(2, :half_even).to_s`, "1.24"},
+		{`Decimal.new("1.225").round(2, :half_down).to_s`, "1.22"},
+		{`Decimal.new("1.221").round(2, :up).to_s`, "1.23"},
+		{`Decimal.new("1.229").round(2, :down).to_s`, "1.22"},
+		{`Decimal.new("-1.221").round(2, :ceiling).to_s`, "-1.22"},
+		{`Decimal.new("-1.221").round(2, :floor).to_s`, "-1.23"},
+		{`Decimal.new("1250").round(-2).to_s`, "1300"},
+		{`Decimal.new("1.2").round(5).to_s`, "1.2"},
+		{`Decimal.rounding_mode.to_s`, "half_up"},
+		{`
+		Decimal.rounding_mode = :half_even
+		Decimal.new("2.5").round.to_s
+		`, "2"},
+		{`
+		Decimal.rounding_mode = :down
+		(Decimal.new("2") / 3).to_s
+		`, "0.66666666666666666666"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, "require 'decimal'\n"+tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestDecimalMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`Decimal.new("1.2.3")`, `ArgumentError: Invalid value for Decimal: "1.2.3"`, 2, 1},
+		{`Decimal.new`, "ArgumentError: Expect 1 arguments. got: 0", 2, 1},
+		{`Decimal.new(nil)`, "TypeError: Expect argument to be Numeric. got: Null", 2, 1},
+		{`Decimal.new("1") + "1"`, "TypeError: Expect argument to be Numeric. got: String", 2, 1},
+		{`Decimal.new("1") / 0`, "ZeroDivisionError: Divided by 0", 2, 1},
+		{`1 / Decimal.new("0")`, "ZeroDivisionError: Divided by 0", 2, 1},
+		{`Decimal.new("1") ** Decimal.new("2")`, "TypeError: Expect argument to be Integer. got: Decimal", 2, 1},
+		{`Decimal.new("1") > nil`, "TypeError: Expect argument to be Numeric. got: Null", 2, 1},
+		{`Decimal.new("1").round("1")`, "TypeError: Expect argument to be Integer. got: String", 2, 1},
+		{`Decimal.new("1").round(1, :sideways)`, "ArgumentError: Unknown rounding mode: :sideways", 2, 1},
+		{`Decimal.rounding_mode = "up"`, "TypeError: Expect argument to be Symbol. got: String", 2, 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, "require 'decimal'\n"+tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, tt.expectedCFP)
+		v.checkSP(t, i, 1)
+	}
+}
@@ -0,0 +1,159 @@
+package vm
+
+import (
+	"testing"
+)
+
+func TestEnumerableModule(t *testing.T) {
+	list := `
+	class NumberList
+	  include Enumerable
+
+	  def initialize(*items)
+	    @items = items
+	  end
+
+	  def each
+	    @items.each do |item|
+	      yield(item)
+	    end
+
+	    self
+	  end
+	end
+
+	list = NumberList.new(3, 1, 4, 1, 5)
+	`
+
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`list.to_a.to_s`, "[3, 1, 4, 1, 5]"},
+		{`list.map do |x| x * 2 end.to_s`, "[6, 2, 8, 2, 10]"},
+		{`list.flat_map do |x| [x, x] end.length`, 10},
+		{`list.select do |x| x > 2 end.to_s`, "[3, 4, 5]"},
+		{`list.filter do |x| x > 2 end.to_s`, "[3, 4, 5]"},
+		{`list.reject do |x| x > 2 end.to_s`, "[1, 1]"},
+		{`list.partition do |x| x.odd? end.to_s`, "[[3, 1, 1, 5], [4]]"},
+		{`list.find do |x| x > 3 end`, 4},
+		{`list.detect do |x| x > 5 end`, nil},
+		{`list.find_index(4)`, 2},
+		{`list.find_index do |x| x == 1 end`, 1},
+		{`list.include?(5)`, true},
+		{`list.member?(2)`, false},
+		{`list.all? do |x| x > 0 end`, true},
+		{`list.any? do |x| x > 4 end`, true},
+		{`list.none? do |x| x > 5 end`, true},
+		{`list.count`, 5},
+		{`list.count(1)`, 2},
+		{`list.count do |x| x.odd? end`, 4},
+		{`list.first`, 3},
+		{`list.first(2).to_s`, "[3, 1]"},
+		{`list.take(3).to_s`, "[3, 1, 4]"},
+		{`list.take_while do |x| x < 4 end.to_s`, "[3, 1]"},
+		{`list.drop(3).to_s`, "[1, 5]"},
+		{`list.drop_while do |x| x < 4 end.to_s`, "[4, 1, 5]"},
+		{`list.reduce do |sum, x| sum + x end`, 14},
+		{`list.reduce(10) do |sum, x| sum + x end`, 24},
+		{`list.inject do |product, x| product * x end`, 60},
+		{`list.sum`, 14},
+		{`list.sum(0.5)`, 14.5},
+		{`list.sum do |x| x * 10 end`, 140},
+		{`list.min`, 1},
+		{`list.max`, 5},
+		{`list.min do |a, b| b <=> a end`, 5},
+		{`list.min_by do |x| -x end`, 5},
+		{`list.max_by do |x| -x end`, 1},
+		{`list.sort.to_s`, "[1, 1, 3, 4, 5]"},
+		{`list.sort do |a, b| b <=> a end.to_s`, "[5, 4, 3, 1, 1]"},
+		{`NumberList.new("ccc", "a", "bb").sort_by do |s| s.length end.to_s`, `["a", "bb", "ccc"]`},
+		{`list.group_by do |x| x.odd? end[false].to_s`, "[4]"},
+		{`list.tally[1]`, 2},
+		{`list.uniq.to_s`, "[3, 1, 4, 5]"},
+		{`list.uniq do |x| x % 3 end.to_s`, "[3, 1, 5]"},
+		{`list.zip([1, 2], [3]).first(2).to_s`, "[[3, 1, 3], [1, 2, nil]]"},
+		{`
+		result = []
+		list.each_with_index do |x, i|
+		  result.push(x * i)
+		end
+		result.to_s
+		`, "[0, 1, 8, 3, 20]"},
+		{`
+		list.each_with_object([]) do |x, memo|
+		  memo.push(x + 1)
+		end.to_s
+		`, "[4, 2, 5, 2, 6]"},
+		{`
+		result = []
+		list.each_slice(2) do |slice|
+		  result.push(slice)
+		end
+		result.to_s
+		`, "[[3, 1], [4, 1], [5]]"},
+		{`list.is_a?(Enumerable)`, true},
+		{`NumberList.ancestors.to_s`, "[NumberList, Enumerable, Object]"},
+		{`
+		begin
+		  list.sort do |a, b| nil end
+		rescue ArgumentError => e
+		  e.message
+		end
+		`, "comparison of Integer with 1 failed"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, list+tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestEnumerableBuiltinCollections(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`[3, 1, 2].sort.to_s`, "[1, 2, 3]"},
+		{`[1, 2, 3, 4].partition do |x| x.even? end.to_s`, "[[2, 4], [1, 3]]"},
+		{`["a", "b", "a"].tally["a"]`, 2},
+		{`[1, 2, 3].sum`, 6},
+		{`(1..10).select do |x| x % 3 == 0 end.to_s`, "[3, 6, 9]"},
+		{`(1..4).reduce(1) do |product, x| product * x end`, 24},
+		{`
+		begin
+		  (1..5).each_slice(2)
+		rescue InternalError => e
+		  e.message
+		end
+		`, "Can't yield without a block"},
+		{`(1..3).zip(4..6).to_s`, "[[1, 4], [2, 5], [3, 6]]"},
+		{`{ a: 1, b: 2 }.map do |key, value| key.to_s + value.to_s end.to_s`, `["a1", "b2"]`},
+		{`{ a: 1, b: 2 }.count do |key, value| value > 1 end`, 1},
+		{`{ a: 1, b: 2 }.min_by do |key, value| value end.to_s`, `[:a, 1]`},
+		{`{ a: 1, b: 2 }.sort_by do |key, value| -value end.to_s`, `[[:b, 2], [:a, 1]]`},
+		{`{ a: 1, b: 2 }.reject do |key, value| value > 1 end.to_s`, "{ a: 1 }"},
+		{`{ a: 1 }.include?(:a)`, true},
+		{`Hash.ancestors.to_s`, "[Hash, Enumerable, Object]"},
+		{`Range.ancestors.to_s`, "[Range, Enumerable, Object]"},
+		{`
+		require 'concurrent/array'
+		Concurrent::Array.new([3, 1, 2]).sort.to_s
+		`, "[1, 2, 3]"},
+		{`
+		require 'concurrent/array'
+		Concurrent::Array.new([1, 2, 3]).is_a?(Enumerable)
+		`, true},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
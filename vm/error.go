@@ -7,9 +7,22 @@ import (
 )
 
 // Error class is actually a special struct to hold internal error types with messages.
-// Goby developers need not to take care of the struct.
 // Goby maintainers should consider using the appropriate error type.
-// Cannot create instances of Error class, or inherit Error class.
+// Errors can be raised by `raise` and rescued by `begin ... rescue ... end`.
+//
+// All error classes inherit `StandardError`, and Goby developers can define their own errors by inheriting it:
+//
+// ```ruby
+// class PaymentError < StandardError
+// end
+//
+// begin
+//   raise(PaymentError, "card declined")
+// rescue StandardError => e
+//   e.is_a?(PaymentError) # => true
+//   e.message             # => "card declined"
+// end
+// ```
 //
 // The type of internal errors:
 //
@@ -22,8 +35,212 @@ import (
 //
 type Error struct {
 	*baseObj
+	// Message contains the error type, message and where the error is raised
 	Message string
 	Type    string
+	// raised means the error is being thrown and should stop current execution until it's rescued
+	raised     bool
+	rawMessage string
+	backtrace  []string
+	cause      *Error
+	// initializeMethod is the user-defined `initialize` method that's called after `new`, like RObject's InitializeMethod
+	initializeMethod *MethodObject
+}
+
+// Class methods --------------------------------------------------------
+func builtinErrorClassMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Creates an error with the given message, the message is the class name by default.
+			// The error can be raised by `raise` later.
+			// Like other classes, an error class can define `initialize` to take its own arguments,
+			// and call `super` with the message.
+			//
+			// ```ruby
+			// e = ArgumentError.new("foo")
+			// e.message # => "foo"
+			// raise(e)
+			//
+			// class PaymentError < StandardError
+			//   attr_reader :amount
+			//
+			//   def initialize(amount)
+			//     @amount = amount
+			//     super("can't pay " + amount.to_s)
+			//   end
+			// end
+			//
+			// e = PaymentError.new(5)
+			// e.amount  # => 5
+			// e.message # => "can't pay 5"
+			// ```
+			//
+			// @param message [String]
+			// @return [Error]
+			Name: "new",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					errClass := receiver.(*RClass)
+					e := t.vm.newErrorObject(errClass, errClass.Name)
+
+					// The user-defined initialize is called with the arguments after new returns, see evalBuiltinMethod
+					if initMethod, ok := errClass.lookupMethod("initialize").(*MethodObject); ok {
+						e.initializeMethod = initMethod
+						return e
+					}
+
+					if len(args) > 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "Expect at most 1 argument. got: %d", len(args))
+					}
+
+					if len(args) == 1 {
+						e.setMessage(args[0].toString())
+					}
+
+					return e
+				}
+			},
+		},
+	}
+}
+
+// Instance methods -----------------------------------------------------
+func builtinErrorInstanceMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Returns an array of the places where the error is raised, the innermost one comes first.
+			// Each of them is formatted as "file:line in method".
+			// The array is empty if the error hasn't been raised.
+			//
+			// ```ruby
+			// def foo
+			//   raise(ArgumentError)
+			// end
+			//
+			// begin
+			//   foo
+			// rescue => e
+			//   e.backtrace # => ["main.gb:2 in foo", "main.gb:6 in <main>"]
+			// end
+			// ```
+			//
+			// @return [Array]
+			Name: "backtrace",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					e := receiver.(*Error)
+					elems := []Object{}
+
+					for _, b := range e.backtrace {
+						elems = append(elems, t.vm.initStringObject(b))
+					}
+
+					return t.vm.initArrayObject(elems)
+				}
+			},
+		},
+		{
+			// Returns the error being rescued when the receiver is raised, or nil if there's no such error.
+			//
+			// ```ruby
+			// begin
+			//   begin
+			//     raise(TypeError, "foo")
+			//   rescue
+			//     raise(ArgumentError, "bar")
+			//   end
+			// rescue => e
+			//   e.cause.message # => "foo"
+			// end
+			// ```
+			//
+			// @return [Error]
+			Name: "cause",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					e := receiver.(*Error)
+
+					if e.cause == nil {
+						return NULL
+					}
+
+					return e.cause
+				}
+			},
+		},
+		{
+			// Sets the error's message, the message is the class name by default.
+			// It's called by `super` in the `initialize` method of a user-defined error class.
+			//
+			// ```ruby
+			// class PaymentError < StandardError
+			//   def initialize
+			//     super("card declined")
+			//   end
+			// end
+			//
+			// PaymentError.new.message # => "card declined"
+			// ```
+			//
+			// @param message [String]
+			// @return [Null]
+			Name: "initialize",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					e := receiver.(*Error)
+
+					switch len(args) {
+					case 0:
+						e.setMessage(e.Type)
+					case 1:
+						e.setMessage(args[0].toString())
+					default:
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "Expect at most 1 argument. got: %d", len(args))
+					}
+
+					return NULL
+				}
+			},
+		},
+		{
+			// Returns the error's message.
+			//
+			// ```ruby
+			// begin
+			//   raise(ArgumentError, "foo")
+			// rescue => e
+			//   e.message # => "foo"
+			// end
+			// ```
+			//
+			// @return [String]
+			Name: "message",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					return t.vm.initStringObject(receiver.(*Error).rawMessage)
+				}
+			},
+		},
+		{
+			// Returns the error's type, which is the name of its class.
+			//
+			// ```ruby
+			// begin
+			//   raise(ArgumentError, "foo")
+			// rescue => e
+			//   e.type # => "ArgumentError"
+			// end
+			// ```
+			//
+			// @return [String]
+			Name: "type",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					return t.vm.initStringObject(receiver.(*Error).Type)
+				}
+			},
+		},
+	}
 }
 
 // Internal functions ===================================================
@@ -31,8 +248,65 @@ type Error struct {
 // Functions for initialization -----------------------------------------
 
 func (vm *VM) initErrorObject(errorType string, sourceLine int, format string, args ...interface{}) *Error {
-	errClass := vm.objectClass.getClassConstant(errorType)
+	return vm.initErrorObjectWithClass(vm.objectClass.getClassConstant(errorType), sourceLine, format, args...)
+}
+
+func (vm *VM) initErrorObjectWithClass(errClass *RClass, sourceLine int, format string, args ...interface{}) *Error {
+	e := vm.newErrorObject(errClass, fmt.Sprintf(format, args...))
+	vm.raiseErrorObject(e, sourceLine)
+
+	return e
+}
+
+// raiseErrorClass instantiates the error class with its `new` and raises the error.
+// It raises a TypeError instead if the class doesn't inherit StandardError.
+func (t *thread) raiseErrorClass(errClass *RClass, sourceLine int, args ...Object) *Error {
+	standardError := t.vm.objectClass.getClassConstant(errors.StandardError)
+	isErrorClass := false
 
+	for _, ancestor := range errClass.ancestors() {
+		if ancestor == standardError {
+			isErrorClass = true
+			break
+		}
+	}
+
+	if !isErrorClass {
+		return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, "a subclass of StandardError", errClass.Name)
+	}
+
+	e, ok := t.callMethod(errClass, "new", sourceLine, args...).(*Error)
+
+	if !ok {
+		return t.vm.initErrorObject(errors.TypeError, sourceLine, "Expect %s.new to return an error", errClass.Name)
+	}
+
+	// Errors raised by `new` itself, like wrong arguments for `initialize`, are raised as they are
+	if !e.raised {
+		t.vm.raiseErrorObject(e, sourceLine)
+	}
+
+	return e
+}
+
+// newErrorObject initializes an error that hasn't been raised
+func (vm *VM) newErrorObject(errClass *RClass, message string) *Error {
+	return &Error{
+		baseObj:    &baseObj{class: errClass, InstanceVariables: newEnvironment()},
+		Message:    errClass.Name + ": " + message,
+		Type:       errClass.Name,
+		rawMessage: message,
+	}
+}
+
+// setMessage replaces the message of an error that hasn't been raised
+func (e *Error) setMessage(message string) {
+	e.Message = e.Type + ": " + message
+	e.rawMessage = message
+}
+
+// raiseErrorObject marks the error as raised, and records where it's raised if it's raised for the first time
+func (vm *VM) raiseErrorObject(e *Error, sourceLine int) {
 	t := vm.mainThread
 	cf := t.callFrameStack.top()
 
@@ -47,25 +321,37 @@ func (vm *VM) initErrorObject(errorType string, sourceLine int, format string, a
 		t.callFrameStack.pop()
 	}
 
-	msg := fmt.Sprintf("%s. At %s:%d", fmt.Sprintf(errorType+": "+format, args...), cf.FileName(), sourceLine)
+	e.raised = true
+
+	// Re-raised errors keep their original location
+	if e.backtrace != nil {
+		return
+	}
 
 	if sourceLine == -1 {
-		msg = fmt.Sprintf("%s. At %s", fmt.Sprintf(errorType+": "+format, args...), cf.FileName())
+		e.Message = fmt.Sprintf("%s. At %s", e.Message, cf.FileName())
+	} else {
+		e.Message = fmt.Sprintf("%s. At %s:%d", e.Message, cf.FileName(), sourceLine)
 	}
 
-	return &Error{
-		baseObj: &baseObj{class: errClass},
-		// Add 1 to source line because it's zero indexed
-		Message: msg,
-		Type:    errorType,
+	e.backtrace = t.backtrace(sourceLine)
+
+	if cause := t.rescuedError(); cause != e {
+		e.cause = cause
 	}
 }
 
 func (vm *VM) initErrorClasses() {
-	errTypes := []string{errors.InternalError, errors.ArgumentError, errors.NameError, errors.TypeError, errors.UndefinedMethodError, errors.UnsupportedMethodError, errors.ConstantAlreadyInitializedError, errors.HTTPError}
+	errTypes := []string{errors.InternalError, errors.ArgumentError, errors.NameError, errors.TypeError, errors.UndefinedMethodError, errors.UnsupportedMethodError, errors.ConstantAlreadyInitializedError, errors.HTTPError, errors.ZeroDivisionError, errors.NoMatchingPatternError, errors.KeyError}
+
+	sc := vm.initializeClass(errors.StandardError, false)
+	sc.setBuiltinMethods(builtinErrorInstanceMethods(), false)
+	sc.setBuiltinMethods(builtinErrorClassMethods(), true)
+	vm.objectClass.setClassConstant(sc)
 
 	for _, errType := range errTypes {
 		c := vm.initializeClass(errType, false)
+		c.inherits(sc)
 		vm.objectClass.setClassConstant(c)
 	}
 }
@@ -149,6 +149,24 @@ func TestArgumentError(t *testing.T) {
 		`,
 			"ArgumentError: Expect at most 3 args for method 'foo'. got: 4",
 			6, 1},
+		{`class Foo
+		  def initialize(a)
+		  end
+		end
+
+		Foo.new
+		`, "ArgumentError: Expect at least 1 args for method 'initialize'. got: 0",
+			6, 1},
+		{`class PaymentError < StandardError
+		  def initialize(amount)
+		    super("bad")
+		  end
+		end
+
+		PaymentError.new(1, 2)
+		`, "ArgumentError: Expect at most 1 args for method 'initialize'. got: 2",
+			7, 1},
+		{`ArgumentError.new("a", "b")`, "ArgumentError: Expect at most 1 argument. got: 2", 1, 1},
 	}
 
 	for i, tt := range tests {
@@ -254,6 +272,435 @@ func TestConstantAlreadyInitializedError(t *testing.T) {
 	}
 }
 
+func TestRescueEvaluation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		begin
+		  raise(ArgumentError, "foo")
+		  1
+		rescue TypeError
+		  2
+		rescue ArgumentError, NameError => e
+		  e.class.name
+		end
+		`, "ArgumentError"},
+		{`
+		begin
+		  undefined_method
+		rescue
+		  10
+		end
+		`, 10},
+		{`
+		begin
+		  1
+		rescue
+		  2
+		else
+		  3
+		end
+		`, 3},
+		{`
+		a = 0
+		b = begin
+		  raise(TypeError)
+		rescue
+		  2
+		ensure
+		  a = 10
+		end
+		a + b
+		`, 12},
+		{`
+		i = 0
+		begin
+		  i += 1
+		  if i < 3
+		    raise(TypeError, "retry")
+		  end
+		  i
+		rescue TypeError
+		  retry
+		end
+		`, 3},
+		{`
+		def foo
+		  [1, 2].each do |i|
+		    raise(NameError, "bar")
+		  end
+		rescue NameError
+		  "rescued"
+		end
+		foo
+		`, "rescued"},
+		{`
+		def foo
+		  raise(ArgumentError, "foo")
+		end
+
+		def bar
+		  foo
+		  1
+		rescue => e
+		  e.class.name
+		end
+		bar
+		`, "ArgumentError"},
+		{`
+		a = []
+		def foo(a)
+		  begin
+		    return 1
+		  ensure
+		    a.push(2)
+		  end
+		end
+		foo(a) + a[0]
+		`, 3},
+		{`
+		a = 0
+		i = 0
+		while i < 5 do
+		  i += 1
+		  begin
+		    if i == 2
+		      next
+		    end
+		    if i == 4
+		      break
+		    end
+		  ensure
+		    a += 1
+		  end
+		end
+		a
+		`, 4},
+		{`
+		begin
+		  begin
+		    raise(TypeError, "inner")
+		  rescue => e
+		    raise(e)
+		  end
+		rescue TypeError
+		  "re-raised"
+		end
+		`, "re-raised"},
+		{`
+		begin
+		  begin
+		    raise("a")
+		  rescue
+		    raise
+		  end
+		rescue => e
+		  e.message
+		end
+		`, "a"},
+		{`
+		[1, 2].map do |i|
+		  begin
+		    raise(ArgumentError)
+		  rescue
+		    i
+		  end
+		end.last
+		`, 2},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestRaiseError(t *testing.T) {
+	tests := []errorTestCase{
+		{`raise(ArgumentError, "foo")`, "ArgumentError: foo", 1, 1},
+		{`raise(TypeError)`, "TypeError: TypeError", 1, 1},
+		{`raise("foo")`, "InternalError: foo", 1, 1},
+		{`raise`, "InternalError: unhandled exception", 1, 1},
+		{`begin
+		  raise("a")
+		rescue
+		  raise
+		end`, "InternalError: a", 2, 1},
+		{`begin
+		  raise("a")
+		rescue
+		end
+		raise`, "InternalError: unhandled exception", 5, 1},
+		{`begin
+		  raise(ArgumentError, "foo")
+		rescue TypeError
+		  1
+		end`, "ArgumentError: foo", 2, 1},
+		{`begin
+		  raise(ArgumentError, "foo")
+		ensure
+		  1
+		end`, "ArgumentError: foo", 2, 1},
+		{`raise(String, "foo")`, "TypeError: Expect argument to be a subclass of StandardError. got: String", 1, 1},
+		{`raise(Object)`, "TypeError: Expect argument to be a subclass of StandardError. got: Object", 1, 1},
+		{`class PaymentError < StandardError
+		  def initialize(amount)
+		    super("can't pay " + amount.to_s)
+		  end
+		end
+
+		raise(PaymentError)`, "ArgumentError: Expect at least 1 args for method 'initialize'. got: 0", 7, 1},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, tt.expectedCFP)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestErrorClassHierarchy(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`ArgumentError.superclass.name`, "StandardError"},
+		{`HTTPError.ancestors.length`, 3},
+		{`
+		class PaymentError < StandardError
+		end
+
+		begin
+		  raise(PaymentError, "declined")
+		rescue StandardError => e
+		  e.is_a?(PaymentError)
+		end
+		`, true},
+		{`
+		class PaymentError < StandardError
+		end
+
+		begin
+		  raise(PaymentError, "declined")
+		rescue ArgumentError
+		  1
+		rescue PaymentError
+		  2
+		end
+		`, 2},
+		{`
+		begin
+		  raise(TypeError, "foo")
+		rescue StandardError => e
+		  e.is_a?(ArgumentError)
+		end
+		`, false},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestErrorInstanceMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		begin
+		  raise(ArgumentError, "foo")
+		rescue => e
+		  e.message
+		end
+		`, "foo"},
+		{`
+		begin
+		  raise(ArgumentError)
+		rescue => e
+		  e.message
+		end
+		`, "ArgumentError"},
+		{`
+		class PaymentError < StandardError
+		end
+
+		begin
+		  raise(PaymentError, "foo")
+		rescue => e
+		  e.type
+		end
+		`, "PaymentError"},
+		{`ArgumentError.new("foo").message`, "foo"},
+		{`ArgumentError.new.backtrace.length`, 0},
+		{`
+		def foo
+		  raise(ArgumentError, "foo")
+		end
+
+		begin
+		  foo
+		rescue => e
+		  e.backtrace.first
+		end
+		`, fmt.Sprintf("%s:3 in foo", getFilename())},
+		{`
+		def foo
+		  [1].each do |i|
+		    raise(ArgumentError, "foo")
+		  end
+		end
+
+		begin
+		  foo
+		rescue => e
+		  e.backtrace.last
+		end
+		`, fmt.Sprintf("%s:9 in <main>", getFilename())},
+		{`
+		def foo
+		  [1].each do |i|
+		    raise(ArgumentError, "foo")
+		  end
+		end
+
+		begin
+		  foo
+		rescue => e
+		  e.backtrace.length
+		end
+		`, 4},
+		{`
+		begin
+		  [1].each do |i|
+		    [2].map do |j|
+		      raise(ArgumentError, "foo")
+		    end
+		  end
+		rescue => e
+		  e.backtrace.to_s
+		end
+		`, fmt.Sprintf(`["%[1]s:5 in block", "%[1]s:4 in map", "%[1]s:4 in block", "%[1]s:3 in each", "%[1]s:3 in <main>"]`, getFilename())},
+		{`
+		class PaymentError < StandardError
+		  attr_reader :amount
+
+		  def initialize(amount)
+		    @amount = amount
+		    super("can't pay " + amount.to_s)
+		  end
+		end
+
+		e = PaymentError.new(5)
+		e.amount.to_s + " " + e.message
+		`, "5 can't pay 5"},
+		{`
+		class PaymentError < StandardError
+		  def initialize(amount)
+		    @amount = amount
+		  end
+
+		  def amount
+		    @amount
+		  end
+		end
+
+		begin
+		  raise(PaymentError.new(5))
+		rescue PaymentError => e
+		  e.amount.to_s + " " + e.message
+		end
+		`, "5 PaymentError"},
+		{`
+		class PaymentError < StandardError
+		  attr_reader :amount
+
+		  def initialize(amount)
+		    @amount = amount
+		    super("can't pay " + amount.to_s)
+		  end
+		end
+
+		begin
+		  raise(PaymentError, 7)
+		rescue PaymentError => e
+		  e.amount.to_s + " " + e.message
+		end
+		`, "7 can't pay 7"},
+		{`
+		class PaymentError < StandardError
+		  def initialize
+		    super
+		  end
+		end
+
+		PaymentError.new.message
+		`, "PaymentError"},
+		{`
+		e = ArgumentError.new("foo")
+
+		begin
+		  raise(e)
+		rescue => e
+		  e.backtrace.first
+		end
+		`, fmt.Sprintf("%s:5 in <main>", getFilename())},
+		{`
+		begin
+		  begin
+		    raise(TypeError, "foo")
+		  rescue
+		    raise(ArgumentError, "bar")
+		  end
+		rescue => e
+		  e.cause.message
+		end
+		`, "foo"},
+		{`
+		begin
+		  raise(TypeError, "foo")
+		rescue
+		end
+
+		begin
+		  raise(ArgumentError, "bar")
+		rescue => e
+		  e.cause
+		end
+		`, nil},
+		{`
+		begin
+		  begin
+		    raise(TypeError, "foo")
+		  rescue => e
+		    raise(e)
+		  end
+		rescue => e
+		  e.cause
+		end
+		`, nil},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func checkError(t *testing.T, index int, evaluated Object, expectedErrMsg, fn string, line int) {
 	err, ok := evaluated.(*Error)
 	if !ok {
@@ -1,6 +1,8 @@
 package errors
 
 const (
+	// StandardError is the superclass of all error types
+	StandardError = "StandardError"
 	// InternalError is the default error type
 	InternalError = "InternalError"
 	// ArgumentError is for an argument-related error
@@ -17,13 +19,24 @@ const (
 	ConstantAlreadyInitializedError = "ConstantAlreadyInitializedError"
 	// HTTPError is returned when when a request fails to return a proper response
 	HTTPError = "HTTPError"
+	// ZeroDivisionError is for dividing a number by zero
+	ZeroDivisionError = "ZeroDivisionError"
+	// NoMatchingPatternError is raised when none of the patterns of a `case ... in` expression matches
+	NoMatchingPatternError = "NoMatchingPatternError"
+	// KeyError is raised when a key that's required can't be found
+	KeyError = "KeyError"
 )
 
 /*
 	Here defines different error message formats for different types of errors
 */
 const (
-	WrongNumberOfArgumentFormat = "Expect %d arguments. got: %d"
-	WrongArgumentTypeFormat     = "Expect argument to be %s. got: %s"
-	CantYieldWithoutBlockFormat = "Can't yield without a block"
+	WrongNumberOfArgumentFormat      = "Expect %d arguments. got: %d"
+	WrongNumberOfArgumentMoreFormat  = "Expect %d or more arguments. got: %d"
+	WrongNumberOfArgumentRangeFormat = "Expect %d to %d arguments. got: %d"
+	WrongArgumentTypeFormat          = "Expect argument to be %s. got: %s"
+	CantYieldWithoutBlockFormat      = "Can't yield without a block"
+	CantCreateProcWithoutBlockFormat = "Can't create a Proc without a block"
+	PrivateMethodCallFormat          = "Private method '%s' called for %s"
+	ProtectedMethodCallFormat        = "Protected method '%s' called for %s"
 )
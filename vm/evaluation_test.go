@@ -562,6 +562,53 @@ func TestMethodCallWithBlockArgument(t *testing.T) {
 	}
 }
 
+func TestBlockArgumentSpreading(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		sum = 0
+		[[1, 2], [3, 4]].each do |a, b|
+		  sum += a * b
+		end
+		sum
+		`, 14},
+		{`
+		def foo
+		  yield([1, 2])
+		end
+
+		foo do |a, b|
+		  a + b
+		end
+		`, 3},
+		{`
+		def foo
+		  yield([1, 2])
+		end
+
+		foo do |a|
+		  a.length
+		end
+		`, 2},
+		{`
+		p = Proc.new do |a, b|
+		  b
+		end
+		p.call([1, 2])
+		`, 2},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestMethodCallWithNestedBlock(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -1295,6 +1342,150 @@ func TestInstanceVariableEvaluation(t *testing.T) {
 	}
 }
 
+func TestClassVariableEvaluation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		class Foo
+		  @@count = 0
+
+		  def self.count
+		    @@count
+		  end
+
+		  def increment
+		    @@count += 1
+		  end
+		end
+
+		class Bar < Foo
+		  def increment
+		    @@count += 10
+		  end
+		end
+
+		Foo.new.increment
+		Bar.new.increment
+		Foo.count
+		`, 11},
+		{`
+		class Foo
+		  def self.name=(name)
+		    @@name = name
+		  end
+		end
+
+		class Bar < Foo
+		  def name
+		    @@name
+		  end
+		end
+
+		Foo.name = "foo"
+		Bar.new.name
+		`, "foo"},
+		{`
+		class Foo
+		  @@name = "foo"
+
+		  class << self
+		    def name
+		      @@name
+		    end
+		  end
+		end
+
+		Foo.name
+		`, "foo"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestClassVariableFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`
+		class Foo
+		  def bar
+		    @@bar
+		  end
+		end
+
+		Foo.new.bar
+		`, "NameError: uninitialized class variable @@bar in Foo", 4, 2},
+		{`
+		class Foo
+		end
+
+		class Bar < Foo
+		  @@bar = 1
+		end
+
+		class Foo
+		  @@bar
+		end
+		`, "NameError: uninitialized class variable @@bar in Foo", 10, 2},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, tt.expectedCFP)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestGlobalVariableEvaluation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		$foo = 1
+
+		def bar
+		  $foo += 1
+		end
+
+		bar
+		$foo
+		`, 2},
+		{`$foo`, nil},
+		{`$stdout.is_a?(File)`, true},
+		{`$stderr.is_a?(File)`, true},
+		{`$0 == $PROGRAM_NAME`, true},
+		{`$0`, getFilename()},
+		{`
+		c = Channel.new
+
+		thread do
+		  $result = 10
+		  c.deliver(1)
+		end
+
+		c.receive
+		$result
+		`, 10},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestAssignmentEvaluation(t *testing.T) {
 	tests := []struct {
 		input         string
@@ -1637,6 +1828,143 @@ func TestIfExpressionEvaluation(t *testing.T) {
 	}
 }
 
+func TestUnlessExpressionEvaluation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		unless 10 > 5
+		  100
+		else
+		  -10
+		end
+		`, -10},
+		{`
+		unless nil
+		  100
+		end
+		`, 100},
+		{`
+		unless true
+		  100
+		end
+		`, nil},
+		{`
+		def foo(x)
+		  return "negative" if x < 0
+		  return "zero" unless x != 0
+		  "positive"
+		end
+
+		foo(-1) + foo(0) + foo(1)
+		`, "negativezeropositive"},
+		{`
+		def foo
+		  return if true
+		  1
+		end
+
+		foo
+		`, nil},
+		{`10 if false`, nil},
+		{`10 unless false`, 10},
+		{`
+		a = 1
+		a += 1 if a > 0
+		a += 10 unless a > 0
+		a
+		`, 2},
+		{`
+		def foo
+		  yield if block_given?
+		end
+
+		foo { 10 }
+		`, 10},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestTernaryExpressionEvaluation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`10 > 5 ? "big" : "small"`, "big"},
+		{`nil ? 1 : 2`, 2},
+		{`false || nil ? 1 : 2`, 2},
+		{`x = 5; x < 3 ? 1 : x < 10 ? 2 : 3`, 2},
+		{`true ? false ? 1 : 2 : 3`, 2},
+		{`a = 1 > 0 ? [1, 2] : []; a.length`, 2},
+		{`h = { a: 1.odd? ? "odd" : "even" }; h[:a]`, "odd"},
+		{`
+		def foo(x)
+		  x.nil? ? :none : x.to_s
+		end
+
+		foo(nil).to_s + foo(1)
+		`, "none1"},
+		{`
+		a = 0
+		false && a = 10
+		true || a += 1
+		a
+		`, 0},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestSafeNavigationEvaluation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`nil&.length`, nil},
+		{`"foo"&.length`, 3},
+		{`false&.to_s`, "false"},
+		{`a = nil; a&.foo(raise("never"))`, nil},
+		{`[1, 2]&.map { |x| x * 2 }.to_s`, "[2, 4]"},
+		{`a = nil; a&.length.to_s`, ""},
+		{`
+		class Foo
+		  attr_accessor :bar
+		end
+
+		f = Foo.new
+		f&.bar = 10
+		f&.bar
+		`, 10},
+		{`
+		a = nil
+		a&.length
+		1
+		`, 1},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestCaseExpressionEvaluation(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -1830,6 +2158,210 @@ func TestCaseExpressionEvaluation(t *testing.T) {
 	}
 }
 
+func TestCaseExpressionWithCaseEquality(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		case 7
+		when 1..5
+		  "low"
+		when 6..10
+		  "high"
+		end
+		`, "high"},
+		{`
+		case "goby"
+		when Integer, Float
+		  "number"
+		when String
+		  "string"
+		end
+		`, "string"},
+		{`
+		case "main.gb"
+		when /\.rb$/
+		  "ruby"
+		when /\.gb$/
+		  "goby"
+		end
+		`, "goby"},
+		{`
+		case 42
+		when String, 40..50, /4/
+		  "matched"
+		else
+		  "no"
+		end
+		`, "matched"},
+		{`
+		case [1, 2]
+		when Hash
+		  "hash"
+		when [1, 2]
+		  "array"
+		end
+		`, "array"},
+		{`
+		case 3
+		in 1..2
+		  "low"
+		in Integer => n
+		  n
+		end
+		`, 3},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestCaseInExpressionEvaluation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		case {status: "ok", data: [1, 2, 3]}
+		in {status: "error"}
+		  0
+		in {status: "ok", data: [first, *rest]}
+		  first + rest.length
+		end
+		`, 3},
+		{`
+		case [1, [2, 3], 4, 5]
+		in [a, [b, *], *, c]
+		  a + b + c
+		end
+		`, 8},
+		{`
+		case [1, 2]
+		in [a]
+		  a
+		in [a, b, c]
+		  a
+		in [a, *b, c]
+		  b.length * 10 + c
+		end
+		`, 2},
+		{`
+		case 3
+		in 1 | 2 => n
+		  n
+		in 3 | 4 => n
+		  n * 10
+		end
+		`, 30},
+		{`
+		limit = 2
+		case [1, 2]
+		in [_, ^limit]
+		  "pinned"
+		end
+		`, "pinned"},
+		{`
+		limit = 3
+		case [1, 2]
+		in [_, ^limit]
+		  "pinned"
+		else
+		  "else"
+		end
+		`, "else"},
+		{`
+		case [5, 1]
+		in [a, b] if a < b
+		  "asc"
+		in [a, b] unless a < b
+		  "desc"
+		end
+		`, "desc"},
+		{`
+		case {name: "goby", version: 1}
+		in {name:, **rest}
+		  name + rest.keys.first.to_s
+		end
+		`, "gobyversion"},
+		{`
+		case {a: 1, b: 2}
+		in {a: 1, **nil}
+		  "exact"
+		in {a: 1}
+		  "partial"
+		end
+		`, "partial"},
+		{`
+		case "str"
+		in []
+		  1
+		in {}
+		  2
+		in x
+		  x
+		end
+		`, "str"},
+		{`
+		case [1, [2]]
+		in first, [*rest]
+		  first + rest[0]
+		end
+		`, 3},
+		{`
+		case {x: 1, y: 2}
+		in x:, y:
+		  x + y
+		end
+		`, 3},
+		{`
+		[[1, 2], [3, 4]].map do |pair|
+		  case pair
+		  in [a, b] then a * b
+		  end
+		end.last
+		`, 12},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestCaseInExpressionFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`case [1, 2]
+		in [a]
+		  a
+		end
+		`, "NoMatchingPatternError: [1, 2]", 1, 1},
+		{`case {a: 1}
+		in {a: 2}
+		  1
+		in {b:}
+		  b
+		end
+		`, "NoMatchingPatternError: { a: 1 }", 1, 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, tt.expectedCFP)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestClassInheritance(t *testing.T) {
 	input := `
 		class Bar
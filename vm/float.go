@@ -2,6 +2,7 @@ package vm
 
 import (
 	"math"
+	"math/big"
 	"strconv"
 
 	"github.com/goby-lang/goby/vm/classes"
@@ -93,6 +94,25 @@ func builtinFloatInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Returns the negation of self, it's called by the unary minus.
+			//
+			// ```Ruby
+			// -1.5    # => -1.5
+			// -(-1.5) # => 1.5
+			// ```
+			// @return [Float]
+			Name: "-@",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					return t.vm.initFloatObject(-receiver.(*FloatObject).value)
+				}
+			},
+		},
 		{
 			// Returns self multiplying a Numeric.
 			//
@@ -306,6 +326,16 @@ func builtinFloatInstanceMethods() []*BuiltinMethodObject {
 			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
 					r := receiver.(*FloatObject)
+
+					if r.value >= maxInt || r.value < minInt {
+						if math.IsInf(r.value, 0) {
+							return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "Can't convert %s to Integer", r.toString())
+						}
+
+						value, _ := big.NewFloat(r.value).Int(nil)
+						return t.vm.initBigIntegerObject(value)
+					}
+
 					newInt := t.vm.initIntegerObject(int(r.value))
 					newInt.flag = i
 					return newInt
@@ -339,6 +369,7 @@ func (vm *VM) initFloatClass() *RClass {
 	ic := vm.initializeClass(classes.FloatClass, false)
 	ic.setBuiltinMethods(builtinFloatInstanceMethods(), false)
 	ic.setBuiltinMethods(builtinFloatClassMethods(), true)
+	vm.libFiles = append(vm.libFiles, "float.gb")
 	return ic
 }
 
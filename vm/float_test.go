@@ -22,6 +22,30 @@ func TestFloatClassSuperclass(t *testing.T) {
 	}
 }
 
+func TestFloatLiteral(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`3.14`, 3.14},
+		{`1e-9`, 1e-9},
+		{`2.5E3`, 2500.0},
+		{`1_000.5`, 1000.5},
+		{`-1.5`, -1.5},
+		{`1.5 + 1`, 2.5},
+		{`3.14.class.name`, "Float"},
+		{`1.5.to_s`, "1.5"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestFloatArithmeticOperationWithFloat(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -202,6 +226,14 @@ func TestFloatConversions(t *testing.T) {
 	}{
 		{`'100.3'.to_f.to_i`, 100},
 		{`'100.3'.to_f.to_s`, "100.3"},
+		{`
+		a = 1.5
+		-a
+		`, -1.5},
+		{`
+		a = -1.5
+		-a
+		`, 1.5},
 	}
 
 	for i, tt := range tests {
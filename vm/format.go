@@ -0,0 +1,550 @@
+package vm
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/goby-lang/goby/vm/classes"
+	"github.com/goby-lang/goby/vm/errors"
+)
+
+// formatter formats the arguments of `format`, `printf` and `String#%` with Ruby-style directives:
+//
+// `%[flags][width][.precision]verb`, where the flags are `-` (left-justify), `+` (show the sign), ` ` (space before positive numbers),
+// `0` (pad numbers with zeros) and `#` (alternative form, like `0x` before hexadecimal numbers).
+// The width and the precision can be `*` to take them from the arguments, and `%1$s` refers to an argument by its position.
+// `%<name>d` formats the value of the `name` key of a hash argument, and `%{name}` inserts it as a string.
+// Like Ruby, negative numbers are shown in two's complement by `%x`, `%o` and `%b` without the `+` or ` ` flag, see formatTwosComplement.
+type formatter struct {
+	t          *thread
+	args       []Object
+	sourceLine int
+	// nextArg is the index of the next argument that a directive without position consumes
+	nextArg int
+}
+
+// formatDirective is a parsed `%` directive
+type formatDirective struct {
+	flags     string
+	width     int
+	precision int
+	verb      rune
+}
+
+// sprintf formats the arguments with the directives of the format, see formatter
+func (t *thread) sprintf(format string, args []Object, sourceLine int) Object {
+	f := &formatter{t: t, args: args, sourceLine: sourceLine}
+	result, err := f.format([]rune(format))
+
+	if err != nil {
+		return err
+	}
+
+	return t.vm.initStringObject(result)
+}
+
+func (f *formatter) format(runes []rune) (string, *Error) {
+	var out strings.Builder
+
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '%' {
+			out.WriteRune(runes[i])
+			continue
+		}
+
+		if i+1 < len(runes) && runes[i+1] == '%' {
+			out.WriteRune('%')
+			i++
+			continue
+		}
+
+		result, end, err := f.formatDirective(runes, i+1)
+
+		if err != nil {
+			return "", err
+		}
+
+		out.WriteString(result)
+		i = end
+	}
+
+	return out.String(), nil
+}
+
+// formatDirective formats the directive that starts at the given position, after its `%`.
+// It returns the formatted argument and the position of the directive's last character.
+func (f *formatter) formatDirective(runes []rune, i int) (string, int, *Error) {
+	d := &formatDirective{width: -1, precision: -1}
+	var arg Object
+
+	for ; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case strings.ContainsRune("-+ 0#", r):
+			d.flags += string(r)
+		case r >= '1' && r <= '9':
+			n, end := readNumber(runes, i)
+
+			if end < len(runes) && runes[end] == '$' {
+				if arg != nil {
+					return "", 0, f.error(errors.ArgumentError, "value given twice - %d$", n)
+				}
+
+				var err *Error
+				arg, err = f.positionalArgument(n)
+
+				if err != nil {
+					return "", 0, err
+				}
+
+				i = end
+				continue
+			}
+
+			d.width = n
+			i = end - 1
+		case r == '*':
+			width, err := f.integerArgument()
+
+			if err != nil {
+				return "", 0, err
+			}
+
+			if width < 0 {
+				d.flags += "-"
+				width = -width
+			}
+
+			d.width = width
+		case r == '.':
+			d.precision = 0
+
+			if i+1 < len(runes) && runes[i+1] == '*' {
+				precision, err := f.integerArgument()
+
+				if err != nil {
+					return "", 0, err
+				}
+
+				d.precision = precision
+				i++
+				continue
+			}
+
+			if i+1 < len(runes) && runes[i+1] >= '0' && runes[i+1] <= '9' {
+				d.precision, i = readNumber(runes, i+1)
+				i--
+			}
+		case r == '<' || r == '{':
+			closing := '>'
+
+			if r == '{' {
+				closing = '}'
+			}
+
+			end := i + 1
+
+			for end < len(runes) && runes[end] != closing {
+				end++
+			}
+
+			if end == len(runes) {
+				return "", 0, f.error(errors.ArgumentError, "malformed name - unmatched parenthesis")
+			}
+
+			value, err := f.namedArgument(string(runes[i+1 : end]))
+
+			if err != nil {
+				return "", 0, err
+			}
+
+			arg = value
+			i = end
+
+			// `%{name}` is replaced by the value as a string, without a verb
+			if r == '{' {
+				d.verb = 's'
+				result, err := f.formatArgument(d, arg)
+				return result, i, err
+			}
+		default:
+			d.verb = r
+
+			if arg == nil && r != '%' {
+				var err *Error
+				arg, err = f.argument()
+
+				if err != nil {
+					return "", 0, err
+				}
+			}
+
+			result, err := f.formatArgument(d, arg)
+			return result, i, err
+		}
+	}
+
+	return "", 0, f.error(errors.ArgumentError, "incomplete format specifier; use %%%% (double %%) instead")
+}
+
+// formatArgument formats the argument with the directive's verb
+func (f *formatter) formatArgument(d *formatDirective, arg Object) (string, *Error) {
+	switch d.verb {
+	case 's':
+		str, err := f.objectString(arg, "to_s")
+
+		if err != nil {
+			return "", err
+		}
+
+		return d.pad(d.truncate(str)), nil
+	case 'p':
+		str, err := f.objectString(arg, "inspect")
+
+		if err != nil {
+			return "", err
+		}
+
+		return d.pad(d.truncate(str)), nil
+	case 'c':
+		switch a := arg.(type) {
+		case *IntegerObject:
+			return d.pad(string(rune(a.value))), nil
+		case *StringObject:
+			r, _ := utf8.DecodeRuneInString(a.value)
+			return d.pad(string(r)), nil
+		}
+
+		return "", f.error(errors.TypeError, errors.WrongArgumentTypeFormat, classes.IntegerClass+" or "+classes.StringClass, arg.Class().Name)
+	case 'd', 'i', 'u', 'x', 'X', 'o', 'b', 'B':
+		n, err := f.toInteger(arg)
+
+		if err != nil {
+			return "", err
+		}
+
+		return d.formatInteger(n), nil
+	case 'f', 'e', 'E', 'g', 'G':
+		n, err := f.toFloat(arg)
+
+		if err != nil {
+			return "", err
+		}
+
+		return d.formatFloat(n), nil
+	case '%':
+		return "%", nil
+	}
+
+	return "", f.error(errors.ArgumentError, "malformed format string - %%%c", d.verb)
+}
+
+// formatInteger formats an integer with Go's formatting, which has the same flags as Ruby's for these verbs
+func (d *formatDirective) formatInteger(n *big.Int) string {
+	if n.Sign() < 0 && strings.ContainsRune("xXobB", d.verb) && !strings.ContainsAny(d.flags, "+ ") {
+		return d.formatTwosComplement(n)
+	}
+
+	verb := d.verb
+
+	switch verb {
+	case 'i', 'u':
+		verb = 'd'
+	case 'B':
+		verb = 'b'
+	}
+
+	result := fmt.Sprintf(d.spec(verb), n)
+
+	if d.verb == 'B' {
+		result = strings.Replace(result, "0b", "0B", 1)
+	}
+
+	return result
+}
+
+// formatTwosComplement formats a negative integer's two's complement after `..`, which stands for the infinite leading `f`, `7` or `1` digits.
+// Only one of the leading digits is kept, so -255 is `..f01` in hexadecimal. The `..` counts in the width and the precision,
+// which are filled with the leading digit, and negative octal numbers don't get the `0` prefix of the `#` flag like in Ruby.
+func (d *formatDirective) formatTwosComplement(n *big.Int) string {
+	base := 16
+	prefix := "0" + string(d.verb)
+
+	switch d.verb {
+	case 'o':
+		base = 8
+		prefix = ""
+	case 'b', 'B':
+		base = 2
+	}
+
+	if !strings.ContainsRune(d.flags, '#') {
+		prefix = ""
+	}
+
+	// b^m + n has m digits and its first digit is the largest one, since -n has fewer than m digits
+	m := len(new(big.Int).Neg(n).Text(base)) + 1
+	complement := new(big.Int).Exp(big.NewInt(int64(base)), big.NewInt(int64(m)), nil)
+	digits := complement.Add(complement, n).Text(base)
+	leading := digits[:1]
+
+	for strings.HasPrefix(digits, leading+leading) {
+		digits = digits[1:]
+	}
+
+	precision := d.precision - 2
+
+	if d.precision < 0 && strings.ContainsRune(d.flags, '0') && !strings.ContainsRune(d.flags, '-') {
+		precision = d.width - 2 - len(prefix)
+	}
+
+	if precision > len(digits) {
+		digits = strings.Repeat(leading, precision-len(digits)) + digits
+	}
+
+	if d.verb == 'X' {
+		digits = strings.ToUpper(digits)
+	}
+
+	return d.pad(prefix + ".." + digits)
+}
+
+// formatFloat formats a float with Go's formatting, `%g` uses 6 significant digits by default like Ruby's
+func (d *formatDirective) formatFloat(n float64) string {
+	if math.IsInf(n, 0) || math.IsNaN(n) {
+		str := "Inf"
+
+		switch {
+		case math.IsNaN(n):
+			str = "NaN"
+		case n < 0:
+			str = "-Inf"
+		case strings.ContainsRune(d.flags, '+'):
+			str = "+Inf"
+		case strings.ContainsRune(d.flags, ' '):
+			str = " Inf"
+		}
+
+		return d.pad(str)
+	}
+
+	if (d.verb == 'g' || d.verb == 'G') && d.precision < 0 {
+		d.precision = 6
+	}
+
+	return fmt.Sprintf(d.spec(d.verb), n)
+}
+
+// spec returns the directive as a Go format with the given verb
+func (d *formatDirective) spec(verb rune) string {
+	spec := "%" + d.flags
+
+	if d.width >= 0 {
+		spec += strconv.Itoa(d.width)
+	}
+
+	if d.precision >= 0 {
+		spec += "." + strconv.Itoa(d.precision)
+	}
+
+	return spec + string(verb)
+}
+
+// truncate keeps the precision's number of characters of a string
+func (d *formatDirective) truncate(str string) string {
+	if d.precision >= 0 && utf8.RuneCountInString(str) > d.precision {
+		return string([]rune(str)[:d.precision])
+	}
+
+	return str
+}
+
+// pad pads a string with spaces to the width, on the left unless the directive has the `-` flag
+func (d *formatDirective) pad(str string) string {
+	n := d.width - utf8.RuneCountInString(str)
+
+	if n <= 0 {
+		return str
+	}
+
+	if strings.ContainsRune(d.flags, '-') {
+		return str + strings.Repeat(" ", n)
+	}
+
+	return strings.Repeat(" ", n) + str
+}
+
+// argument returns the next argument
+func (f *formatter) argument() (Object, *Error) {
+	if f.nextArg >= len(f.args) {
+		return nil, f.error(errors.ArgumentError, "too few arguments")
+	}
+
+	arg := f.args[f.nextArg]
+	f.nextArg++
+	return arg, nil
+}
+
+// positionalArgument returns the nth argument, counting from 1
+func (f *formatter) positionalArgument(n int) (Object, *Error) {
+	if n > len(f.args) {
+		return nil, f.error(errors.ArgumentError, "invalid index - %d$", n)
+	}
+
+	return f.args[n-1], nil
+}
+
+// namedArgument returns the value of the key in the hash argument
+func (f *formatter) namedArgument(name string) (Object, *Error) {
+	if len(f.args) != 1 {
+		return nil, f.error(errors.ArgumentError, "one hash required")
+	}
+
+	hash, ok := f.args[0].(*HashObject)
+
+	if !ok {
+		return nil, f.error(errors.ArgumentError, "one hash required")
+	}
+
+	pair, err := hash.find(f.t, f.t.vm.initSymbolObject(name), f.sourceLine)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if pair == nil {
+		return nil, f.error(errors.KeyError, "key<%s> not found", name)
+	}
+
+	return pair.value, nil
+}
+
+// integerArgument returns the next argument, which must be an Integer, for a `*` width or precision
+func (f *formatter) integerArgument() (int, *Error) {
+	arg, err := f.argument()
+
+	if err != nil {
+		return 0, err
+	}
+
+	n, ok := arg.(*IntegerObject)
+
+	if !ok {
+		return 0, f.error(errors.TypeError, errors.WrongArgumentTypeFormat, classes.IntegerClass, arg.Class().Name)
+	}
+
+	return n.value, nil
+}
+
+// objectString returns the result of calling the object's `to_s` or `inspect` method.
+// Objects without an `inspect` method are shown like in arrays, with quotes around strings and colons before symbols.
+func (f *formatter) objectString(arg Object, methodName string) (string, *Error) {
+	if methodName == "inspect" && arg.findMethod(methodName) == nil {
+		if str, ok := arg.(*StringObject); ok {
+			return strconv.Quote(str.value), nil
+		}
+
+		return inspectString(arg), nil
+	}
+
+	result := f.t.callMethod(arg, methodName, f.sourceLine)
+
+	if err, ok := result.(*Error); ok {
+		return "", err
+	}
+
+	return result.toString(), nil
+}
+
+// toInteger converts an Integer, a Float or a String argument to an integer like Ruby's `Integer()`
+func (f *formatter) toInteger(arg Object) (*big.Int, *Error) {
+	switch a := arg.(type) {
+	case *IntegerObject:
+		if a.bigValue != nil {
+			return a.bigValue, nil
+		}
+
+		return big.NewInt(int64(a.value)), nil
+	case *FloatObject:
+		if math.IsInf(a.value, 0) || math.IsNaN(a.value) {
+			return nil, f.error(errors.ArgumentError, "%s", strconv.FormatFloat(a.value, 'g', -1, 64))
+		}
+
+		n, _ := big.NewFloat(a.value).Int(nil)
+		return n, nil
+	case *StringObject:
+		n, ok := new(big.Int).SetString(strings.TrimSpace(a.value), 0)
+
+		if !ok {
+			return nil, f.error(errors.ArgumentError, "invalid value for Integer(): %s", strconv.Quote(a.value))
+		}
+
+		return n, nil
+	}
+
+	return nil, f.error(errors.TypeError, errors.WrongArgumentTypeFormat, classes.IntegerClass, arg.Class().Name)
+}
+
+// toFloat converts an Integer, a Float, a Decimal or a String argument to a float like Ruby's `Float()`
+func (f *formatter) toFloat(arg Object) (float64, *Error) {
+	switch a := arg.(type) {
+	case *IntegerObject:
+		if a.bigValue != nil {
+			n, _ := new(big.Float).SetInt(a.bigValue).Float64()
+			return n, nil
+		}
+
+		return float64(a.value), nil
+	case *FloatObject:
+		return a.value, nil
+	case *DecimalObject:
+		n, _ := a.value.Float64()
+		return n, nil
+	case *StringObject:
+		n, err := strconv.ParseFloat(strings.TrimSpace(a.value), 64)
+
+		if err != nil {
+			return 0, f.error(errors.ArgumentError, "invalid value for Float(): %s", strconv.Quote(a.value))
+		}
+
+		return n, nil
+	}
+
+	return 0, f.error(errors.TypeError, errors.WrongArgumentTypeFormat, classes.FloatClass, arg.Class().Name)
+}
+
+func (f *formatter) error(errorType string, format string, args ...interface{}) *Error {
+	return f.t.vm.initErrorObject(errorType, f.sourceLine, format, args...)
+}
+
+// readNumber reads the digits at the position, and returns their value and the position after them
+func readNumber(runes []rune, i int) (int, int) {
+	end := i
+
+	for end < len(runes) && runes[end] >= '0' && runes[end] <= '9' {
+		end++
+	}
+
+	n, _ := strconv.Atoi(string(runes[i:end]))
+	return n, end
+}
+
+// formatArguments formats the arguments that follow the format string, for `format`, `sprintf` and `printf`
+func (t *thread) formatArguments(args []Object, sourceLine int) Object {
+	if len(args) < 1 {
+		return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentMoreFormat, 1, len(args))
+	}
+
+	format, ok := args[0].(*StringObject)
+
+	if !ok {
+		return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.StringClass, args[0].Class().Name)
+	}
+
+	return t.sprintf(format.value, args[1:], sourceLine)
+}
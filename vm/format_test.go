@@ -0,0 +1,114 @@
+package vm
+
+import (
+	"testing"
+)
+
+func TestFormatMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`format("Hello")`, "Hello"},
+		{`format("%s and %s", "Goby", "Ruby")`, "Goby and Ruby"},
+		{`format("%s|%s|%s", nil, 1, :sym)`, "|1|sym"},
+		{`format("%-10s|", "Goby")`, "Goby      |"},
+		{`format("%10s|", "Goby")`, "      Goby|"},
+		{`format("%5s|", "漢字")`, "   漢字|"},
+		{`format("%.3s", "abcdef")`, "abc"},
+		{`format("%p", "a")`, `"a"`},
+		{`format("%p", nil)`, "nil"},
+		{`format("%p", [1, "a"])`, `[1, "a"]`},
+		{`format("%c%c", 65, "BC")`, "AB"},
+		{`format("100%%")`, "100%"},
+		{`format("%d %i %u", 1, -2, 3)`, "1 -2 3"},
+		{`format("%+d % d %05d %-5d|", 5, 5, -42, 7)`, "+5  5 -0042 7    |"},
+		{`format("%.3d", 7)`, "007"},
+		{`format("%x %X %#x %#X", 255, 255, 255, 255)`, "ff FF 0xff 0XFF"},
+		{`format("%o %#o", 8, 8)`, "10 010"},
+		{`format("%b %#b %#B %08b", 5, 5, 5, 5)`, "101 0b101 0B101 00000101"},
+		{`format("%d", 2 ** 70)`, "1180591620717411303424"},
+		{`format("%x", 2 ** 64)`, "10000000000000000"},
+		{`format("%x %X %#x %x %x", -255, -255, -255, -1, -256)`, "..f01 ..F01 0x..f01 ..f ..f00"},
+		{`format("%+x % x %+#x", -255, -255, -255)`, "-ff -ff -0xff"},
+		{`format("%o %#o %b %#B", -123, -123, -123, -5)`, "..7605 ..7605 ..10000101 0B..1011"},
+		{`format("%08x|%.8x|%12.8x|%-8x|%8x", -11, -11, -11, -11, -11)`, "..fffff5|..fffff5|    ..fffff5|..f5    |    ..f5"},
+		{`format("%x", -(2 ** 64))`, "..f0000000000000000"},
+		{`format("%d %d %d", 3.99, "42", "0x1f")`, "3 42 31"},
+		{`format("%f", 3.14159)`, "3.141590"},
+		{`format("%.2f %05.1f %+.1f", 3.14159, 2.25, 1)`, "3.14 002.2 +1.0"},
+		{`format("%10.4f|", 3.14159)`, "    3.1416|"},
+		{`format("%e %E", 12345.678, 0.00012)`, "1.234568e+04 1.200000E-04"},
+		{`format("%.2e", 12345.678)`, "1.23e+04"},
+		{`format("%g %g %g %G", 1234567.0, 0.0001, 100000.0, 0.00001)`, "1.23457e+06 0.0001 100000 1E-05"},
+		{`format("%.3g", 3.14159)`, "3.14"},
+		{`format("%f", "1.5")`, "1.500000"},
+		{`
+		require "decimal"
+		format("%.2f", Decimal.new("1.5"))
+		`, "1.50"},
+		{`format("%*d|%-*d|", 5, 1, 4, 2)`, "    1|2   |"},
+		{`format("%*d|", -4, 1)`, "1   |"},
+		{`format("%.*f", 2, 3.14159)`, "3.14"},
+		{`format("%2$s %1$s %2$s", "a", "b")`, "b a b"},
+		{`format("%{name} is %{age}", { name: "Goby", age: 5 })`, "Goby is 5"},
+		{`format("%<name>s is %<age>03d", { name: "Goby", age: 5 })`, "Goby is 005"},
+		{`format("%<pi>.2f", { pi: 3.14159 })`, "3.14"},
+		{`format("%{a}", { "a" => 1, a: 2 })`, "2"},
+		{`format("%-5{x}|", { x: "a" })`, "a    |"},
+		{`sprintf("%03d", 7)`, "007"},
+		{`"%s is %d years old" % ["Goby", 5]`, "Goby is 5 years old"},
+		{`"%05.2f" % 3.14159`, "03.14"},
+		{`
+		class Point
+		  def to_s
+		    "(1, 2)"
+		  end
+
+		  def inspect
+		    "#<Point>"
+		  end
+		end
+
+		format("%s %p", Point.new, Point.new)
+		`, "(1, 2) #<Point>"},
+		{`printf("%s", "")`, nil},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestFormatMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`format`, "ArgumentError: Expect 1 or more arguments. got: 0", 1, 1},
+		{`format(1)`, "TypeError: Expect argument to be String. got: Integer", 1, 1},
+		{`format("%s %s", 1)`, "ArgumentError: too few arguments", 1, 1},
+		{`format("%z", 1)`, "ArgumentError: malformed format string - %z", 1, 1},
+		{`format("%5", 1)`, "ArgumentError: incomplete format specifier; use %% (double %) instead", 1, 1},
+		{`format("%d", "abc")`, "ArgumentError: invalid value for Integer(): \"abc\"", 1, 1},
+		{`format("%f", "abc")`, "ArgumentError: invalid value for Float(): \"abc\"", 1, 1},
+		{`format("%d", nil)`, "TypeError: Expect argument to be Integer. got: Null", 1, 1},
+		{`format("%f", [])`, "TypeError: Expect argument to be Float. got: Array", 1, 1},
+		{`format("%*d", "a", 1)`, "TypeError: Expect argument to be Integer. got: String", 1, 1},
+		{`format("%3$s", 1)`, "ArgumentError: invalid index - 3$", 1, 1},
+		{`format("%{name}", 1)`, "ArgumentError: one hash required", 1, 1},
+		{`format("%{name", { name: 1 })`, "ArgumentError: malformed name - unmatched parenthesis", 1, 1},
+		{`format("%{age}", { name: 1 })`, "KeyError: key<age> not found", 1, 1},
+		{`printf("%d", "x")`, "ArgumentError: invalid value for Integer(): \"x\"", 1, 1},
+		{`sprintf()`, "ArgumentError: Expect 1 or more arguments. got: 0", 1, 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, tt.expectedCFP)
+		v.checkSP(t, i, 1)
+	}
+}
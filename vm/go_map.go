@@ -20,6 +20,8 @@ func builtinGoMapClassMethods() []*BuiltinMethodObject {
 			// Initialize a new GoMap instance.
 			// It can be called without any arguments, which will create an empty map.
 			// Or you can pass a hash as argument, so the map will have same pairs.
+			// Since the map is keyed by Go strings, the hash's keys should be strings or symbols.
+			// A string key and a symbol key with the same name are merged, and the value inserted later is kept.
 			//
 			// @return [GoMap]
 			Name: "new",
@@ -37,7 +39,16 @@ func builtinGoMapClassMethods() []*BuiltinMethodObject {
 						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.HashClass, args[0].Class().Name)
 					}
 
-					for k, v := range hash.Pairs {
+					for _, p := range hash.pairs {
+						if _, ok := symbolOrStringValue(p.key); !ok {
+							return t.vm.initErrorObject(errors.TypeError, sourceLine, "Expect hash keys to be Symbol or String. got: %s", p.key.Class().Name)
+						}
+					}
+
+					// A String key and a Symbol key with the same name are merged, the later one is kept
+					_, pairs := mergeStringKeys(hash.pairs)
+
+					for k, v := range pairs {
 						m[k] = v.Value()
 					}
 
@@ -80,15 +91,15 @@ func builtinGoMapInstanceMethods() []*BuiltinMethodObject {
 						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "Expect 1 argument. got: %d", len(args))
 					}
 
-					key, ok := args[0].(*StringObject)
+					key, ok := symbolOrStringValue(args[0])
 
 					if !ok {
-						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.StringClass, args[0].Class().Name)
+						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.SymbolClass+" or "+classes.StringClass, args[0].Class().Name)
 					}
 
 					m := receiver.(*GoMap).data
 
-					result, ok := m[key.value]
+					result, ok := m[key]
 
 					if !ok {
 						return NULL
@@ -112,15 +123,15 @@ func builtinGoMapInstanceMethods() []*BuiltinMethodObject {
 						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "Expect 2 argument. got: %d", len(args))
 					}
 
-					key, ok := args[0].(*StringObject)
+					key, ok := symbolOrStringValue(args[0])
 
 					if !ok {
-						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.StringClass, args[0].Class().Name)
+						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.SymbolClass+" or "+classes.StringClass, args[0].Class().Name)
 					}
 
 					m := receiver.(*GoMap).data
 
-					m[key.value] = args[1]
+					m[key] = args[1]
 
 					return args[1]
 				}
@@ -59,6 +59,11 @@ func TestGoMapGetMethod(t *testing.T) {
 		m = GoMap.new
 		m.get("foo")
 		`, nil},
+		{`
+		h = { "foo" => "bar", foo: "baz" }
+		m = GoMap.new(h)
+		m.get("foo")
+		`, "baz"},
 	}
 
 	for i, tt := range tests {
@@ -100,12 +105,12 @@ func TestGoMapToHashMethod(t *testing.T) {
 		h = { foo: "bar" }
 		m = GoMap.new(h)
 		h2 = m.to_hash
-		h2[:foo]
+		h2["foo"]
 		`, "bar"},
 		{`
 		m = GoMap.new
 		h = m.to_hash
-		h[:foo]
+		h["foo"]
 		`, nil},
 	}
 
@@ -117,3 +122,19 @@ func TestGoMapToHashMethod(t *testing.T) {
 		v.checkSP(t, i, 1)
 	}
 }
+
+func TestGoMapMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`GoMap.new({ 1 => "bar" })`, "TypeError: Expect hash keys to be Symbol or String. got: Integer", 1, 1},
+		{`GoMap.new.set(1, "bar")`, "TypeError: Expect argument to be Symbol or String. got: Integer", 1, 1},
+		{`GoMap.new.get(nil)`, "TypeError: Expect argument to be Symbol or String. got: Null", 1, 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, tt.expectedCFP)
+		v.checkSP(t, i, 1)
+	}
+}
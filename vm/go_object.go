@@ -38,7 +38,7 @@ func builtinGoObjectInstanceMethods() []*BuiltinMethodObject {
 					funcArgs, err := convertToGoFuncArgs(args[1:])
 
 					if err != nil {
-						t.vm.initErrorObject(errors.TypeError, sourceLine, err.Error())
+						t.vm.initErrorObject(errors.TypeError, sourceLine, "%s", err.Error())
 					}
 
 					result := metago.CallFunc(r.data, funcName, funcArgs...)
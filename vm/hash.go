@@ -3,7 +3,7 @@ package vm
 import (
 	"bytes"
 	"fmt"
-	"reflect"
+	"hash/fnv"
 	"sort"
 	"strings"
 
@@ -19,34 +19,56 @@ import (
 //
 // - **Key:** an alphanumeric word that starts with alphabet, without containing space and punctuations.
 // Underscore `_` can also be used within the key.
-// String literal like "mickey mouse" cannot be used as a hash key.
-// The internal key is actually a String and **not a Symbol** for now (TBD).
-// Thus only a String object or a string literal should be used when referencing with `[ ]`.
+// The key written like `key:` is a Symbol.
+// Other objects can be used as keys with `=>`, like `{ "a" => 1, [1, 2] => "b", nil => "c" }`.
 //
 // ```ruby
 // a = { balthazar1: 100 } # valid
 // b = { 2melchior: 200 }  # invalid
-// x = 'balthazar1'
+// x = :balthazar1
 //
-// a["balthazar1"]  # => 100
+// a[:balthazar1]   # => 100
 // a[x]             # => 100
+// a["balthazar1"]  # => nil
 // a[balthazar1]    # => error
 // ```
 //
 // - **value:** String literal and objects (Integer, String, Array, Hash, nil, etc) can be used.
 //
+// Keys are compared by their values:
+// - String, Symbol, Integer, Float, Boolean, nil, Range and Array keys are the same when their classes and values are the same. `1` and `1.0` are different keys, and so are `:a` and `"a"`.
+// - Objects that define `hash` are the same key when their `hash` results are the same and `eql?` (or `==` if `eql?` isn't defined) returns true.
+// - Other objects are only the same key as themselves.
+//
 // **Note:**
-// - The order of key-value pairs are **not** preserved.
-// - Operator `=>` is not supported.
+// - The order of key-value pairs is the order the keys are inserted.
 // - `Hash.new` is not supported.
 type HashObject struct {
 	*baseObj
-	Pairs map[string]Object
+	// pairs holds the key-value pairs in the insertion order, and index holds them by their keys' hashKey
+	pairs []*hashPair
+	index map[hashKey][]*hashPair
 
 	// See `[]` and `[]=` for the operational explanation of the default value.
 	Default Object
 }
 
+// hashPair is a key-value pair of a hash
+type hashPair struct {
+	hashKey hashKey
+	key     Object
+	value   Object
+}
+
+// hashKey is the comparable form of a hash key object, keys that are the same share the same hashKey
+type hashKey struct {
+	kind  string
+	value interface{}
+}
+
+// userHashKind is the kind of hashKey for objects that define their own `hash` method, these keys also need to be compared with `eql?`
+const userHashKind = "#hash"
+
 // Class methods --------------------------------------------------------
 func builtinHashClassMethods() []*BuiltinMethodObject {
 	return []*BuiltinMethodObject{
@@ -70,17 +92,17 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 			//
 			// ```Ruby
 			// h = { a: 1, b: "2", c: [1, 2, 3], d: { k: 'v' } }
-			// h['a'] #=> 1
-			// h['b'] #=> "2"
-			// h['c'] #=> [1, 2, 3]
-			// h['d'] #=> { k: 'v' }
+			// h[:a] #=> 1
+			// h[:b] #=> "2"
+			// h[:c] #=> [1, 2, 3]
+			// h[:d] #=> { k: 'v' }
 			//
 			// h = { a: 1 }
-			// h['c']        #=> nil
+			// h[:c]        #=> nil
 			// h.default = 0
-			// h['c']        #=> 0
+			// h[:c]        #=> 0
 			// h             #=> { a: 1 }
-			// h['d'] += 2
+			// h[:d] += 2
 			// h             #=> { a: 1, d: 2 }
 			// ```
 			//
@@ -93,16 +115,12 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "Expect 1 argument. got: %d", len(args))
 					}
 
-					i := args[0]
-					key, ok := i.(*StringObject)
-
-					if !ok {
-						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.StringClass, i.Class().Name)
-					}
-
 					h := receiver.(*HashObject)
+					value, ok, err := h.get(t, args[0], sourceLine)
 
-					value, ok := h.Pairs[key.value]
+					if err != nil {
+						return err
+					}
 
 					if !ok {
 						if h.Default != nil {
@@ -122,10 +140,10 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 			//
 			// ```Ruby
 			// h = { a: 1, b: "2", c: [1, 2, 3], d: { k: 'v' } }
-			// h['a'] = 1          #=> 1
-			// h['b'] = "2"        #=> "2"
-			// h['c'] = [1, 2, 3]  #=> [1, 2, 3]
-			// h['d'] = { k: 'v' } #=> { k: 'v' }
+			// h[:a] = 1          #=> 1
+			// h[:b] = "2"        #=> "2"
+			// h[:c] = [1, 2, 3]  #=> [1, 2, 3]
+			// h[:d] = { k: 'v' } #=> { k: 'v' }
 			// ```
 			//
 			// @return [Object] The value
@@ -139,16 +157,12 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "Expect 2 arguments. got: %d", len(args))
 					}
 
-					k := args[0]
-					key, ok := k.(*StringObject)
+					h := receiver.(*HashObject)
 
-					if !ok {
-						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.StringClass, k.Class().Name)
+					if err := h.set(t, args[0], args[1], sourceLine); err != nil {
+						return err
 					}
 
-					h := receiver.(*HashObject)
-					h.Pairs[key.value] = args[1]
-
 					return args[1]
 				}
 			},
@@ -192,13 +206,12 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 
 					hash := receiver.(*HashObject)
 
-					if len(hash.Pairs) == 0 {
+					if hash.length() == 0 {
 						t.callFrameStack.pop()
 					}
 
-					for stringKey, value := range hash.Pairs {
-						objectKey := t.vm.initStringObject(stringKey)
-						result := t.builtinMethodYield(blockFrame, objectKey, value)
+					for _, p := range hash.pairs {
+						result := t.builtinMethodYield(blockFrame, p.key, p.value)
 
 						booleanResult, isResultBoolean := result.Target.(*BooleanObject)
 
@@ -233,7 +246,7 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 
 					h := receiver.(*HashObject)
 
-					h.Pairs = make(map[string]Object)
+					h.clear()
 
 					return h
 				}
@@ -273,9 +286,9 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 			//
 			// ```Ruby
 			// h = { a: 1 }
-			// h['c']         #=> nil
+			// h[:c]         #=> nil
 			// h.default = 2
-			// h['c']         #=> 2
+			// h[:c]         #=> 2
 			// h.default = [] #=> ArgumentError
 			// ```
 			//
@@ -308,7 +321,7 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 			//
 			// ```Ruby
 			// h = { a: 1, b: 2, c: 3 }
-			// h.delete("b") # =>  { a: 1, c: 3 }
+			// h.delete(:b) # =>  { a: 1, c: 3 }
 			// ```
 			//
 			// @return [Hash]
@@ -320,17 +333,11 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 					}
 
 					h := receiver.(*HashObject)
-					d := args[0]
-					deleteKey, ok := d.(*StringObject)
 
-					if !ok {
-						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.StringClass, d.Class().Name)
+					if _, err := h.delete(t, args[0], sourceLine); err != nil {
+						return err
 					}
 
-					deleteKeyValue := deleteKey.value
-					if _, ok := h.Pairs[deleteKeyValue]; ok {
-						delete(h.Pairs, deleteKeyValue)
-					}
 					return h
 				}
 			},
@@ -362,24 +369,22 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 
 					hash := receiver.(*HashObject)
 
-					if len(hash.Pairs) == 0 {
+					if hash.length() == 0 {
 						t.callFrameStack.pop()
 					}
 
-					// Note that from the Go specification, https://golang.org/ref/spec#For_statements,
-					// it's safe to delete elements from a Map, while iterating it.
-					for stringKey, value := range hash.Pairs {
-						objectKey := t.vm.initStringObject(stringKey)
-						result := t.builtinMethodYield(blockFrame, objectKey, value)
+					// Deleting a pair makes a new slice, so it's safe to keep iterating the original one.
+					for _, p := range hash.pairs {
+						result := t.builtinMethodYield(blockFrame, p.key, p.value)
 
 						booleanResult, isResultBoolean := result.Target.(*BooleanObject)
 
 						if isResultBoolean {
 							if booleanResult.value {
-								delete(hash.Pairs, stringKey)
+								hash.deletePair(p)
 							}
 						} else if result.Target != NULL {
-							delete(hash.Pairs, stringKey)
+							hash.deletePair(p)
 						}
 					}
 
@@ -414,7 +419,7 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 			},
 		},
 		{
-			// Calls block once for each key in the hash (in sorted key order), passing the
+			// Calls block once for each key in the hash (in insertion order), passing the
 			// key-value pair as parameters.
 			// Returns `self`.
 			//
@@ -423,8 +428,8 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 			// h.each do |k, v|
 			//   puts k.to_s + "->" + v.to_s
 			// end
-			// # => a->1
 			// # => b->2
+			// # => a->1
 			// ```
 			//
 			// @return [Hash]
@@ -441,16 +446,11 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 
 					h := receiver.(*HashObject)
 
-					if len(h.Pairs) == 0 {
+					if h.length() == 0 {
 						t.callFrameStack.pop()
 					} else {
-						keys := h.sortedKeys()
-
-						for _, k := range keys {
-							v := h.Pairs[k]
-							strK := t.vm.initStringObject(k)
-
-							t.builtinMethodYield(blockFrame, strK, v)
+						for _, p := range h.pairs {
+							t.builtinMethodYield(blockFrame, p.key, p.value)
 						}
 					}
 
@@ -460,7 +460,7 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 		},
 		{
 			// Loop through keys of the hash with given block frame. It also returns array of
-			// keys in alphabetical order.
+			// keys in insertion order.
 			//
 			// ```Ruby
 			// h = { a: 1, b: "2", c: [1, 2, 3], d: { k: 'v' } }
@@ -487,26 +487,23 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 
 					h := receiver.(*HashObject)
 
-					if len(h.Pairs) == 0 {
+					if h.length() == 0 {
 						t.callFrameStack.pop()
 					}
 
-					keys := h.sortedKeys()
-					var arrOfKeys []Object
+					keys := h.keys()
 
 					for _, k := range keys {
-						obj := t.vm.initStringObject(k)
-						arrOfKeys = append(arrOfKeys, obj)
-						t.builtinMethodYield(blockFrame, obj)
+						t.builtinMethodYield(blockFrame, k)
 					}
 
-					return t.vm.initArrayObject(arrOfKeys)
+					return t.vm.initArrayObject(keys)
 				}
 			},
 		},
 		{
 			// Loop through values of the hash with given block frame. It also returns array of
-			// values of the hash in the alphabetical order of its key
+			// values of the hash in the insertion order of its key
 			//
 			// ```Ruby
 			// h = { a: 1, b: "2", c: [1, 2, 3], d: { k: "v" } }
@@ -532,20 +529,17 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 
 					h := receiver.(*HashObject)
 
-					if len(h.Pairs) == 0 {
+					if h.length() == 0 {
 						t.callFrameStack.pop()
 					}
 
-					keys := h.sortedKeys()
-					var arrOfValues []Object
+					values := h.values()
 
-					for _, k := range keys {
-						value := h.Pairs[k]
-						arrOfValues = append(arrOfValues, value)
+					for _, value := range values {
 						t.builtinMethodYield(blockFrame, value)
 					}
 
-					return t.vm.initArrayObject(arrOfValues)
+					return t.vm.initArrayObject(values)
 				}
 			},
 		},
@@ -592,10 +586,17 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 					c := args[0]
 					compare, ok := c.(*HashObject)
 
-					if ok && reflect.DeepEqual(h, compare) {
-						return TRUE
+					if !ok {
+						return FALSE
 					}
-					return FALSE
+
+					equal, err := h.equal(t, compare, sourceLine)
+
+					if err != nil {
+						return err
+					}
+
+					return toBooleanObject(equal)
 				}
 			},
 		},
@@ -624,14 +625,12 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 					}
 
 					hash := receiver.(*HashObject)
-					key, ok := args[0].(*StringObject)
+					value, ok, err := hash.get(t, args[0], sourceLine)
 
-					if !ok {
-						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.StringClass, key.Class().Name)
+					if err != nil {
+						return err
 					}
 
-					value, ok := hash.Pairs[key.value]
-
 					if ok {
 						if blockFrame != nil {
 							t.callFrameStack.pop()
@@ -645,7 +644,7 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 					}
 
 					if blockFrame != nil {
-						return t.builtinMethodYield(blockFrame, key).Target
+						return t.builtinMethodYield(blockFrame, args[0]).Target
 					}
 
 					return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "The value was not found, and no block has been provided")
@@ -677,14 +676,12 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 					}
 
 					hash := receiver.(*HashObject)
-					key, ok := args[0].(*StringObject)
+					value, ok, err := hash.get(t, args[0], sourceLine)
 
-					if !ok {
-						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.StringClass, key.Class().Name)
+					if err != nil {
+						return err
 					}
 
-					value, ok := hash.Pairs[key.value]
-
 					if ok {
 						if blockFrame != nil {
 							t.callFrameStack.pop()
@@ -698,7 +695,7 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 					}
 
 					if blockFrame != nil {
-						return t.builtinMethodYield(blockFrame, key).Target
+						return t.builtinMethodYield(blockFrame, args[0]).Target
 					}
 
 					return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "The value was not found, and no block has been provided")
@@ -712,9 +709,9 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 			// ```Ruby
 			// h = { cat: "feline", dog: "canine", cow: "bovine" }
 			//
-			// h.fetch_values("cow", "cat")                      #=> ["bovine", "feline"]
-			// h.fetch_values("cow", "bird")                     # raises ArgumentError
-			// h.fetch_values("cow", "bird") do |k| k.upcase end #=> ["bovine", "BIRD"]
+			// h.fetch_values(:cow, :cat)                           #=> ["bovine", "feline"]
+			// h.fetch_values(:cow, :bird)                          # raises ArgumentError
+			// h.fetch_values(:cow, :bird) do |k| k.to_s.upcase end #=> ["bovine", "BIRD"]
 			// ```
 			//
 			// @return [ArrayObject]
@@ -731,27 +728,25 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 					blockFramePopped := false
 
 					for index, objectKey := range args {
-						stringKey, ok := objectKey.(*StringObject)
+						value, ok, err := hash.get(t, objectKey, sourceLine)
 
-						if !ok {
-							return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.StringClass, objectKey.Class().Name)
+						if err != nil {
+							return err
 						}
 
-						value, ok := hash.Pairs[stringKey.value]
-
 						if !ok {
 							if blockFrame != nil {
 								value = t.builtinMethodYield(blockFrame, objectKey).Target
 								blockFramePopped = true
 							} else {
-								return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "There is no value for the key `%s`, and no block has been provided", stringKey.value)
+								return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "There is no value for the key `%s`, and no block has been provided", hashKeyString(objectKey))
 							}
 						}
 
 						values[index] = value
 					}
 
-					if blockFrame != nil && ! blockFramePopped {
+					if blockFrame != nil && !blockFramePopped {
 						t.callFrameStack.pop()
 					}
 
@@ -760,16 +755,13 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 			},
 		},
 		{
-			// Returns true if the key exist in the hash. Currently, it can only input string
-			// type object.
+			// Returns true if the key exist in the hash.
 			//
 			// ```Ruby
 			// h = { a: 1, b: "2", c: [1, 2, 3], d: { k: "v" } }
-			// h.has_key?("a") # => true
-			// h.has_key?("e") # => false
-			// # TODO: Support Symbol Type Key Input
-			// h.has_key?(:b)  # => true
-			// h.has_key?(:f)  # => false
+			// h.has_key?(:a)  # => true
+			// h.has_key?(:e)  # => false
+			// h.has_key?("b") # => false
 			// ```
 			//
 			// @return [Boolean]
@@ -781,17 +773,13 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 					}
 
 					h := receiver.(*HashObject)
-					i := args[0]
-					input, ok := i.(*StringObject)
+					_, ok, err := h.get(t, args[0], sourceLine)
 
-					if !ok {
-						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.StringClass, i.Class().Name)
+					if err != nil {
+						return err
 					}
 
-					if _, ok := h.Pairs[input.value]; ok {
-						return TRUE
-					}
-					return FALSE
+					return toBooleanObject(ok)
 				}
 			},
 		},
@@ -817,8 +805,14 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 
 					h := receiver.(*HashObject)
 
-					for _, v := range h.Pairs {
-						if reflect.DeepEqual(v, args[0]) {
+					for _, v := range h.values() {
+						equal, err := t.objectsEqual(v, args[0], sourceLine)
+
+						if err != nil {
+							return err
+						}
+
+						if equal {
 							return TRUE
 						}
 					}
@@ -827,11 +821,11 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 			},
 		},
 		{
-			// Returns an array of keys (in arbitrary order)
+			// Returns an array of keys (in insertion order)
 			//
 			// ```Ruby
 			// { a: 1, b: "2", c: [3, true, "Hello"] }.keys
-			// # =>  ["c", "b", "a"] or ["b", "a", "c"] ... etc
+			// # =>  [:a, :b, :c]
 			// ```
 			//
 			// @return [Boolean]
@@ -843,11 +837,7 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 					}
 
 					h := receiver.(*HashObject)
-					var keys []Object
-					for k := range h.Pairs {
-						keys = append(keys, t.vm.initStringObject(k))
-					}
-					return t.vm.initArrayObject(keys)
+					return t.vm.initArrayObject(h.keys())
 				}
 			},
 		},
@@ -899,13 +889,13 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 
 					h := receiver.(*HashObject)
 
-					if len(h.Pairs) == 0 {
+					if h.length() == 0 {
 						t.callFrameStack.pop()
 					}
 
-					for k, v := range h.Pairs {
-						result := t.builtinMethodYield(blockFrame, v)
-						h.Pairs[k] = result.Target
+					for _, p := range h.pairs {
+						result := t.builtinMethodYield(blockFrame, p.value)
+						p.value = result.Target
 					}
 					return h
 				}
@@ -929,22 +919,22 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 					}
 
 					h := receiver.(*HashObject)
-					result := make(map[string]Object)
-					for k, v := range h.Pairs {
-						result[k] = v
-					}
+					result := h.copy().(*HashObject)
+					result.Default = nil
 
 					for _, obj := range args {
 						hashObj, ok := obj.(*HashObject)
 						if !ok {
 							return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.HashClass, obj.Class().Name)
 						}
-						for k, v := range hashObj.Pairs {
-							result[k] = v
+						for _, p := range hashObj.pairs {
+							if err := result.set(t, p.key, p.value, sourceLine); err != nil {
+								return err
+							}
 						}
 					}
 
-					return t.vm.initHashObject(result)
+					return result
 				}
 			},
 		},
@@ -980,43 +970,42 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 					}
 
 					sourceHash := receiver.(*HashObject)
-					destinationPairs := map[string]Object{}
+					destinationHash := t.vm.initEmptyHashObject()
 
-					if len(sourceHash.Pairs) == 0 {
+					if sourceHash.length() == 0 {
 						t.callFrameStack.pop()
 					}
 
-					for stringKey, value := range sourceHash.Pairs {
-						objectKey := t.vm.initStringObject(stringKey)
-						result := t.builtinMethodYield(blockFrame, objectKey, value)
+					for _, p := range sourceHash.pairs {
+						result := t.builtinMethodYield(blockFrame, p.key, p.value)
 
 						booleanResult, isResultBoolean := result.Target.(*BooleanObject)
 
 						if isResultBoolean {
 							if booleanResult.value {
-								destinationPairs[stringKey] = value
+								destinationHash.setPair(p.hashKey, p.key, p.value)
 							}
 						} else if result.Target != NULL {
-							destinationPairs[stringKey] = value
+							destinationHash.setPair(p.hashKey, p.key, p.value)
 						}
 					}
 
-					return t.vm.initHashObject(destinationPairs)
+					return destinationHash
 				}
 			},
 		},
 		{
-			// Returns an array of keys (in arbitrary order)
+			// Returns an array of keys sorted by their string format
 			//
 			// ```Ruby
 			// { a: 1, b: "2", c: [3, true, "Hello"] }.sorted_keys
-			// # =>  ["a", "b", "c"]
+			// # =>  [:a, :b, :c]
 			// { c: 1, b: "2", a: [3, true, "Hello"] }.sorted_keys
-			// # =>  ["a", "b", "c"]
+			// # =>  [:a, :b, :c]
 			// { b: 1, c: "2", a: [3, true, "Hello"] }.sorted_keys
-			// # =>  ["a", "b", "c"]
+			// # =>  [:a, :b, :c]
 			// { b: 1, c: "2", b: [3, true, "Hello"] }.sorted_keys
-			// # =>  ["b", "c"]
+			// # =>  [:b, :c]
 			// ```
 			//
 			// @return [Boolean]
@@ -1028,28 +1017,27 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 					}
 
 					h := receiver.(*HashObject)
-					sortedKeys := h.sortedKeys()
 					var keys []Object
-					for _, k := range sortedKeys {
-						keys = append(keys, t.vm.initStringObject(k))
+					for _, p := range h.sortedPairs() {
+						keys = append(keys, p.key)
 					}
 					return t.vm.initArrayObject(keys)
 				}
 			},
 		},
 		{
-			// Returns two-dimensional array with the key-value pairs of hash. If specified true
+			// Returns two-dimensional array with the key-value pairs of hash in insertion order. If specified true
 			// then it will return sorted key value pairs array
 			//
 			// ```Ruby
-			// { a: 1, b: 2, c: 3 }.to_a
-			// # => [["a", 1], ["c", 3], ["b", 2]] or [["b", 2], ["c", 3], ["a", 1]] ... etc
+			// { a: 1, c: 3, b: 2 }.to_a
+			// # => [[:a, 1], [:c, 3], [:b, 2]]
 			// { a: 1, b: 2, c: 3 }.to_a(true)
-			// # => [["a", 1], ["b", 2], ["c", 3]]
+			// # => [[:a, 1], [:b, 2], [:c, 3]]
 			// { b: 1, a: 2, c: 3 }.to_a(true)
-			// # => [["a", 2], ["b", 1], ["c", 3]]
+			// # => [[:a, 2], [:b, 1], [:c, 3]]
 			// { b: 1, a: 2, a: 3 }.to_a(true)
-			// # => [["a", 3], ["b", 1]]
+			// # => [[:a, 3], [:b, 1]]
 			// ```
 			//
 			// @return [Array]
@@ -1073,21 +1061,14 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 						sorted = st.value
 					}
 
-					var resultArr []Object
+					pairs := h.pairs
 					if sorted {
-						for _, k := range h.sortedKeys() {
-							var pairArr []Object
-							pairArr = append(pairArr, t.vm.initStringObject(k))
-							pairArr = append(pairArr, h.Pairs[k])
-							resultArr = append(resultArr, t.vm.initArrayObject(pairArr))
-						}
-					} else {
-						for k, v := range h.Pairs {
-							var pairArr []Object
-							pairArr = append(pairArr, t.vm.initStringObject(k))
-							pairArr = append(pairArr, v)
-							resultArr = append(resultArr, t.vm.initArrayObject(pairArr))
-						}
+						pairs = h.sortedPairs()
+					}
+
+					var resultArr []Object
+					for _, p := range pairs {
+						resultArr = append(resultArr, t.vm.initArrayObject([]Object{p.key, p.value}))
 					}
 					return t.vm.initArrayObject(resultArr)
 				}
@@ -1096,10 +1077,12 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 		{
 			// Returns json that is corresponding to the hash.
 			// Basically just like Hash#to_json in Rails but currently doesn't support options.
+			// Keys with the same string form like "a" and :a are merged, and the value inserted later is kept.
 			//
 			// ```Ruby
 			// h = { a: 1, b: [1, "2", [4, 5, nil], { foo: "bar" }]}.to_json
 			// puts(h) #=> {"a":1,"b":[1, "2", [4, 5, null], {"foo":"bar"}]}
+			// { "a" => 1, a: 2 }.to_json #=> {"a":2}
 			// ```
 			//
 			// @return [String]
@@ -1122,6 +1105,7 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 			// ```Ruby
 			// h = { a: 1, b: [1, "2", [4, 5, nil], { foo: "bar" }]}.to_s
 			// puts(h) #=> "{ a: 1, b: [1, \"2\", [4, 5, null], { foo: \"bar \" }] }"
+			// { "a" => 1, a: 2 }.to_s #=> "{ \"a\" => 1, a: 2 }"
 			// ```
 			//
 			// @return [String]
@@ -1165,25 +1149,25 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 
 					h := receiver.(*HashObject)
 
-					if len(h.Pairs) == 0 {
+					if h.length() == 0 {
 						t.callFrameStack.pop()
 					}
 
-					resultHash := make(map[string]Object)
-					for k, v := range h.Pairs {
-						result := t.builtinMethodYield(blockFrame, v)
-						resultHash[k] = result.Target
+					resultHash := t.vm.initEmptyHashObject()
+					for _, p := range h.pairs {
+						result := t.builtinMethodYield(blockFrame, p.value)
+						resultHash.setPair(p.hashKey, p.key, result.Target)
 					}
-					return t.vm.initHashObject(resultHash)
+					return resultHash
 				}
 			},
 		},
 		{
-			// Returns an array of values (in arbitrary order)
+			// Returns an array of values (in insertion order)
 			//
 			// ```Ruby
-			// { a: 1, b: "2", c: [3, true, "Hello"] }.keys
-			// # =>  [1, "2", [3, true, "Hello"]] or ["2", [3, true, "Hello"], 1] ... etc
+			// { a: 1, b: "2", c: [3, true, "Hello"] }.values
+			// # =>  [1, "2", [3, true, "Hello"]]
 			// ```
 			//
 			// @return [Boolean]
@@ -1195,11 +1179,7 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 					}
 
 					h := receiver.(*HashObject)
-					var keys []Object
-					for _, v := range h.Pairs {
-						keys = append(keys, v)
-					}
-					return t.vm.initArrayObject(keys)
+					return t.vm.initArrayObject(h.values())
 				}
 			},
 		},
@@ -1207,7 +1187,7 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 			// Return an array containing the values associated with the given keys.
 			//
 			// ```Ruby
-			// { a: 1, b: "2" }.values_at("a", "c") # => [1, nil]
+			// { a: 1, b: "2" }.values_at(:a, :c) # => [1, nil]
 			// ```
 			//
 			// @return [Boolean]
@@ -1218,14 +1198,12 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 					var result []Object
 
 					for _, objectKey := range args {
-						stringObjectKey, ok := objectKey.(*StringObject)
+						value, ok, err := hash.get(t, objectKey, sourceLine)
 
-						if !ok {
-							return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.StringClass, objectKey.Class().Name)
+						if err != nil {
+							return err
 						}
 
-						value, ok := hash.Pairs[stringObjectKey.value]
-
 						if !ok {
 							value = NULL
 						}
@@ -1244,10 +1222,28 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 
 // Functions for initialization -----------------------------------------
 
+// initHashObject returns a hash of the given string-keyed pairs, the keys are inserted in sorted order
 func (vm *VM) initHashObject(pairs map[string]Object) *HashObject {
+	h := vm.initEmptyHashObject()
+	keys := []string{}
+
+	for k := range pairs {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		h.setPair(hashKey{kind: classes.StringClass, value: k}, vm.initStringObject(k), pairs[k])
+	}
+
+	return h
+}
+
+func (vm *VM) initEmptyHashObject() *HashObject {
 	return &HashObject{
 		baseObj: &baseObj{class: vm.topLevelClass(classes.HashClass)},
-		Pairs:   pairs,
+		index:   map[hashKey][]*hashPair{},
 	}
 }
 
@@ -1255,14 +1251,17 @@ func (vm *VM) initHashClass() *RClass {
 	hc := vm.initializeClass(classes.HashClass, false)
 	hc.setBuiltinMethods(builtinHashInstanceMethods(), false)
 	hc.setBuiltinMethods(builtinHashClassMethods(), true)
+	vm.libFiles = append(vm.libFiles, "hash.gb")
 	return hc
 }
 
 // Polymorphic helper functions -----------------------------------------
 
-// Value returns the object
+// Value returns the pairs of the hash as a map, which is keyed by the string form of each key.
+// Keys with the same string form are merged, see mergeStringKeys.
 func (h *HashObject) Value() interface{} {
-	return h.Pairs
+	_, pairs := mergeStringKeys(h.pairs)
+	return pairs
 }
 
 // toString returns the object's name as the string format
@@ -1270,13 +1269,8 @@ func (h *HashObject) toString() string {
 	var out bytes.Buffer
 	var pairs []string
 
-	for _, key := range h.sortedKeys() {
-		// TODO: Improve this conditional statement
-		if _, isString := h.Pairs[key].(*StringObject); isString {
-			pairs = append(pairs, fmt.Sprintf("%s: \"%s\"", key, h.Pairs[key].toString()))
-		} else {
-			pairs = append(pairs, fmt.Sprintf("%s: %s", key, h.Pairs[key].toString()))
-		}
+	for _, p := range h.pairs {
+		pairs = append(pairs, hashPairString(p.key, inspectString(p.value)))
 	}
 
 	out.WriteString("{ ")
@@ -1286,15 +1280,17 @@ func (h *HashObject) toString() string {
 	return out.String()
 }
 
-// toJSON returns the object's name as the JSON string format
+// toJSON returns the object's name as the JSON string format.
+// Keys with the same string form are merged, see mergeStringKeys.
 func (h *HashObject) toJSON() string {
 	var out bytes.Buffer
 	var values []string
-	pairs := h.Pairs
 	out.WriteString("{")
 
-	for key, value := range pairs {
-		values = append(values, generateJSONFromPair(key, value))
+	keys, pairs := mergeStringKeys(h.pairs)
+
+	for _, k := range keys {
+		values = append(values, generateJSONFromPair(k, pairs[k]))
 	}
 
 	out.WriteString(strings.Join(values, ","))
@@ -1304,30 +1300,53 @@ func (h *HashObject) toJSON() string {
 
 // Returns the length of the hash
 func (h *HashObject) length() int {
-	return len(h.Pairs)
+	return len(h.pairs)
 }
 
-// Returns the sorted keys of the hash
-func (h *HashObject) sortedKeys() []string {
-	var arr []string
-	for k := range h.Pairs {
-		arr = append(arr, k)
+// Returns the pairs of the hash sorted by the string form of their keys
+func (h *HashObject) sortedPairs() []*hashPair {
+	pairs := make([]*hashPair, len(h.pairs))
+	copy(pairs, h.pairs)
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return hashKeyString(pairs[i].key) < hashKeyString(pairs[j].key)
+	})
+
+	return pairs
+}
+
+// Returns the keys of the hash in the insertion order
+func (h *HashObject) keys() []Object {
+	keys := []Object{}
+
+	for _, p := range h.pairs {
+		keys = append(keys, p.key)
 	}
-	sort.Strings(arr)
-	return arr
+
+	return keys
 }
 
-// Returns the duplicate of the Hash object
-func (h *HashObject) copy() Object {
-	elems := map[string]Object{}
+// Returns the values of the hash in the insertion order
+func (h *HashObject) values() []Object {
+	values := []Object{}
 
-	for k, v := range h.Pairs {
-		elems[k] = v
+	for _, p := range h.pairs {
+		values = append(values, p.value)
 	}
 
+	return values
+}
+
+// Returns the duplicate of the Hash object
+func (h *HashObject) copy() Object {
 	newHash := &HashObject{
 		baseObj: &baseObj{class: h.class},
-		Pairs:   elems,
+		index:   map[hashKey][]*hashPair{},
+		Default: h.Default,
+	}
+
+	for _, p := range h.pairs {
+		newHash.setPair(p.hashKey, p.key, p.value)
 	}
 
 	return newHash
@@ -1336,19 +1355,19 @@ func (h *HashObject) copy() Object {
 // recursive indexed access - see ArrayObject#dig documentation.
 func (h *HashObject) dig(t *thread, keys []Object, sourceLine int) Object {
 	currentKey := keys[0]
-	stringCurrentKey, ok := currentKey.(*StringObject)
+	nextKeys := keys[1:]
+	pair, err := h.find(t, currentKey, sourceLine)
 
-	if !ok {
-		return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.StringClass, currentKey.Class().Name)
+	if err != nil {
+		return err
 	}
 
-	nextKeys := keys[1:]
-	currentValue, ok := h.Pairs[stringCurrentKey.value]
-
-	if !ok {
+	if pair == nil {
 		return NULL
 	}
 
+	currentValue := pair.value
+
 	if len(nextKeys) == 0 {
 		return currentValue
 	}
@@ -1362,8 +1381,312 @@ func (h *HashObject) dig(t *thread, keys []Object, sourceLine int) Object {
 	return diggableCurrentValue.dig(t, nextKeys, sourceLine)
 }
 
+// find returns the pair of the given key, or nil if the hash doesn't have the key
+func (h *HashObject) find(t *thread, key Object, sourceLine int) (*hashPair, *Error) {
+	hk, err := t.hashKeyOf(key, sourceLine)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return h.findByHashKey(t, hk, key, sourceLine)
+}
+
+func (h *HashObject) findByHashKey(t *thread, hk hashKey, key Object, sourceLine int) (*hashPair, *Error) {
+	return t.findHashPair(h.index[hk], hk, key, sourceLine)
+}
+
+// findHashPair returns the pair of the given key in the bucket of pairs that share the key's hashKey
+func (t *thread) findHashPair(bucket []*hashPair, hk hashKey, key Object, sourceLine int) (*hashPair, *Error) {
+	for _, p := range bucket {
+		if hk.kind != userHashKind || p.key == key {
+			return p, nil
+		}
+
+		eql, err := t.keysEql(p.key, key, sourceLine)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if eql {
+			return p, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// get returns the value of the given key
+func (h *HashObject) get(t *thread, key Object, sourceLine int) (Object, bool, *Error) {
+	pair, err := h.find(t, key, sourceLine)
+
+	if err != nil || pair == nil {
+		return nil, false, err
+	}
+
+	return pair.value, true, nil
+}
+
+// getSymbol returns the value of the given symbol key, it's for reading the hashes that are built with `key:` in Goby
+func (h *HashObject) getSymbol(key string) (Object, bool) {
+	pairs := h.index[hashKey{kind: classes.SymbolClass, value: key}]
+
+	if len(pairs) == 0 {
+		return nil, false
+	}
+
+	return pairs[0].value, true
+}
+
+// set associates the value with the given key, an existing key keeps its original key object and position
+func (h *HashObject) set(t *thread, key, value Object, sourceLine int) *Error {
+	hk, err := t.hashKeyOf(key, sourceLine)
+
+	if err != nil {
+		return err
+	}
+
+	pair, err := h.findByHashKey(t, hk, key, sourceLine)
+
+	if err != nil {
+		return err
+	}
+
+	if pair != nil {
+		pair.value = value
+		return nil
+	}
+
+	h.setPair(hk, key, value)
+	return nil
+}
+
+// setPair appends a new pair without checking whether the key exists
+func (h *HashObject) setPair(hk hashKey, key, value Object) {
+	p := &hashPair{hashKey: hk, key: key, value: value}
+	h.pairs = append(h.pairs, p)
+	h.index[hk] = append(h.index[hk], p)
+}
+
+// delete removes the pair of the given key and returns it, or nil if the hash doesn't have the key
+func (h *HashObject) delete(t *thread, key Object, sourceLine int) (*hashPair, *Error) {
+	pair, err := h.find(t, key, sourceLine)
+
+	if err != nil || pair == nil {
+		return nil, err
+	}
+
+	h.deletePair(pair)
+	return pair, nil
+}
+
+func (h *HashObject) deletePair(pair *hashPair) {
+	h.pairs = removeHashPair(h.pairs, pair)
+
+	bucket := removeHashPair(h.index[pair.hashKey], pair)
+
+	if len(bucket) == 0 {
+		delete(h.index, pair.hashKey)
+	} else {
+		h.index[pair.hashKey] = bucket
+	}
+}
+
+// clear removes all the pairs
+func (h *HashObject) clear() {
+	h.pairs = nil
+	h.index = map[hashKey][]*hashPair{}
+}
+
+// equal returns true if both hashes have the same keys and values regardless of their order.
+// Keys are the same key like in lookups, so keys with user-defined `hash` methods are compared with `eql?`.
+func (h *HashObject) equal(t *thread, other *HashObject, sourceLine int) (bool, *Error) {
+	if h.length() != other.length() {
+		return false, nil
+	}
+
+	for _, p := range h.pairs {
+		op, err := t.findHashPair(other.index[p.hashKey], p.hashKey, p.key, sourceLine)
+
+		if err != nil || op == nil {
+			return false, err
+		}
+
+		equal, err := t.objectsEqual(p.value, op.value, sourceLine)
+
+		if err != nil || !equal {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
 // Other helper functions ----------------------------------------------
 
+// hashKeyOf returns the hashKey of the given key object, it calls the object's `hash` method if it's defined
+func (t *thread) hashKeyOf(key Object, sourceLine int) (hashKey, *Error) {
+	switch key := key.(type) {
+	case *StringObject:
+		return hashKey{kind: classes.StringClass, value: key.value}, nil
+	case *SymbolObject:
+		return hashKey{kind: classes.SymbolClass, value: key.value}, nil
+	case *IntegerObject:
+		return hashKey{kind: classes.IntegerClass, value: key.hashKeyValue()}, nil
+	case *FloatObject:
+		return hashKey{kind: classes.FloatClass, value: key.value}, nil
+	case *DecimalObject:
+		return hashKey{kind: classes.DecimalClass, value: key.value.RatString()}, nil
+	case *BooleanObject:
+		return hashKey{kind: classes.BooleanClass, value: key.value}, nil
+	case *NullObject:
+		return hashKey{kind: classes.NullClass}, nil
+	case *RangeObject:
+		return hashKey{kind: classes.RangeClass, value: [2]int{key.Start, key.End}}, nil
+	case *ArrayObject:
+		kind := classes.ArrayClass
+		elements := []string{}
+
+		for _, elem := range key.Elements {
+			hk, err := t.hashKeyOf(elem, sourceLine)
+
+			if err != nil {
+				return hashKey{}, err
+			}
+
+			// Elements with user-defined `hash` methods need `eql?`, so does the array
+			if hk.kind == userHashKind {
+				kind = userHashKind
+			}
+
+			elements = append(elements, fmt.Sprintf("%#v", hk))
+		}
+
+		return hashKey{kind: kind, value: strings.Join(elements, ",")}, nil
+	}
+
+	if _, ok := key.findMethod("hash").(*MethodObject); ok {
+		result := t.callMethod(key, "hash", sourceLine)
+
+		switch result := result.(type) {
+		case *Error:
+			return hashKey{}, result
+		case *IntegerObject:
+			return hashKey{kind: userHashKind, value: result.hashKeyValue()}, nil
+		default:
+			return hashKey{}, t.vm.initErrorObject(errors.TypeError, sourceLine, "Expect %s#hash to return Integer. got: %s", key.Class().Name, result.Class().Name)
+		}
+	}
+
+	return hashKey{kind: key.Class().Name, value: key.id()}, nil
+}
+
+// hashValue returns the result of builtin `hash` methods for the key, the keys that are the same have the same hash value
+func (hk hashKey) hashValue() int {
+	h := fnv.New64a()
+	h.Write([]byte(fmt.Sprintf("%#v", hk)))
+	return int(h.Sum64())
+}
+
+// hashMethodResult returns the Integer returned by builtin `hash` methods, or the error raised by a user-defined `hash` of an element
+func (t *thread) hashMethodResult(receiver Object, sourceLine int) Object {
+	hk, err := t.hashKeyOf(receiver, sourceLine)
+
+	if err != nil {
+		return err
+	}
+
+	return t.vm.initIntegerObject(hk.hashValue())
+}
+
+// keysEql returns true if the keys that have the same `hash` result are the same key.
+// Arrays are the same key if all their elements are.
+func (t *thread) keysEql(key, other Object, sourceLine int) (bool, *Error) {
+	if arr, ok := key.(*ArrayObject); ok {
+		otherArr, ok := other.(*ArrayObject)
+
+		if !ok || len(arr.Elements) != len(otherArr.Elements) {
+			return false, nil
+		}
+
+		for i, elem := range arr.Elements {
+			eql, err := t.keysEql(elem, otherArr.Elements[i], sourceLine)
+
+			if err != nil || !eql {
+				return false, err
+			}
+		}
+
+		return true, nil
+	}
+
+	methodName := "eql?"
+
+	if key.findMethod(methodName) == nil {
+		methodName = "=="
+	}
+
+	result := t.callMethod(key, methodName, sourceLine, other)
+
+	if err, ok := result.(*Error); ok {
+		return false, err
+	}
+
+	return result != NULL && result != FALSE, nil
+}
+
+// hashKeyString returns the name of a String or Symbol key, or the string format of other keys
+func hashKeyString(key Object) string {
+	if name, ok := symbolOrStringValue(key); ok {
+		return name
+	}
+
+	return key.toString()
+}
+
+// hashPairString formats a pair for `to_s`, a Symbol key is written like `a: 1` and other keys like `"a" => 1` or `1 => 1`
+func hashPairString(key Object, value string) string {
+	switch k := key.(type) {
+	case *SymbolObject:
+		return fmt.Sprintf("%s: %s", k.value, value)
+	case *StringObject:
+		return fmt.Sprintf("\"%s\" => %s", k.value, value)
+	default:
+		return fmt.Sprintf("%s => %s", key.toString(), value)
+	}
+}
+
+// mergeStringKeys returns the pairs keyed by the string form of their keys, and the keys in the order they first appear.
+// It's for the formats that only have string keys, like JSON and Go maps.
+// Keys with the same string form like "a" and :a are merged, and the value of the pair inserted later is kept.
+func mergeStringKeys(pairs []*hashPair) ([]string, map[string]Object) {
+	keys := []string{}
+	values := map[string]Object{}
+
+	for _, p := range pairs {
+		k := hashKeyString(p.key)
+
+		if _, ok := values[k]; !ok {
+			keys = append(keys, k)
+		}
+
+		values[k] = p.value
+	}
+
+	return keys, values
+}
+
+func removeHashPair(pairs []*hashPair, pair *hashPair) []*hashPair {
+	for i, p := range pairs {
+		if p == pair {
+			return append(pairs[:i:i], pairs[i+1:]...)
+		}
+	}
+
+	return pairs
+}
+
 // Return the JSON style strings of the Hash object
 func generateJSONFromPair(key string, v Object) string {
 	var data string
@@ -38,7 +38,7 @@ func TestEvalHashExpression(t *testing.T) {
 		t.Fatalf("Expect evaluated value to be a hash. got: %T", evaluated)
 	}
 
-	for key, value := range h.Pairs {
+	for key, value := range h.Value().(map[string]Object) {
 		switch key {
 		case "foo":
 			testIntegerObject(t, 0, value, 123)
@@ -68,53 +68,53 @@ func TestHashAccessOperation(t *testing.T) {
 			{ foo123: 100 }[:foo123]
 		`, 100},
 		{`
-			{}["foo"]
+			{}[:foo]
 		`, nil},
 		{`
 			{ bar: "foo" }[:bar]
 		`, "foo"},
 		{`
 			{ bar: "foo" }["bar"]
-		`, "foo"},
+		`, nil},
 		{`
 			{ foo: 2, bar: "foo" }[:foo]
 		`, 2},
 		{`
 			{ foo: 2, bar: "foo" }["foo"]
-		`, 2},
+		`, nil},
 		{`
 			h = { bar: "Foo" }
-			h["bar"]
+			h[:bar]
 		`, "Foo"},
 		{`
 			h = { bar: 1, foo: 2 }
-			h["foo"] = h["bar"]
-			h["foo"]
+			h[:foo] = h[:bar]
+			h[:foo]
 
 		`, 1},
 		{`
 			h = {}
-			h["foo"] = 100
-			h["foo"]
+			h[:foo] = 100
+			h[:foo]
 		`, 100},
 		{`
 			h = {}
-			h["foo"] = { bar: 100 }
-			h["foo"]["bar"]
+			h[:foo] = { bar: 100 }
+			h[:foo][:bar]
 		`, 100},
 		{`
 			h = { foo: { bar: [1, 2, 3] }}
-			h["foo"]["bar"][0] + h["foo"]["bar"][1]
+			h[:foo][:bar][0] + h[:foo][:bar][1]
 		`, 3},
 		{`
 			h = {}
-			h["foo"] = 100
-			h["bar"]
+			h[:foo] = 100
+			h[:bar]
 		`, nil},
 		{`
 			h = { foo: 1, bar: 5, baz: 10 }
-			h["foo"] = h["bar"] * h["baz"]
-			h["foo"]
+			h[:foo] = h[:bar] * h[:baz]
+			h[:foo]
 		`, 50},
 	}
 
@@ -127,6 +127,95 @@ func TestHashAccessOperation(t *testing.T) {
 	}
 }
 
+func TestHashAccessWithNonStringKeys(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`{ 1 => "a" }[1]`, "a"},
+		{`{ 1 => "a" }[1.0]`, nil},
+		{`{ 1.5 => 2 }[1.5]`, 2},
+		{`{ nil => 1 }[nil]`, 1},
+		{`{ true => 1, false => 2 }[false]`, 2},
+		{`{ [1, 2] => 3 }[[1, 2]]`, 3},
+		{`{ [1, 2] => 3 }[[2, 1]]`, nil},
+		{`{ (1..3) => 4 }[(1..3)]`, 4},
+		{`{ :a => 1 }["a"]`, nil},
+		{`{ "a" => 1 }[:a]`, nil},
+		{`{ "a" => 1, :a => 2 }.length`, 2},
+		{`
+			h = { a: 1 }
+			h[:a] = 2
+			h.keys.first.class.name
+		`, "Symbol"},
+		{`
+			h = {}
+			h[1] = "a"
+			h["1"] = "b"
+			h[1] + h["1"]
+		`, "ab"},
+		{`
+			class Foo
+			  def initialize(id)
+			    @id = id
+			  end
+			  def id
+			    @id
+			  end
+			  def hash
+			    @id
+			  end
+			  def ==(other)
+			    @id == other.id
+			  end
+			end
+
+			h = { Foo.new(1) => "a" }
+			h[Foo.new(1)] = "b"
+			h.length.to_s + h[Foo.new(1)]
+		`, "1b"},
+		{`
+			class Bar; end
+
+			b = Bar.new
+			h = { b => 1 }
+			h[Bar.new].to_s + h[b].to_s
+		`, "1"},
+		{`
+			class Foo
+			  attr_reader :id
+			  def initialize(id, name)
+			    @id = id
+			    @name = name
+			  end
+			  def hash
+			    1
+			  end
+			  def eql?(other)
+			    @id == other.id
+			  end
+			end
+
+			h = { [Foo.new(1, "a")] => "a" }
+			h[[Foo.new(2, "a")]] = "b"
+			h.length.to_s + h[[Foo.new(1, "b")]] + h[[Foo.new(2, "b")]] + h[[Foo.new(3, "a")]].to_s
+		`, "2ab"},
+		{`{ b: 1, 3 => 2, a: 3 }.keys.to_s`, `[:b, 3, :a]`},
+		{`{ b: 1, 3 => 2, a: 3 }.values.to_s`, `[1, 2, 3]`},
+		{`{ b: 1, 3 => "2" }.to_s`, `{ b: 1, 3 => "2" }`},
+		{`{ a: 1, b: 2 } == { b: 2, a: 1 }`, true},
+		{`{ 1 => 2 } == { "1" => 2 }`, false},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestHashAccessWithDefaultOperation(t *testing.T) {
 	valueTests := []struct {
 		input    string
@@ -182,8 +271,13 @@ func TestHashAccessWithDefaultOperation(t *testing.T) {
 func TestHashAccessOperationFail(t *testing.T) {
 	testsFail := []errorTestCase{
 		{`{ a: 1, b: 2 }[]`, "ArgumentError: Expect 1 argument. got: 0", 1, 1},
-		{`{ a: 1, b: 2 }[true]`, "TypeError: Expect argument to be String. got: Boolean", 1, 1},
-		{`{ a: 1, b: 2 }[true] = 1`, "TypeError: Expect argument to be String. got: Boolean", 1, 1},
+		{`
+		class Foo
+		  def hash
+		    "foo"
+		  end
+		end
+		{ a: 1, b: 2 }[Foo.new]`, "TypeError: Expect Foo#hash to return Integer. got: String", 7, 1},
 	}
 
 	for i, tt := range testsFail {
@@ -228,6 +322,25 @@ func TestHashComparisonOperation(t *testing.T) {
 		{`{ a: [1, 2, 3], b: 2 } != { a: [3, 2, 1], b: 2 }`, true}, // Hash of array has order issue
 		{`{ a: 1, b: 2 } != [1, "String", true, 2..5]`, true},
 		{`{ a: 1, b: 2 } != Integer`, true},
+		{`
+		class K
+		  attr_reader :v
+		  def initialize(v, name)
+		    @v = v
+		    @name = name
+		  end
+		  def hash
+		    @v.hash
+		  end
+		  def eql?(o)
+		    o.v == @v
+		  end
+		end
+
+		a = { K.new(1, "x") => 1 }
+		b = { K.new(1, "y") => 1 }
+		a[K.new(1, "z")].to_s + (a == b).to_s + (a == { K.new(2, "x") => 1 }).to_s
+		`, "1truefalse"},
 	}
 
 	for i, tt := range tests {
@@ -384,40 +497,40 @@ func TestHashDeleteMethod(t *testing.T) {
 		expected interface{}
 	}{
 		{`
-		h = { a: 1, b: "Hello", c: true }.delete("a")
-		h["a"]
+		h = { a: 1, b: "Hello", c: true }.delete(:a)
+		h[:a]
 		`, nil},
 		{`
-		h = { a: 1, b: "Hello", c: true }.delete("a")
-		h["b"]
+		h = { a: 1, b: "Hello", c: true }.delete(:a)
+		h[:b]
 		`, "Hello"},
 		{`
-		h = { a: 1, b: "Hello", c: true }.delete("a")
-		h["c"]
+		h = { a: 1, b: "Hello", c: true }.delete(:a)
+		h[:c]
 		`, true},
 		{`
-		h = { a: 1, b: "Hello", c: true }.delete("b")
-		h["a"]
+		h = { a: 1, b: "Hello", c: true }.delete(:b)
+		h[:a]
 		`, 1},
 		{`
-		h = { a: 1, b: "Hello", c: true }.delete("b")
-		h["b"]
+		h = { a: 1, b: "Hello", c: true }.delete(:b)
+		h[:b]
 		`, nil},
 		{`
-		h = { a: 1, b: "Hello", c: true }.delete("b")
-		h["c"]
+		h = { a: 1, b: "Hello", c: true }.delete(:b)
+		h[:c]
 		`, true},
 		{`
-		h = { a: 1, b: "Hello", c: true }.delete("c")
-		h["a"]
+		h = { a: 1, b: "Hello", c: true }.delete(:c)
+		h[:a]
 		`, 1},
 		{`
-		h = { a: 1, b: "Hello", c: true }.delete("c")
-		h["b"]
+		h = { a: 1, b: "Hello", c: true }.delete(:c)
+		h[:b]
 		`, "Hello"},
 		{`
-		h = { a: 1, b: "Hello", c: true }.delete("c")
-		h["c"]
+		h = { a: 1, b: "Hello", c: true }.delete(:c)
+		h[:c]
 		`, nil},
 	}
 
@@ -434,8 +547,6 @@ func TestHashDeleteMethodFail(t *testing.T) {
 	testsFail := []errorTestCase{
 		{`{ a: 1, b: "Hello", c: true }.delete`, "ArgumentError: Expect 1 argument. got: 0", 1, 1},
 		{`{ a: 1, b: "Hello", c: true }.delete("a", "b")`, "ArgumentError: Expect 1 argument. got: 2", 1, 1},
-		{`{ a: 1, b: "Hello", c: true }.delete(123)`, "TypeError: Expect argument to be String. got: Integer", 1, 1},
-		{`{ a: 1, b: "Hello", c: true }.delete(true)`, "TypeError: Expect argument to be String. got: Boolean", 1, 1},
 	}
 
 	for i, tt := range testsFail {
@@ -548,7 +659,7 @@ func TestHashEachMethod(t *testing.T) {
 	}{
 		// return value
 		{`
-			{ b: "2", a: 1 }.each do end
+			{ "b" => "2", "a" => 1 }.each do end
 		`, map[string]interface{}{"a": 1, "b": "2"}},
 		// empty hash
 		{`
@@ -571,12 +682,12 @@ func TestHashEachMethod(t *testing.T) {
 		// block yielding
 		{`
 			output = []
-			h = { b: "2", a: 1 }
+			h = { "b" => "2", "a" => 1 }
 			h.each do |k, v|
 				output.push([k, v])
 			end
 			output
-		`, [][]interface{}{{"a", 1}, {"b", "2"}}},
+		`, [][]interface{}{{"b", "2"}, {"a", 1}}},
 	}
 
 	for i, tt := range tests2 {
@@ -610,23 +721,23 @@ func TestHashEachKeyMethod(t *testing.T) {
 		expected []interface{}
 	}{
 		{`
-			{ a: "Hello", b: "World", c: "Goby" }.each_key do |key|
+			{ "a" => "Hello", "b" => "World", "c" => "Goby" }.each_key do |key|
 				# Empty Block
 			end
 		`, []interface{}{"a", "b", "c"}},
 		{`
-			{ b: "Hello", c: "World", a: "Goby" }.each_key do |key|
+			{ "b" => "Hello", "c" => "World", "a" => "Goby" }.each_key do |key|
 				# Empty Block
 			end
-		`, []interface{}{"a", "b", "c"}},
+		`, []interface{}{"b", "c", "a"}},
 		{`
-			{ b: "Hello", c: "World", b: "Goby" }.each_key do |key|
+			{ "b" => "Hello", "c" => "World", "b" => "Goby" }.each_key do |key|
 				# Empty Block
 			end
 		`, []interface{}{"b", "c"}},
 		{`
 			arr = []
-			{ a: "Hello", b: "World", c: "Goby" }.each_key do |key|
+			{ "a" => "Hello", "b" => "World", "c" => "Goby" }.each_key do |key|
 				arr.push(key)
 			end
 			arr
@@ -681,7 +792,7 @@ func TestHashEachValueMethod(t *testing.T) {
 			{ b: "Hello", c: 123, a: true }.each_value do |v|
 				# Empty Block
 			end
-		`, []interface{}{true, "Hello", 123}},
+		`, []interface{}{"Hello", 123, true}},
 		{`
 			{ a: "Hello", b: 123, a: true }.each_value do |v|
 				# Empty Block
@@ -845,7 +956,7 @@ func TestHashFetchMethod(t *testing.T) {
 		expected string
 	}{
 		{`
-			{ spaghetti: "eat" }.fetch("spaghetti")
+			{ spaghetti: "eat" }.fetch(:spaghetti)
 		`, "eat"},
 		{`
 			{ spaghetti: "eat" }.fetch("pizza", "not eat")
@@ -887,10 +998,10 @@ func TestHashFetchValuesMethod(t *testing.T) {
 		expected []interface{}
 	}{
 		{`
-      { cat: "feline", dog: "canine", cow: "bovine" }.fetch_values("cow", "cat")
+      { cat: "feline", dog: "canine", cow: "bovine" }.fetch_values(:cow, :cat)
 		`, []interface{}{"bovine", "feline"}},
 		{`
-      { cat: "feline", dog: "canine", cow: "bovine" }.fetch_values("cow", "bird") do |k| k.upcase end
+      { cat: "feline", dog: "canine", cow: "bovine" }.fetch_values(:cow, "bird") do |k| k.upcase end
 		`, []interface{}{"bovine", "BIRD"}},
 	}
 
@@ -906,7 +1017,7 @@ func TestHashFetchValuesMethod(t *testing.T) {
 func TestHashFetchValuesMethodFail(t *testing.T) {
 	testsFail := []errorTestCase{
 		{`{ cat: "feline" }.fetch_values()`, "ArgumentError: Expected 1+ arguments, got 0", 1, 1},
-		{`{ cat: "feline" }.fetch_values(1)`, "TypeError: Expect argument to be String. got: Integer", 1, 1},
+		{`{ cat: "feline" }.fetch_values(1)`, "ArgumentError: There is no value for the key `1`, and no block has been provided", 1, 1},
 		{`{ cat: "feline" }.fetch_values("dog")`, "ArgumentError: There is no value for the key `dog`, and no block has been provided", 1, 1},
 	}
 
@@ -924,7 +1035,7 @@ func TestHashHasKeyMethod(t *testing.T) {
 		input    string
 		expected interface{}
 	}{
-		{`{ a: "Hello", b: 123, c: true }.has_key?("a")`, true},
+		{`{ a: "Hello", b: 123, c: true }.has_key?(:a)`, true},
 		{`{ a: "Hello", b: 123, c: true }.has_key?("d")`, false},
 	}
 
@@ -941,8 +1052,6 @@ func TestHashHasKeyMethodFail(t *testing.T) {
 	testsFail := []errorTestCase{
 		{`{ a: 1, b: 2 }.has_key?`, "ArgumentError: Expect 1 argument. got: 0", 1, 1},
 		{`{ a: 1, b: 2 }.has_key?(true, { hello: "World" })`, "ArgumentError: Expect 1 argument. got: 2", 1, 1},
-		{`{ a: 1, b: 2 }.has_key?(true)`, "TypeError: Expect argument to be String. got: Boolean", 1, 1},
-		{`{ a: 1, b: 2 }.has_key?(123)`, "TypeError: Expect argument to be String. got: Integer", 1, 1},
 	}
 
 	for i, tt := range testsFail {
@@ -1009,7 +1118,7 @@ func TestHashKeysMethod(t *testing.T) {
 
 	var evaluatedArr []string
 	for _, k := range arr.Elements {
-		evaluatedArr = append(evaluatedArr, k.(*StringObject).value)
+		evaluatedArr = append(evaluatedArr, k.(*SymbolObject).value)
 	}
 	sort.Strings(evaluatedArr)
 	if !reflect.DeepEqual(evaluatedArr, []string{"bar", "baz", "foo"}) {
@@ -1082,49 +1191,49 @@ func TestHashMapValuesMethod(t *testing.T) {
 		result = h.map_values do |v|
 			v * 3
 		end
-		h["a"]
+		h[:a]
 		`, 3},
 		{`
 		h = { a: 1, b: 2, c: 3 }
 		result = h.map_values do |v|
 			v * 3
 		end
-		h["b"]
+		h[:b]
 		`, 6},
 		{`
 		h = { a: 1, b: 2, c: 3 }
 		result = h.map_values do |v|
 			v * 3
 		end
-		h["c"]
+		h[:c]
 		`, 9},
 		{`
 		h = { a: 1, b: 2, c: 3 }
 		result = h.map_values do |v|
 			v * 3
 		end
-		result["a"]
+		result[:a]
 		`, 3},
 		{`
 		h = { a: 1, b: 2, c: 3 }
 		result = h.map_values do |v|
 			v * 3
 		end
-		result["b"]
+		result[:b]
 		`, 6},
 		{`
 		h = { a: 1, b: 2, c: 3 }
 		result = h.map_values do |v|
 			v * 3
 		end
-		result["c"]
+		result[:c]
 		`, 9},
 		{`
 		h = {}
 		result = h.map_values do |v|
 			v * 3
 		end
-		result["c"]
+		result[:c]
 		`, nil},
 	}
 
@@ -1170,7 +1279,7 @@ func TestHashMergeMethod(t *testing.T) {
 			t.Fatalf("Expect evaluated value to be a hash. got: %T", evaluated)
 		}
 
-		for key, value := range h.Pairs {
+		for key, value := range h.Value().(map[string]Object) {
 			switch key {
 			case "a":
 				testStringObject(t, i, value, "Hello")
@@ -1209,11 +1318,11 @@ func TestHashSortedKeysMethod(t *testing.T) {
 		input    string
 		expected []interface{}
 	}{
-		{`{ a: 1, b: 2, c: 3 }.sorted_keys`, []interface{}{"a", "b", "c"}},
-		{`{ c: 1, b: 2, a: 3 }.sorted_keys`, []interface{}{"a", "b", "c"}},
-		{`{ b: 1, a: 2, c: 3 }.sorted_keys`, []interface{}{"a", "b", "c"}},
-		{`{ b: 1, a: 2, b: 3 }.sorted_keys`, []interface{}{"a", "b"}},
-		{`{ c: 1, a: 2, a: 3 }.sorted_keys`, []interface{}{"a", "c"}},
+		{`{ "a" => 1, "b" => 2, "c" => 3 }.sorted_keys`, []interface{}{"a", "b", "c"}},
+		{`{ "c" => 1, "b" => 2, "a" => 3 }.sorted_keys`, []interface{}{"a", "b", "c"}},
+		{`{ "b" => 1, "a" => 2, "c" => 3 }.sorted_keys`, []interface{}{"a", "b", "c"}},
+		{`{ "b" => 1, "a" => 2, "b" => 3 }.sorted_keys`, []interface{}{"a", "b"}},
+		{`{ "c" => 1, "a" => 2, "a" => 3 }.sorted_keys`, []interface{}{"a", "c"}},
 	}
 
 	for i, tt := range tests {
@@ -1305,12 +1414,12 @@ func TestHashToArrayMethod(t *testing.T) {
 		input    string
 		expected []interface{}
 	}{
-		{`{ a: 1, b: 2, c: 3 }.to_a(true)[0]`, []interface{}{"a", 1}},
-		{`{ a: 1, b: 2, c: 3 }.to_a(true)[1]`, []interface{}{"b", 2}},
-		{`{ a: 1, b: 2, c: 3 }.to_a(true)[2]`, []interface{}{"c", 3}},
-		{`{ b: 1, c: 2, a: 3 }.to_a(true)[0]`, []interface{}{"a", 3}},
-		{`{ b: 1, c: 2, a: 3 }.to_a(true)[1]`, []interface{}{"b", 1}},
-		{`{ b: 1, c: 2, a: 3 }.to_a(true)[2]`, []interface{}{"c", 2}},
+		{`{ "a" => 1, "b" => 2, "c" => 3 }.to_a(true)[0]`, []interface{}{"a", 1}},
+		{`{ "a" => 1, "b" => 2, "c" => 3 }.to_a(true)[1]`, []interface{}{"b", 2}},
+		{`{ "a" => 1, "b" => 2, "c" => 3 }.to_a(true)[2]`, []interface{}{"c", 3}},
+		{`{ "b" => 1, "c" => 2, "a" => 3 }.to_a(true)[0]`, []interface{}{"a", 3}},
+		{`{ "b" => 1, "c" => 2, "a" => 3 }.to_a(true)[1]`, []interface{}{"b", 1}},
+		{`{ "b" => 1, "c" => 2, "a" => 3 }.to_a(true)[2]`, []interface{}{"c", 2}},
 	}
 
 	for i, tt := range testsSortedArray {
@@ -1322,7 +1431,7 @@ func TestHashToArrayMethod(t *testing.T) {
 	}
 
 	input := `
-	{ a: 123, b: "test", c: true, d: [1, "Goby", false] }.to_a
+	{ "a" => 123, "b" => "test", "c" => true, "d" => [1, "Goby", false] }.to_a
 	`
 
 	v := initTestVM()
@@ -1522,6 +1631,26 @@ func TestHashToJSONMethodWithBasicTypes(t *testing.T) {
 	}
 }
 
+func TestHashToJSONMethodWithSameKeyNames(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`{ "a" => 1, b: 2 }.to_json`, `{"a":1,"b":2}`},
+		{`{ "a" => 1, b: 2, a: 3 }.to_json`, `{"a":3,"b":2}`},
+		{`{ a: 1, "a" => 2 }.to_json`, `{"a":2}`},
+		{`{ 1 => 1, "1" => 2 }.to_json`, `{"1":2}`},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestHashToJSONMethodFail(t *testing.T) {
 	testsFail := []errorTestCase{
 		{`{ a: 1, b: 2 }.to_json(123)`, "ArgumentError: Expect 0 argument. got: 1", 1, 1},
@@ -1545,6 +1674,8 @@ func TestHashToStringMethod(t *testing.T) {
 		{`{ a: 1 }.to_s`, "{ a: 1 }"},
 		{`{ a: 1, b: "Hello" }.to_s`, "{ a: 1, b: \"Hello\" }"},
 		{`{ a: 1, b: [1, true, "Hello", 1..2], c: { lang: "Goby" } }.to_s`, "{ a: 1, b: [1, true, \"Hello\", (1..2)], c: { lang: \"Goby\" } }"},
+		{`{ "a" => 1, a: 2 }.to_s`, "{ \"a\" => 1, a: 2 }"},
+		{`{ 1 => "a", [1] => nil }.to_s`, "{ 1 => \"a\", [1] => nil }"},
 	}
 
 	for i, tt := range tests {
@@ -1581,49 +1712,49 @@ func TestHashTransformValuesMethod(t *testing.T) {
 		result = h.transform_values do |v|
 			v * 3
 		end
-		h["a"]
+		h[:a]
 		`, 1},
 		{`
 		h = { a: 1, b: 2, c: 3 }
 		result = h.transform_values do |v|
 			v * 3
 		end
-		h["b"]
+		h[:b]
 		`, 2},
 		{`
 		h = { a: 1, b: 2, c: 3 }
 		result = h.transform_values do |v|
 			v * 3
 		end
-		h["c"]
+		h[:c]
 		`, 3},
 		{`
 		h = { a: 1, b: 2, c: 3 }
 		result = h.transform_values do |v|
 			v * 3
 		end
-		result["a"]
+		result[:a]
 		`, 3},
 		{`
 		h = { a: 1, b: 2, c: 3 }
 		result = h.transform_values do |v|
 			v * 3
 		end
-		result["b"]
+		result[:b]
 		`, 6},
 		{`
 		h = { a: 1, b: 2, c: 3 }
 		result = h.transform_values do |v|
 			v * 3
 		end
-		result["c"]
+		result[:c]
 		`, 9},
 		{`
 		h = {}
 		result = h.transform_values do |v|
 			v * 3
 		end
-		result["c"]
+		result[:c]
 		`, nil},
 	}
 
@@ -1706,13 +1837,13 @@ func TestHashValuesAtMethod(t *testing.T) {
 		expected []interface{}
 	}{
 		{`
-		{ a: 1, b: "2" }.values_at("a", "c")
+		{ a: 1, b: "2" }.values_at(:a, "c")
 		`, []interface{}{1, nil}},
 		{`
 		{ a: 1, b: "2" }.values_at()
 		`, []interface{}{}},
 		{`
-		{}.values_at("a")
+		{}.values_at(:a)
 		`, []interface{}{nil}},
 	}
 
@@ -1727,7 +1858,13 @@ func TestHashValuesAtMethod(t *testing.T) {
 
 func TestHashValuesAtMethodFail(t *testing.T) {
 	testsFail := []errorTestCase{
-		{`{ a: 1, b: 2 }.values_at(123)`, "TypeError: Expect argument to be String. got: Integer", 1, 1},
+		{`
+		class Foo
+		  def hash
+		    nil
+		  end
+		end
+		{ a: 1, b: 2 }.values_at(Foo.new)`, "TypeError: Expect Foo#hash to return Integer. got: Null", 7, 1},
 	}
 
 	for i, tt := range testsFail {
@@ -58,7 +58,7 @@ func builtinHTTPClassMethods() []*BuiltinMethodObject {
 					resp.Body.Close()
 
 					if err != nil {
-						return t.vm.initErrorObject(errors.InternalError, sourceLine, err.Error())
+						return t.vm.initErrorObject(errors.InternalError, sourceLine, "%s", err.Error())
 					}
 
 					return t.vm.initStringObject(string(content))
@@ -103,7 +103,7 @@ func builtinHTTPClassMethods() []*BuiltinMethodObject {
 					resp.Body.Close()
 
 					if err != nil {
-						return t.vm.initErrorObject(errors.InternalError, sourceLine, err.Error())
+						return t.vm.initErrorObject(errors.InternalError, sourceLine, "%s", err.Error())
 					}
 
 					return t.vm.initStringObject(string(content))
@@ -143,13 +143,13 @@ func builtinHTTPClassMethods() []*BuiltinMethodObject {
 						return t.vm.initErrorObject(errors.HTTPError, sourceLine, "Non-200 response, %s (%d)", resp.Status, resp.StatusCode)
 					}
 
-					ret := t.vm.initHashObject(map[string]Object{})
+					headers := map[string]Object{}
 
 					for k, v := range resp.Header {
-						ret.Pairs[k] = t.vm.initStringObject(strings.Join(v, " "))
+						headers[k] = t.vm.initStringObject(strings.Join(v, " "))
 					}
 
-					return ret
+					return t.vm.initHashObject(headers)
 				}
 			},
 		}, {
@@ -38,7 +38,7 @@ func builtinHTTPClientInstanceMethods() []*BuiltinMethodObject {
 
 					gobyResp, err := responseGoToGoby(t, resp)
 					if err != nil {
-						return t.vm.initErrorObject(errors.InternalError, sourceLine, err.Error())
+						return t.vm.initErrorObject(errors.InternalError, sourceLine, "%s", err.Error())
 					}
 
 					return gobyResp
@@ -77,7 +77,7 @@ func builtinHTTPClientInstanceMethods() []*BuiltinMethodObject {
 
 					gobyResp, err := responseGoToGoby(t, resp)
 					if err != nil {
-						return t.vm.initErrorObject(errors.InternalError, sourceLine, err.Error())
+						return t.vm.initErrorObject(errors.InternalError, sourceLine, "%s", err.Error())
 					}
 
 					return gobyResp
@@ -104,7 +104,7 @@ func builtinHTTPClientInstanceMethods() []*BuiltinMethodObject {
 
 					gobyResp, err := responseGoToGoby(t, resp)
 					if err != nil {
-						return t.vm.initErrorObject(errors.InternalError, sourceLine, err.Error())
+						return t.vm.initErrorObject(errors.InternalError, sourceLine, "%s", err.Error())
 					}
 
 					return gobyResp
@@ -133,7 +133,7 @@ func builtinHTTPClientInstanceMethods() []*BuiltinMethodObject {
 
 					goReq, err := requestGobyToGo(args[0])
 					if err != nil {
-						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, err.Error())
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "%s", err.Error())
 					}
 
 					goResp, err := goClient.Do(goReq)
@@ -144,7 +144,7 @@ func builtinHTTPClientInstanceMethods() []*BuiltinMethodObject {
 					gobyResp, err := responseGoToGoby(t, goResp)
 
 					if err != nil {
-						return t.vm.initErrorObject(errors.InternalError, sourceLine, err.Error())
+						return t.vm.initErrorObject(errors.InternalError, sourceLine, "%s", err.Error())
 					}
 
 					return gobyResp
@@ -1,10 +1,11 @@
 package vm
 
 import (
-	"fmt"
+	"github.com/dlclark/regexp2"
 	"github.com/goby-lang/goby/compiler/bytecode"
 	"github.com/goby-lang/goby/vm/classes"
 	"github.com/goby-lang/goby/vm/errors"
+	"math/big"
 	"strings"
 )
 
@@ -32,6 +33,7 @@ type instructionSet struct {
 	instructions []*instruction
 	filename     filename
 	paramTypes   *bytecode.ArgSet
+	kind         setType
 }
 
 func (is *instructionSet) define(line int, a *action, params ...interface{}) *instruction {
@@ -142,6 +144,55 @@ var builtinActions = map[operationType]*action{
 			t.stack.push(&Pointer{Target: obj})
 		},
 	},
+	bytecode.GetClassVariable: {
+		name: bytecode.GetClassVariable,
+		operation: func(t *thread, sourceLine int, cf *normalCallFrame, args ...interface{}) {
+			variableName := args[0].(string)
+			class := classVariableScope(cf.self)
+			v, ok := class.getClassVariable(variableName)
+
+			if !ok {
+				t.pushErrorObject(errors.NameError, sourceLine, "uninitialized class variable %s in %s", variableName, class.Name)
+				return
+			}
+
+			t.stack.push(&Pointer{Target: v})
+		},
+	},
+	bytecode.SetClassVariable: {
+		name: bytecode.SetClassVariable,
+		operation: func(t *thread, sourceLine int, cf *normalCallFrame, args ...interface{}) {
+			variableName := args[0].(string)
+			p := t.stack.pop()
+			classVariableScope(cf.self).setClassVariable(variableName, p.Target)
+
+			t.stack.push(&Pointer{Target: p.Target})
+		},
+	},
+	bytecode.GetGlobalVariable: {
+		name: bytecode.GetGlobalVariable,
+		operation: func(t *thread, sourceLine int, cf *normalCallFrame, args ...interface{}) {
+			variableName := args[0].(string)
+			v, ok := t.vm.getGlobalVariable(variableName)
+
+			if !ok {
+				t.stack.push(&Pointer{Target: NULL})
+				return
+			}
+
+			t.stack.push(&Pointer{Target: v})
+		},
+	},
+	bytecode.SetGlobalVariable: {
+		name: bytecode.SetGlobalVariable,
+		operation: func(t *thread, sourceLine int, cf *normalCallFrame, args ...interface{}) {
+			variableName := args[0].(string)
+			p := t.stack.pop()
+			t.vm.setGlobalVariable(variableName, p.Target)
+
+			t.stack.push(&Pointer{Target: p.Target})
+		},
+	},
 	bytecode.SetLocal: {
 		name: bytecode.SetLocal,
 		operation: func(t *thread, sourceLine int, cf *normalCallFrame, args ...interface{}) {
@@ -231,12 +282,48 @@ var builtinActions = map[operationType]*action{
 				return
 			}
 
+			elements := arr.Elements
+
+			// With the splat position, like the `*b` of `[a, *b, c]`, the elements in the middle are collected into an array there
+			if len(args) > 1 {
+				splatIndex := args[1].(int)
+				restLength := len(elements) - arrLength + 1
+
+				if restLength < 0 {
+					restLength = 0
+				}
+
+				rest := []Object{}
+				elements = []Object{}
+
+				for i, elem := range arr.Elements {
+					if i >= splatIndex && i < splatIndex+restLength {
+						rest = append(rest, elem)
+						continue
+					}
+
+					if i == splatIndex+restLength {
+						elements = append(elements, t.vm.initArrayObject(rest))
+					}
+
+					elements = append(elements, elem)
+				}
+
+				if len(elements) <= splatIndex {
+					for len(elements) < splatIndex {
+						elements = append(elements, NULL)
+					}
+
+					elements = append(elements, t.vm.initArrayObject(rest))
+				}
+			}
+
 			elems := []Object{}
 
 			for i := 0; i < arrLength; i++ {
 				var elem Object
-				if i < len(arr.Elements) {
-					elem = arr.Elements[i]
+				if i < len(elements) {
+					elem = elements[i]
 				} else {
 					elem = NULL
 				}
@@ -266,15 +353,19 @@ var builtinActions = map[operationType]*action{
 		name: bytecode.NewHash,
 		operation: func(t *thread, sourceLine int, cf *normalCallFrame, args ...interface{}) {
 			argCount := args[0].(int)
-			pairs := map[string]Object{}
-
-			for i := 0; i < argCount/2; i++ {
-				v := t.stack.pop()
-				k := t.stack.pop()
-				pairs[k.Target.(*StringObject).value] = v.Target
+			pairsPtr := t.sp - argCount
+			hash := t.vm.initEmptyHashObject()
+
+			// Pairs are set in the order they're written, so a duplicated key keeps its first position and its last value
+			for i := pairsPtr; i < t.sp; i += 2 {
+				if err := hash.set(t, t.stack.Data[i].Target, t.stack.Data[i+1].Target, sourceLine); err != nil {
+					t.sp = pairsPtr
+					t.stack.push(&Pointer{Target: err})
+					return
+				}
 			}
 
-			hash := t.vm.initHashObject(pairs)
+			t.sp = pairsPtr
 			t.stack.push(&Pointer{Target: hash})
 		},
 	},
@@ -324,18 +415,123 @@ var builtinActions = map[operationType]*action{
 			return
 		},
 	},
+	bytecode.BranchNil: {
+		name: bytecode.BranchNil,
+		operation: func(t *thread, sourceLine int, cf *normalCallFrame, args ...interface{}) {
+			v := t.stack.pop()
+
+			if _, isNull := v.Target.(*NullObject); isNull {
+				cf.pc = args[0].(int)
+			}
+		},
+	},
 	bytecode.Jump: {
 		name: bytecode.Jump,
 		operation: func(t *thread, sourceLine int, cf *normalCallFrame, args ...interface{}) {
 			cf.pc = args[0].(int)
 		},
 	},
+	bytecode.SetRescue: {
+		name: bytecode.SetRescue,
+		operation: func(t *thread, sourceLine int, cf *normalCallFrame, args ...interface{}) {
+			h := &rescueHandler{pc: args[0].(int), sp: t.sp, cfp: t.cfp}
+			cf.rescueHandlers = append(cf.rescueHandlers, h)
+		},
+	},
+	bytecode.SetEnsure: {
+		name: bytecode.SetEnsure,
+		operation: func(t *thread, sourceLine int, cf *normalCallFrame, args ...interface{}) {
+			h := &rescueHandler{pc: args[0].(int), sp: t.sp, cfp: t.cfp, ensure: true}
+			cf.rescueHandlers = append(cf.rescueHandlers, h)
+		},
+	},
+	bytecode.PopRescue: {
+		name: bytecode.PopRescue,
+		operation: func(t *thread, sourceLine int, cf *normalCallFrame, args ...interface{}) {
+			cf.rescueHandlers = cf.rescueHandlers[:len(cf.rescueHandlers)-1]
+		},
+	},
+	bytecode.MatchRescue: {
+		name: bytecode.MatchRescue,
+		operation: func(t *thread, sourceLine int, cf *normalCallFrame, args ...interface{}) {
+			argCount := args[0].(int)
+			errClasses := []*RClass{}
+
+			for i := 0; i < argCount; i++ {
+				v := t.stack.pop().Target
+				c, ok := v.(*RClass)
+
+				if !ok {
+					t.pushErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, "a class", v.Class().Name)
+					return
+				}
+
+				errClasses = append(errClasses, c)
+			}
+
+			err := t.stack.top().Target.(*Error)
+
+			if err.baseObj == nil {
+				t.stack.push(&Pointer{Target: FALSE})
+				return
+			}
+
+			for _, ancestor := range err.Class().ancestors() {
+				for _, c := range errClasses {
+					if ancestor == c {
+						t.stack.push(&Pointer{Target: TRUE})
+						return
+					}
+				}
+			}
+
+			t.stack.push(&Pointer{Target: FALSE})
+		},
+	},
+	bytecode.Throw: {
+		name: bytecode.Throw,
+		operation: func(t *thread, sourceLine int, cf *normalCallFrame, args ...interface{}) {
+			if err, ok := t.stack.top().Target.(*Error); ok {
+				err.raised = true
+				return
+			}
+
+			// The ensure body was run by a `return` in a block, so it keeps returning
+			h := cf.rescueHandlers[len(cf.rescueHandlers)-1]
+			cf.rescueHandlers = cf.rescueHandlers[:len(cf.rescueHandlers)-1]
+			t.stack.pop()
+			panic(h.blockReturn)
+		},
+	},
 	bytecode.PutSelf: {
 		name: bytecode.PutSelf,
 		operation: func(t *thread, sourceLine int, cf *normalCallFrame, args ...interface{}) {
 			t.stack.push(&Pointer{Target: cf.self})
 		},
 	},
+	bytecode.PutSymbol: {
+		name: bytecode.PutSymbol,
+		operation: func(t *thread, sourceLine int, cf *normalCallFrame, args ...interface{}) {
+			t.stack.push(&Pointer{Target: t.vm.initSymbolObject(args[0].(string))})
+		},
+	},
+	bytecode.PutRegexp: {
+		name: bytecode.PutRegexp,
+		operation: func(t *thread, sourceLine int, cf *normalCallFrame, args ...interface{}) {
+			if err, ok := args[3].(error); ok {
+				t.pushErrorObject(errors.ArgumentError, sourceLine, "Invalid regexp: /%s/. %s", args[0], err.Error())
+				return
+			}
+
+			t.stack.push(&Pointer{Target: t.vm.initCompiledRegexpObject(args[2].(*regexp2.Regexp), args[1].(string))})
+		},
+	},
+	bytecode.PutFloat: {
+		name: bytecode.PutFloat,
+		operation: func(t *thread, sourceLine int, cf *normalCallFrame, args ...interface{}) {
+			t.stack.push(&Pointer{Target: t.vm.initFloatObject(args[0].(float64))})
+		},
+	},
 	bytecode.PutString: {
 		name: bytecode.PutString,
 		operation: func(t *thread, sourceLine int, cf *normalCallFrame, args ...interface{}) {
@@ -366,6 +562,7 @@ var builtinActions = map[operationType]*action{
 			v := t.stack.pop().Target
 			switch self := v.(type) {
 			case *RClass:
+				method.visibility = self.defaultVisibility
 				self.Methods.set(methodName, method)
 			default:
 				self.Class().Methods.set(methodName, method)
@@ -381,16 +578,7 @@ var builtinActions = map[operationType]*action{
 			method := &MethodObject{Name: methodName, argc: argCount, instructionSet: is, baseObj: &baseObj{class: t.vm.topLevelClass(classes.MethodClass)}}
 
 			v := t.stack.pop().Target
-
-			switch v := v.(type) {
-			case *RClass:
-				v.SingletonClass().Methods.set(methodName, method)
-			default:
-				singletonClass := t.vm.createRClass(fmt.Sprintf("#<Class:#<%s:%s>>", v.Class().Name, v.id()))
-				singletonClass.Methods.set(methodName, method)
-				singletonClass.isSingleton = true
-				v.SetSingletonClass(singletonClass)
-			}
+			t.vm.singletonClassOf(v).Methods.set(methodName, method)
 		},
 	},
 	bytecode.DefClass: {
@@ -429,12 +617,41 @@ var builtinActions = map[operationType]*action{
 			t.stack.pop()
 			c := newNormalCallFrame(is, cf.FileName())
 			c.self = classPtr.Target
+
+			// Methods are public by default every time the class body is opened
+			class, isClass := classPtr.Target.(*RClass)
+
+			if isClass {
+				class.defaultVisibility = publicMethod
+			}
+
 			t.callFrameStack.push(c)
 			t.startFromTopFrame()
 
+			if isClass {
+				class.defaultVisibility = publicMethod
+			}
+
 			t.stack.push(classPtr)
 		},
 	},
+	bytecode.DefSingletonClass: {
+		name: bytecode.DefSingletonClass,
+		operation: func(t *thread, sourceLine int, cf *normalCallFrame, args ...interface{}) {
+			class := t.vm.singletonClassOf(t.stack.pop().Target)
+			is := t.getClassIS(bytecode.SingletonClassBody, cf.FileName())
+
+			c := newNormalCallFrame(is, cf.FileName())
+			c.self = class
+			class.defaultVisibility = publicMethod
+
+			t.callFrameStack.push(c)
+			t.startFromTopFrame()
+
+			class.defaultVisibility = publicMethod
+			t.stack.push(&Pointer{Target: class})
+		},
+	},
 	bytecode.Send: {
 		name: bytecode.Send,
 		operation: func(t *thread, sourceLine int, cf *normalCallFrame, args ...interface{}) {
@@ -445,16 +662,9 @@ var builtinActions = map[operationType]*action{
 			blockFlag := args[2].(string)
 			argSet := args[3].(*bytecode.ArgSet)
 
-			// Deal with splat arguments
-			if arr, ok := t.stack.top().Target.(*ArrayObject); ok && arr.splat {
-				// Pop array
-				t.stack.pop()
-				// Can't count array itself, only the number of array elements
-				argCount = argCount - 1 + len(arr.Elements)
-				for _, elem := range arr.Elements {
-					t.stack.push(&Pointer{Target: elem})
-				}
-			}
+			// Deal with block argument like `foo(&blk)`, which is pushed after other arguments
+			blockPass := t.popBlockPass(blockFlag)
+			argCount = t.expandSplatArgument(argCount)
 
 			argPr := t.sp - argCount
 			receiverPr := argPr - 1
@@ -463,31 +673,99 @@ var builtinActions = map[operationType]*action{
 			// Find Method
 			method = receiver.findMethod(methodName)
 
+			// Every object responds to `method_missing`, which raises an UndefinedMethodError by default
 			if method == nil {
-				err := t.vm.initErrorObject(errors.UndefinedMethodError, sourceLine, "Undefined Method '%+v' for %+v", methodName, receiver.toString())
+				method = receiver.findMethod(methodMissing)
+				argCount = t.setMethodMissingArguments(methodName, argPr, argCount, argSet, sourceLine)
+				argSet = &bytecode.ArgSet{}
+			} else if err := t.checkMethodVisibility(receiver, cf.self, method, methodName, sourceLine); err != nil {
 				t.stack.set(receiverPr, &Pointer{Target: err})
 				t.sp = argPr
 				return
 			}
 
 			// Find Block
-			blockFrame := t.retrieveBlock(cf.FileName(), blockFlag)
+			blockFrame, err := t.findBlockFrame(cf, blockFlag, blockPass, sourceLine)
+
+			if err != nil {
+				t.stack.set(receiverPr, &Pointer{Target: err})
+				t.sp = argPr
+				return
+			}
 
 			if blockFrame != nil {
-				blockFrame.ep = cf
-				blockFrame.self = cf.self
 				t.callFrameStack.push(blockFrame)
 			}
 
-			switch m := method.(type) {
-			case *MethodObject:
-				callObj := newCallObject(receiver, m, receiverPr, argCount, argSet, blockFrame, sourceLine)
-				t.evalMethodObject(callObj, sourceLine)
-			case *BuiltinMethodObject:
-				t.evalBuiltinMethod(receiver, m, receiverPr, argCount, argSet, blockFrame, sourceLine, cf.fileName)
-			case *Error:
-				t.pushErrorObject(errors.InternalError, sourceLine, m.toString())
+			t.evalMethod(receiver, method, receiverPr, argCount, argSet, blockFrame, sourceLine, cf.fileName)
+		},
+	},
+	bytecode.InvokeSuper: {
+		name: bytecode.InvokeSuper,
+		operation: func(t *thread, sourceLine int, cf *normalCallFrame, args ...interface{}) {
+			argCount := args[0].(int)
+			blockFlag := args[1].(string)
+			argSet := args[2].(*bytecode.ArgSet)
+
+			blockPass := t.popBlockPass(blockFlag)
+			argCount = t.expandSplatArgument(argCount)
+
+			argPr := t.sp - argCount
+			receiverPr := argPr - 1
+
+			// `super` can be called in a block, so we need to find the method's frame first
+			methodFrame := cf
+
+			for methodFrame.ep != nil {
+				methodFrame = methodFrame.ep
+			}
+
+			if methodFrame.instructionSet.kind != bytecode.MethodDef {
+				err := t.vm.initErrorObject(errors.InternalError, sourceLine, "Can't call super outside of a method")
+				t.stack.set(receiverPr, &Pointer{Target: err})
+				t.sp = argPr
+				return
 			}
+
+			receiver := methodFrame.self
+			methodName := methodFrame.instructionSet.name
+			t.stack.set(receiverPr, &Pointer{Target: receiver})
+
+			method := superMethodOf(receiver, methodFrame.instructionSet)
+
+			if method == nil {
+				// Every object can call `super` in its `initialize` method
+				if methodName == "initialize" {
+					t.stack.set(receiverPr, &Pointer{Target: NULL})
+					t.sp = argPr
+					return
+				}
+
+				err := t.vm.initErrorObject(errors.UndefinedMethodError, sourceLine, "Undefined super method '%s' for %s", methodName, receiver.toString())
+				t.stack.set(receiverPr, &Pointer{Target: err})
+				t.sp = argPr
+				return
+			}
+
+			// Without any block given, `super` passes the current method's block
+			blockFrame := methodFrame.blockFrame
+
+			if blockFlag != "" {
+				var err *Error
+				blockFrame, err = t.findBlockFrame(cf, blockFlag, blockPass, sourceLine)
+
+				if err != nil {
+					t.stack.set(receiverPr, &Pointer{Target: err})
+					t.sp = argPr
+					return
+				}
+			}
+
+			if blockFrame != nil {
+				t.callFrameStack.push(blockFrame)
+			}
+
+			t.evalMethod(receiver, method, receiverPr, argCount, argSet, blockFrame, sourceLine, cf.fileName)
 		},
 	},
 	bytecode.InvokeBlock: {
@@ -527,8 +805,13 @@ var builtinActions = map[operationType]*action{
 				In this case the target frame is not first block frame we meet. It should be `bar`'s block.
 				And bar's frame is foo block frame's ep, so our target frame is ep's block frame.
 			*/
-			if cf.blockFrame.ep == cf.ep {
+			if cf.ep != nil && cf.blockFrame.ep == cf.ep {
 				blockFrame = cf.blockFrame.ep.blockFrame
+
+				if blockFrame == nil {
+					t.pushErrorObject(errors.InternalError, sourceLine, "Can't yield without a block")
+					return
+				}
 			}
 
 			c := newNormalCallFrame(blockFrame.instructionSet, blockFrame.FileName())
@@ -536,8 +819,14 @@ var builtinActions = map[operationType]*action{
 			c.ep = blockFrame.ep
 			c.self = receiver
 
+			blockArgs := make([]Object, argCount)
+
 			for i := 0; i < argCount; i++ {
-				c.locals[i] = t.stack.Data[argPr+i]
+				blockArgs[i] = t.stack.Data[argPr+i].Target
+			}
+
+			for i, arg := range spreadBlockArguments(blockFrame.instructionSet, blockArgs) {
+				c.insertLCL(i, 0, arg)
 			}
 
 			t.callFrameStack.push(c)
@@ -554,6 +843,21 @@ var builtinActions = map[operationType]*action{
 			cf.stopExecution()
 		},
 	},
+	bytecode.ReturnFromBlock: {
+		name: bytecode.ReturnFromBlock,
+		operation: func(t *thread, sourceLine int, cf *normalCallFrame, args ...interface{}) {
+			target := t.returnTarget(cf)
+
+			// Without a method to return from, `return` leaves the block like `next`
+			if target == nil || target == cf {
+				t.callFrameStack.pop()
+				cf.stopExecution()
+				return
+			}
+
+			panic(&blockReturn{frame: target, value: t.stack.top().Target})
+		},
+	},
 }
 
 func (vm *VM) initObjectFromGoType(value interface{}) Object {
@@ -562,6 +866,9 @@ func (vm *VM) initObjectFromGoType(value interface{}) Object {
 		return NULL
 	case int:
 		return vm.initIntegerObject(v)
+	case *big.Int:
+		// The value can be an instruction's parameter, so the integer shouldn't share it
+		return vm.initBigIntegerObject(new(big.Int).Set(v))
 	case int64:
 		return vm.initIntegerObject(int(v))
 	case int32:
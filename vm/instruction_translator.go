@@ -2,7 +2,9 @@ package vm
 
 import (
 	"fmt"
+	"github.com/dlclark/regexp2"
 	"github.com/goby-lang/goby/compiler/bytecode"
+	"math/big"
 	"strconv"
 )
 
@@ -33,6 +35,7 @@ func (it *instructionTranslator) setMetadata(is *instructionSet, set *bytecode.I
 	n := set.Name()
 
 	is.name = n
+	is.kind = t
 
 	switch t {
 	case bytecode.Program:
@@ -56,6 +59,35 @@ func (it *instructionTranslator) parseBooleanParam(param string) bool {
 	return boolValue
 }
 
+func (it *instructionTranslator) parseFloatParam(param string) float64 {
+	floatValue, err := strconv.ParseFloat(param, 64)
+
+	// Can happen only in case of programmatic error, as the `param` value
+	// is the string version of a float.
+	if err != nil {
+		panic(fmt.Sprintf("Unknown float value: %s", param))
+	}
+
+	return floatValue
+}
+
+// parseIntegerParam returns an int, or a *big.Int for the integer literals that don't fit in an int
+func (it *instructionTranslator) parseIntegerParam(param string) interface{} {
+	if integer, err := strconv.ParseInt(param, 10, 64); err == nil {
+		return int(integer)
+	}
+
+	bigValue, ok := new(big.Int).SetString(param, 10)
+
+	// Can happen only in case of programmatic error, as the `param` value
+	// is the string version of an integer.
+	if !ok {
+		panic(fmt.Sprintf("Unknown integer value: %s", param))
+	}
+
+	return bigValue
+}
+
 func (it *instructionTranslator) parseParam(param string) interface{} {
 	integer, e := strconv.ParseInt(param, 0, 64)
 	if e != nil {
@@ -104,9 +136,17 @@ func (it *instructionTranslator) transferInstruction(is *instructionSet, i *byte
 	switch act {
 	case bytecode.PutBoolean:
 		params = append(params, it.parseBooleanParam(i.Params[0]))
-	case bytecode.PutString:
+	case bytecode.PutString, bytecode.PutSymbol:
 		params = append(params, i.Params[0])
-	case bytecode.BranchUnless, bytecode.BranchIf, bytecode.Jump:
+	case bytecode.PutRegexp:
+		// Regexp literals are compiled here, so they won't be compiled again on every evaluation
+		r, err := regexp2.Compile(i.Params[0], regexpOptions(i.Params[1]))
+		params = append(params, i.Params[0], i.Params[1], r, err)
+	case bytecode.PutFloat:
+		params = append(params, it.parseFloatParam(i.Params[0]))
+	case bytecode.PutObject:
+		params = append(params, it.parseIntegerParam(i.Params[0]))
+	case bytecode.BranchUnless, bytecode.BranchIf, bytecode.BranchNil, bytecode.Jump, bytecode.SetRescue, bytecode.SetEnsure:
 		line, err := i.AnchorLine()
 
 		if err != nil {
@@ -114,7 +154,7 @@ func (it *instructionTranslator) transferInstruction(is *instructionSet, i *byte
 		}
 
 		params = append(params, line)
-	case bytecode.Send:
+	case bytecode.Send, bytecode.InvokeSuper:
 		for _, param := range i.Params {
 			params = append(params, it.parseParam(param))
 		}
@@ -2,7 +2,9 @@ package vm
 
 import (
 	"math"
+	"math/big"
 	"strconv"
+	"unicode/utf8"
 
 	"github.com/goby-lang/goby/vm/classes"
 	"github.com/goby-lang/goby/vm/errors"
@@ -15,11 +17,24 @@ import (
 // 2 * 2 # => 4
 // ```
 //
+// Integers are promoted to arbitrary precision when a result or a literal doesn't fit in 64 bits,
+// and go back to the fixed size representation once the value fits again.
+//
+// ```ruby
+// 9223372036854775807 + 1       # => 9223372036854775808
+// (9223372036854775807 + 1) - 1 # => 9223372036854775807
+// 2 ** 100                      # => 1267650600228229401496703205376
+// 100000000000000000000 / 10    # => 10000000000000000000
+// ```
+//
 // - `Integer.new` is not supported.
 type IntegerObject struct {
 	*baseObj
 	value int
 	flag  int
+	// bigValue holds the value when it doesn't fit in an int.
+	// value is clamped to the nearest int in that case, so code that only reads value sees an out-of-range number instead of wrapped bits.
+	bigValue *big.Int
 }
 
 /*
@@ -43,6 +58,12 @@ const (
 	f64
 )
 
+// The range of the fixed size representation, results outside of it are promoted to big integers
+const (
+	maxInt = 1<<(strconv.IntSize-1) - 1
+	minInt = -maxInt - 1
+)
+
 // Class methods --------------------------------------------------------
 func builtinIntegerClassMethods() []*BuiltinMethodObject {
 	return []*BuiltinMethodObject{
@@ -70,14 +91,18 @@ func builtinIntegerInstanceMethods() []*BuiltinMethodObject {
 			Name: "+",
 			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
-					intOperation := func(leftValue int, rightValue int) int {
-						return leftValue + rightValue
+					intOperation := func(leftValue int, rightValue int) (int, bool) {
+						result := leftValue + rightValue
+						return result, (leftValue^result)&(rightValue^result) >= 0
+					}
+					bigOperation := func(leftValue *big.Int, rightValue *big.Int) *big.Int {
+						return new(big.Int).Add(leftValue, rightValue)
 					}
 					floatOperation := func(leftValue float64, rightValue float64) float64 {
 						return leftValue + rightValue
 					}
 
-					return receiver.(*IntegerObject).arithmeticOperation(t, args[0], intOperation, floatOperation, sourceLine)
+					return receiver.(*IntegerObject).arithmeticOperation(t, "+", args[0], intOperation, bigOperation, floatOperation, sourceLine)
 				}
 			},
 		},
@@ -91,14 +116,17 @@ func builtinIntegerInstanceMethods() []*BuiltinMethodObject {
 			Name: "%",
 			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
-					intOperation := func(leftValue int, rightValue int) int {
-						return leftValue % rightValue
+					intOperation := func(leftValue int, rightValue int) (int, bool) {
+						return leftValue % rightValue, true
+					}
+					bigOperation := func(leftValue *big.Int, rightValue *big.Int) *big.Int {
+						return new(big.Int).Rem(leftValue, rightValue)
 					}
 					floatOperation := func(leftValue float64, rightValue float64) float64 {
 						return math.Mod(leftValue, rightValue)
 					}
 
-					return receiver.(*IntegerObject).arithmeticOperation(t, args[0], intOperation, floatOperation, sourceLine)
+					return receiver.(*IntegerObject).arithmeticOperation(t, "%", args[0], intOperation, bigOperation, floatOperation, sourceLine)
 				}
 			},
 		},
@@ -112,14 +140,44 @@ func builtinIntegerInstanceMethods() []*BuiltinMethodObject {
 			Name: "-",
 			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
-					intOperation := func(leftValue int, rightValue int) int {
-						return leftValue - rightValue
+					intOperation := func(leftValue int, rightValue int) (int, bool) {
+						result := leftValue - rightValue
+						return result, (leftValue^rightValue)&(leftValue^result) >= 0
+					}
+					bigOperation := func(leftValue *big.Int, rightValue *big.Int) *big.Int {
+						return new(big.Int).Sub(leftValue, rightValue)
 					}
 					floatOperation := func(leftValue float64, rightValue float64) float64 {
 						return leftValue - rightValue
 					}
 
-					return receiver.(*IntegerObject).arithmeticOperation(t, args[0], intOperation, floatOperation, sourceLine)
+					return receiver.(*IntegerObject).arithmeticOperation(t, "-", args[0], intOperation, bigOperation, floatOperation, sourceLine)
+				}
+			},
+		},
+		{
+			// Returns the negation of self, it's called by the unary minus.
+			//
+			// ```Ruby
+			// -1    # => -1
+			// -(-1) # => 1
+			// ```
+			// @return [Integer]
+			Name: "-@",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					i := receiver.(*IntegerObject)
+
+					// The negation of the smallest int doesn't fit in an int
+					if i.bigValue == nil && i.value != minInt {
+						return t.vm.initIntegerObject(-i.value)
+					}
+
+					return t.vm.initBigIntegerObject(new(big.Int).Neg(i.bigInt()))
 				}
 			},
 		},
@@ -133,14 +191,17 @@ func builtinIntegerInstanceMethods() []*BuiltinMethodObject {
 			Name: "*",
 			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
-					intOperation := func(leftValue int, rightValue int) int {
-						return leftValue * rightValue
+					intOperation := func(leftValue int, rightValue int) (int, bool) {
+						return multiplyInt(leftValue, rightValue)
+					}
+					bigOperation := func(leftValue *big.Int, rightValue *big.Int) *big.Int {
+						return new(big.Int).Mul(leftValue, rightValue)
 					}
 					floatOperation := func(leftValue float64, rightValue float64) float64 {
 						return leftValue * rightValue
 					}
 
-					return receiver.(*IntegerObject).arithmeticOperation(t, args[0], intOperation, floatOperation, sourceLine)
+					return receiver.(*IntegerObject).arithmeticOperation(t, "*", args[0], intOperation, bigOperation, floatOperation, sourceLine)
 				}
 			},
 		},
@@ -154,14 +215,25 @@ func builtinIntegerInstanceMethods() []*BuiltinMethodObject {
 			Name: "**",
 			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
-					intOperation := func(leftValue int, rightValue int) int {
-						return int(math.Pow(float64(leftValue), float64(rightValue)))
+					intOperation := func(leftValue int, rightValue int) (int, bool) {
+						if rightValue < 0 {
+							return int(math.Pow(float64(leftValue), float64(rightValue))), true
+						}
+
+						return powerInt(leftValue, rightValue)
+					}
+					bigOperation := func(leftValue *big.Int, rightValue *big.Int) *big.Int {
+						if rightValue.Sign() < 0 {
+							return big.NewInt(int64(math.Pow(bigToFloat(leftValue), bigToFloat(rightValue))))
+						}
+
+						return new(big.Int).Exp(leftValue, rightValue, nil)
 					}
 					floatOperation := func(leftValue float64, rightValue float64) float64 {
 						return math.Pow(leftValue, rightValue)
 					}
 
-					return receiver.(*IntegerObject).arithmeticOperation(t, args[0], intOperation, floatOperation, sourceLine)
+					return receiver.(*IntegerObject).arithmeticOperation(t, "**", args[0], intOperation, bigOperation, floatOperation, sourceLine)
 				}
 			},
 		},
@@ -175,14 +247,161 @@ func builtinIntegerInstanceMethods() []*BuiltinMethodObject {
 			Name: "/",
 			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
-					intOperation := func(leftValue int, rightValue int) int {
-						return leftValue / rightValue
+					intOperation := func(leftValue int, rightValue int) (int, bool) {
+						if leftValue == minInt && rightValue == -1 {
+							return 0, false
+						}
+
+						return leftValue / rightValue, true
+					}
+					bigOperation := func(leftValue *big.Int, rightValue *big.Int) *big.Int {
+						return new(big.Int).Quo(leftValue, rightValue)
 					}
 					floatOperation := func(leftValue float64, rightValue float64) float64 {
 						return leftValue / rightValue
 					}
 
-					return receiver.(*IntegerObject).arithmeticOperation(t, args[0], intOperation, floatOperation, sourceLine)
+					return receiver.(*IntegerObject).arithmeticOperation(t, "/", args[0], intOperation, bigOperation, floatOperation, sourceLine)
+				}
+			},
+		},
+		{
+			// Returns the bitwise AND of self and another Integer.
+			//
+			// ```Ruby
+			// 0b1100 & 0b1010 # => 8
+			// ```
+			// @return [Integer]
+			Name: "&",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					intOperation := func(leftValue int, rightValue int) (int, bool) {
+						return leftValue & rightValue, true
+					}
+					bigOperation := func(leftValue *big.Int, rightValue *big.Int) *big.Int {
+						return new(big.Int).And(leftValue, rightValue)
+					}
+
+					return receiver.(*IntegerObject).bitwiseOperation(t, args, intOperation, bigOperation, sourceLine)
+				}
+			},
+		},
+		{
+			// Returns the bitwise OR of self and another Integer.
+			//
+			// ```Ruby
+			// 0b1100 | 0b1010 # => 14
+			// ```
+			// @return [Integer]
+			Name: "|",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					intOperation := func(leftValue int, rightValue int) (int, bool) {
+						return leftValue | rightValue, true
+					}
+					bigOperation := func(leftValue *big.Int, rightValue *big.Int) *big.Int {
+						return new(big.Int).Or(leftValue, rightValue)
+					}
+
+					return receiver.(*IntegerObject).bitwiseOperation(t, args, intOperation, bigOperation, sourceLine)
+				}
+			},
+		},
+		{
+			// Returns the bitwise exclusive OR of self and another Integer.
+			//
+			// ```Ruby
+			// 0b1100 ^ 0b1010 # => 6
+			// ```
+			// @return [Integer]
+			Name: "^",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					intOperation := func(leftValue int, rightValue int) (int, bool) {
+						return leftValue ^ rightValue, true
+					}
+					bigOperation := func(leftValue *big.Int, rightValue *big.Int) *big.Int {
+						return new(big.Int).Xor(leftValue, rightValue)
+					}
+
+					return receiver.(*IntegerObject).bitwiseOperation(t, args, intOperation, bigOperation, sourceLine)
+				}
+			},
+		},
+		{
+			// Returns the bitwise complement of self, integers converted for Go types keep their ranges.
+			//
+			// ```Ruby
+			// ~5          # => -6
+			// ~0.to_uint8 # => 255
+			// ```
+			// @return [Integer]
+			Name: "~",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					r := receiver.(*IntegerObject)
+
+					if r.bigValue != nil {
+						return t.vm.initBigIntegerObject(new(big.Int).Not(r.bigValue))
+					}
+
+					result := t.vm.initIntegerObject(wrapGoInteger(^r.value, r.flag))
+					result.flag = r.flag
+					return result
+				}
+			},
+		},
+		{
+			// Shifts self to the left by the given bits, a negative count shifts it to the right.
+			//
+			// ```Ruby
+			// 1 << 4           # => 16
+			// 1 << 64          # => 18446744073709551616
+			// (1.to_uint8 << 8) # => 0
+			// ```
+			// @return [Integer]
+			Name: "<<",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					bigOperation := func(leftValue *big.Int, rightValue *big.Int) *big.Int {
+						return shiftBig(leftValue, int(rightValue.Int64()))
+					}
+
+					if err := checkShiftWidth(t, args, sourceLine); err != nil {
+						return err
+					}
+
+					return receiver.(*IntegerObject).bitwiseOperation(t, args, shiftInt, bigOperation, sourceLine)
+				}
+			},
+		},
+		{
+			// Shifts self to the right by the given bits, a negative count shifts it to the left.
+			//
+			// ```Ruby
+			// 16 >> 2 # => 4
+			// -8 >> 1 # => -4
+			// ```
+			// @return [Integer]
+			Name: ">>",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					intOperation := func(leftValue int, rightValue int) (int, bool) {
+						return shiftInt(leftValue, -rightValue)
+					}
+					bigOperation := func(leftValue *big.Int, rightValue *big.Int) *big.Int {
+						return shiftBig(leftValue, -int(rightValue.Int64()))
+					}
+
+					if err := checkShiftWidth(t, args, sourceLine); err != nil {
+						return err
+					}
+
+					return receiver.(*IntegerObject).bitwiseOperation(t, args, intOperation, bigOperation, sourceLine)
 				}
 			},
 		},
@@ -290,19 +509,11 @@ func builtinIntegerInstanceMethods() []*BuiltinMethodObject {
 
 					switch rightObject.(type) {
 					case *IntegerObject:
-						leftValue := receiver.(*IntegerObject).value
-						rightValue := rightObject.(*IntegerObject).value
-
-						if leftValue < rightValue {
-							return t.vm.initIntegerObject(-1)
-						}
-						if leftValue > rightValue {
-							return t.vm.initIntegerObject(1)
-						}
-
-						return t.vm.initIntegerObject(0)
+						return t.vm.initIntegerObject(receiver.(*IntegerObject).compare(rightObject.(*IntegerObject)))
+					case *DecimalObject:
+						return t.vm.initIntegerObject(new(big.Rat).SetInt(receiver.(*IntegerObject).bigInt()).Cmp(rightObject.(*DecimalObject).value))
 					case *FloatObject:
-						leftValue := float64(receiver.(*IntegerObject).value)
+						leftValue := receiver.(*IntegerObject).floatValue()
 						rightValue := rightObject.(*FloatObject).value
 
 						if leftValue < rightValue {
@@ -359,6 +570,32 @@ func builtinIntegerInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Returns the character of self as a Unicode code point, which is the opposite of `String#ord`.
+			//
+			// ```ruby
+			// 120.chr   # => "x"
+			// 28450.chr # => "漢"
+			// "a".ord.chr # => "a"
+			// ```
+			// @return [String]
+			Name: "chr",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					i := receiver.(*IntegerObject)
+
+					if i.bigValue != nil || !utf8.ValidRune(rune(i.value)) || i.value != int(rune(i.value)) {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "%s out of char range", i.toString())
+					}
+
+					return t.vm.initStringObject(string(rune(i.value)))
+				}
+			},
+		},
 		{
 			// Returns if self is even.
 			//
@@ -372,7 +609,7 @@ func builtinIntegerInstanceMethods() []*BuiltinMethodObject {
 				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
 
 					i := receiver.(*IntegerObject)
-					even := i.value%2 == 0
+					even := i.bigInt().Bit(0) == 0
 
 					if even {
 						return TRUE
@@ -388,12 +625,29 @@ func builtinIntegerInstanceMethods() []*BuiltinMethodObject {
 		// 100.to_f # => '100.0'.to_f
 		// ```
 		// @return [Float]
+		{
+			// Returns the hash value of the integer, which is the same for integers that have the same value.
+			// Big integers have it as well, so integers can be used in the user-defined `hash` methods.
+			//
+			// ```ruby
+			// 1.hash == 1.hash                   # => true
+			// (2 ** 100).hash == (2 ** 100).hash # => true
+			// ```
+			//
+			// @return [Integer]
+			Name: "hash",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					return t.hashMethodResult(receiver, sourceLine)
+				}
+			},
+		},
 		{
 			Name: "to_f",
 			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
 					r := receiver.(*IntegerObject)
-					newFloat := t.vm.initFloatObject(float64(r.value))
+					newFloat := t.vm.initFloatObject(r.floatValue())
 					return newFloat
 				}
 			},
@@ -425,7 +679,7 @@ func builtinIntegerInstanceMethods() []*BuiltinMethodObject {
 
 					int := receiver.(*IntegerObject)
 
-					return t.vm.initStringObject(strconv.Itoa(int.value))
+					return t.vm.initStringObject(int.toString())
 				}
 			},
 		},
@@ -440,7 +694,12 @@ func builtinIntegerInstanceMethods() []*BuiltinMethodObject {
 			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
 					i := receiver.(*IntegerObject)
-					return t.vm.initIntegerObject(i.value + 1)
+
+					if i.bigValue == nil && i.value < maxInt {
+						return t.vm.initIntegerObject(i.value + 1)
+					}
+
+					return t.vm.initBigIntegerObject(new(big.Int).Add(i.bigInt(), big.NewInt(1)))
 				}
 			},
 		},
@@ -457,7 +716,7 @@ func builtinIntegerInstanceMethods() []*BuiltinMethodObject {
 				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
 
 					i := receiver.(*IntegerObject)
-					odd := i.value%2 != 0
+					odd := i.bigInt().Bit(0) != 0
 					if odd {
 						return TRUE
 					}
@@ -477,7 +736,12 @@ func builtinIntegerInstanceMethods() []*BuiltinMethodObject {
 			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
 					i := receiver.(*IntegerObject)
-					return t.vm.initIntegerObject(i.value - 1)
+
+					if i.bigValue == nil && i.value > minInt {
+						return t.vm.initIntegerObject(i.value - 1)
+					}
+
+					return t.vm.initBigIntegerObject(new(big.Int).Sub(i.bigInt(), big.NewInt(1)))
 				}
 			},
 		},
@@ -668,22 +932,47 @@ func (vm *VM) initIntegerObject(value int) *IntegerObject {
 	}
 }
 
+// initBigIntegerObject returns an integer of the given value, it only keeps the big.Int when the value doesn't fit in an int
+func (vm *VM) initBigIntegerObject(value *big.Int) *IntegerObject {
+	if value.IsInt64() && value.Int64() >= minInt && value.Int64() <= maxInt {
+		return vm.initIntegerObject(int(value.Int64()))
+	}
+
+	i := vm.initIntegerObject(maxInt)
+
+	if value.Sign() < 0 {
+		i.value = minInt
+	}
+
+	i.bigValue = value
+	return i
+}
+
 func (vm *VM) initIntegerClass() *RClass {
 	ic := vm.initializeClass(classes.IntegerClass, false)
 	ic.setBuiltinMethods(builtinIntegerInstanceMethods(), false)
 	ic.setBuiltinMethods(builtinIntegerClassMethods(), true)
+	vm.libFiles = append(vm.libFiles, "integer.gb")
 	return ic
 }
 
 // Polymorphic helper functions -----------------------------------------
 
-// Value returns the object
+// Value returns the object, it's a *big.Int if the value doesn't fit in an int
 func (i *IntegerObject) Value() interface{} {
+	if i.bigValue != nil {
+		return i.bigValue
+	}
+
 	return i.value
 }
 
 // Numeric interface
 func (i *IntegerObject) floatValue() float64 {
+	if i.bigValue != nil {
+		return bigToFloat(i.bigValue)
+	}
+
 	return float64(i.value)
 }
 
@@ -691,21 +980,35 @@ func (i *IntegerObject) floatValue() float64 {
 // Apply the passed arithmetic operation, while performing type conversion.
 func (i *IntegerObject) arithmeticOperation(
 	t *thread,
+	operator string,
 	rightObject Object,
-	intOperation func(leftValue int, rightValue int) int,
+	intOperation func(leftValue int, rightValue int) (int, bool),
+	bigOperation func(leftValue *big.Int, rightValue *big.Int) *big.Int,
 	floatOperation func(leftValue float64, rightValue float64) float64,
 	sourceLine int,
 ) Object {
 	switch rightObject.(type) {
 	case *IntegerObject:
-		leftValue := i.value
-		rightValue := rightObject.(*IntegerObject).value
+		right := rightObject.(*IntegerObject)
+
+		if (operator == "/" || operator == "%") && right.bigValue == nil && right.value == 0 {
+			return t.vm.initErrorObject(errors.ZeroDivisionError, sourceLine, "Divided by 0")
+		}
 
-		result := intOperation(leftValue, rightValue)
+		// The int operation reports false when the result overflows
+		if i.bigValue == nil && right.bigValue == nil {
+			if result, ok := intOperation(i.value, right.value); ok {
+				return t.vm.initIntegerObject(result)
+			}
+		}
 
-		return t.vm.initIntegerObject(result)
+		result := bigOperation(i.bigInt(), right.bigInt())
+
+		return t.vm.initBigIntegerObject(result)
+	case *DecimalObject:
+		return t.vm.initDecimalObject(new(big.Rat).SetInt(i.bigInt())).arithmeticOperation(t, operator, rightObject, sourceLine)
 	case *FloatObject:
-		leftValue := float64(i.value)
+		leftValue := i.floatValue()
 		rightValue := rightObject.(*FloatObject).value
 
 		result := floatOperation(leftValue, rightValue)
@@ -716,16 +1019,76 @@ func (i *IntegerObject) arithmeticOperation(
 	}
 }
 
+// bitwiseOperation applies the bitwise operation to self and another Integer.
+// Integers converted for Go types like `to_uint8` keep their types, and the results wrap around like Go's.
+func (i *IntegerObject) bitwiseOperation(
+	t *thread,
+	args []Object,
+	intOperation func(leftValue int, rightValue int) (int, bool),
+	bigOperation func(leftValue *big.Int, rightValue *big.Int) *big.Int,
+	sourceLine int,
+) Object {
+	if len(args) != 1 {
+		return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 1, len(args))
+	}
+
+	right, ok := args[0].(*IntegerObject)
+
+	if !ok {
+		return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.IntegerClass, args[0].Class().Name)
+	}
+
+	if hasGoIntegerType(i.flag) {
+		result, _ := intOperation(i.value, right.value)
+		newInt := t.vm.initIntegerObject(wrapGoInteger(result, i.flag))
+		newInt.flag = i.flag
+		return newInt
+	}
+
+	// The int operation reports false when the result overflows
+	if i.bigValue == nil && right.bigValue == nil {
+		if result, ok := intOperation(i.value, right.value); ok {
+			return t.vm.initIntegerObject(result)
+		}
+	}
+
+	return t.vm.initBigIntegerObject(bigOperation(i.bigInt(), right.bigInt()))
+}
+
+// hasGoIntegerType tells if the flag is set by the conversions for Go integer types like `to_int8` or `to_uint64`
+func hasGoIntegerType(flag int) bool {
+	return flag != i && flag != f32 && flag != f64
+}
+
+// wrapGoInteger wraps the value around in the range of the flag's Go integer type
+func wrapGoInteger(value int, flag int) int {
+	switch flag {
+	case i8:
+		value = int(int8(value))
+	case i16:
+		value = int(int16(value))
+	case i32:
+		value = int(int32(value))
+	case ui8:
+		value = int(uint8(value))
+	case ui16:
+		value = int(uint16(value))
+	case ui32:
+		value = int(uint32(value))
+	}
+
+	return value
+}
+
 // Apply an equality test, returning true if the objects are considered equal,
 // and false otherwise.
 // See comment on numericComparison().
 func (i *IntegerObject) equalityTest(rightObject Object) bool {
 	switch rightObject.(type) {
 	case *IntegerObject:
-		leftValue := i.value
-		rightValue := rightObject.(*IntegerObject).value
-
-		return leftValue == rightValue
+		return i.compare(rightObject.(*IntegerObject)) == 0
+	case *DecimalObject:
+		return rightObject.(*DecimalObject).equalityTest(i)
 	case *FloatObject:
 		leftValue := i.floatValue()
 		rightValue := rightObject.(*FloatObject).value
@@ -749,10 +1112,12 @@ func (i *IntegerObject) numericComparison(
 ) Object {
 	switch rightObject.(type) {
 	case *IntegerObject:
-		leftValue := i.value
-		rightValue := rightObject.(*IntegerObject).value
+		// Comparing the result of compare with 0 gives the same answer as comparing the values
+		result := intComparison(i.compare(rightObject.(*IntegerObject)), 0)
 
-		result := intComparison(leftValue, rightValue)
+		return toBooleanObject(result)
+	case *DecimalObject:
+		result := intComparison(new(big.Rat).SetInt(i.bigInt()).Cmp(rightObject.(*DecimalObject).value), 0)
 
 		return toBooleanObject(result)
 	case *FloatObject:
@@ -769,6 +1134,10 @@ func (i *IntegerObject) numericComparison(
 
 // toString returns the object's name as the string format
 func (i *IntegerObject) toString() string {
+	if i.bigValue != nil {
+		return i.bigValue.String()
+	}
+
 	return strconv.Itoa(i.value)
 }
 
@@ -779,5 +1148,126 @@ func (i *IntegerObject) toJSON() string {
 
 // equal checks if the integer values between receiver and argument are equal
 func (i *IntegerObject) equal(e *IntegerObject) bool {
-	return i.value == e.value
+	return i.compare(e) == 0
+}
+
+// compare returns -1, 0 or 1 when the receiver is smaller than, equal to or larger than the argument
+func (i *IntegerObject) compare(e *IntegerObject) int {
+	if i.bigValue == nil && e.bigValue == nil {
+		switch {
+		case i.value < e.value:
+			return -1
+		case i.value > e.value:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	return i.bigInt().Cmp(e.bigInt())
+}
+
+// checkShiftWidth returns an error if the shift count is a big integer
+func checkShiftWidth(t *thread, args []Object, sourceLine int) *Error {
+	if len(args) == 1 {
+		if count, ok := args[0].(*IntegerObject); ok && count.bigValue != nil {
+			return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "Shift width too big: %s", count.toString())
+		}
+	}
+
+	return nil
+}
+
+// shiftInt shifts the value to the left by count bits, a negative count shifts it to the right.
+// It reports false when the result overflows.
+func shiftInt(value int, count int) (int, bool) {
+	if count < 0 {
+		if count <= -strconv.IntSize {
+			return value >> (strconv.IntSize - 1), true
+		}
+
+		return value >> uint(-count), true
+	}
+
+	if count >= strconv.IntSize {
+		return 0, value == 0
+	}
+
+	result := value << uint(count)
+	return result, result>>uint(count) == value
+}
+
+// shiftBig shifts the value to the left by count bits, a negative count shifts it to the right
+func shiftBig(value *big.Int, count int) *big.Int {
+	if count < 0 {
+		return new(big.Int).Rsh(value, uint(-count))
+	}
+
+	return new(big.Int).Lsh(value, uint(count))
+}
+
+// bigInt returns the value as a big.Int
+func (i *IntegerObject) bigInt() *big.Int {
+	if i.bigValue != nil {
+		return i.bigValue
+	}
+
+	return big.NewInt(int64(i.value))
+}
+
+// hashKeyValue returns a value that identifies the integer in a hashKey
+func (i *IntegerObject) hashKeyValue() interface{} {
+	if i.bigValue != nil {
+		return i.bigValue.String()
+	}
+
+	return i.value
+}
+
+// Other helper functions -----------------------------------------------
+
+// multiplyInt returns the product of the given ints, the second value is false if it overflows
+func multiplyInt(left, right int) (int, bool) {
+	if left == 0 || right == 0 {
+		return 0, true
+	}
+
+	result := left * right
+
+	if result/right != left || (left == -1 && right == minInt) || (right == -1 && left == minInt) {
+		return 0, false
+	}
+
+	return result, true
+}
+
+// powerInt returns base to the power of a non-negative exponent, the second value is false if it overflows
+func powerInt(base, exponent int) (int, bool) {
+	result := 1
+
+	for exponent > 0 {
+		var ok bool
+
+		if exponent&1 == 1 {
+			if result, ok = multiplyInt(result, base); !ok {
+				return 0, false
+			}
+		}
+
+		exponent >>= 1
+
+		if exponent > 0 {
+			if base, ok = multiplyInt(base, base); !ok {
+				return 0, false
+			}
+		}
+	}
+
+	return result, true
+}
+
+// bigToFloat converts a big.Int to the nearest float64
+func bigToFloat(value *big.Int) float64 {
+	f, _ := new(big.Float).SetInt(value).Float64()
+	return f
 }
@@ -4,6 +4,41 @@ import (
 	"testing"
 )
 
+func TestIntegerLiteral(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`1_000_000`, 1000000},
+		{`0xff`, 255},
+		{`0XFF`, 255},
+		{`0o17`, 15},
+		{`0b1010`, 10},
+		{`0b1010 + 0x0a`, 20},
+		{`10.to_s`, "10"},
+		{`100000000000000000000.to_s`, "100000000000000000000"},
+		{`100000000000000000000 == 10 ** 20`, true},
+		{`0x1_0000_0000_0000_0000 == 2 ** 64`, true},
+		{`-9223372036854775808 == -(2 ** 63)`, true},
+		{`-9223372036854775808 + 1`, -9223372036854775807},
+		{`
+		sum = 0
+		3.times do
+		  sum = sum + 100000000000000000000 * 2
+		end
+		sum.to_s
+		`, "600000000000000000000"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestIntegerClassSuperclass(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -94,6 +129,66 @@ func TestIntegerArithmeticOperationFail(t *testing.T) {
 		{`1 - "m"`, "TypeError: Expect argument to be Numeric. got: String", 1, 1},
 		{`1 ** "p"`, "TypeError: Expect argument to be Numeric. got: String", 1, 1},
 		{`1 / "t"`, "TypeError: Expect argument to be Numeric. got: String", 1, 1},
+		{`1 / 0`, "ZeroDivisionError: Divided by 0", 1, 1},
+		{`5 % 0`, "ZeroDivisionError: Divided by 0", 1, 1},
+		{`(2 ** 100) / 0`, "ZeroDivisionError: Divided by 0", 1, 1},
+		{`(2 ** 100) % 0`, "ZeroDivisionError: Divided by 0", 1, 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, tt.expectedCFP)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestIntegerBitwiseOperation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`6 & 3`, 2},
+		{`6 | 3`, 7},
+		{`6 ^ 3`, 5},
+		{`~5`, -6},
+		{`~-1`, 0},
+		{`1 << 3`, 8},
+		{`16 >> 2`, 4},
+		{`-8 >> 1`, -4},
+		{`1 << -1`, 0},
+		{`8 >> -1`, 16},
+		{`1 << 64 >> 64`, 1},
+		{`(1 << 70).to_s`, "1180591620717411303424"},
+		{`((2 ** 70) | 1).to_s`, "1180591620717411303425"},
+		{`(2 ** 70 + 3) & 6`, 2},
+		{`(2 ** 70) >> 68`, 4},
+		{`(~(2 ** 70)).to_s`, "-1180591620717411303425"},
+		{`1 | 2 & 3 << 1 + 1`, 1},
+		{`~0.to_uint8`, 255},
+		{`1.to_uint8 << 8`, 0},
+		{`1.to_int8 | 128`, -127},
+		{`(255.to_uint8 ^ 15).to_s`, "240"},
+		{`65535.to_uint16 >> 8`, 255},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestIntegerBitwiseOperationFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`1 & "a"`, "TypeError: Expect argument to be Integer. got: String", 1, 1},
+		{`1 | 1.0`, "TypeError: Expect argument to be Integer. got: Float", 1, 1},
+		{`1 << nil`, "TypeError: Expect argument to be Integer. got: Null", 1, 1},
+		{`1 << 2 ** 70`, "ArgumentError: Shift width too big: 1180591620717411303424", 1, 1},
+		{`1.send("^")`, "ArgumentError: Expect 1 arguments. got: 0", 1, 2},
 	}
 
 	for i, tt := range testsFail {
@@ -217,6 +312,35 @@ func TestIntegerEquality(t *testing.T) {
 	}
 }
 
+func TestIntegerUnaryMinus(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`-5`, -5},
+		{`
+		a = 5
+		-a
+		`, -5},
+		{`
+		a = -5
+		-a
+		`, 5},
+		{`5.-@`, -5},
+		{`5.send(:-@)`, -5},
+		{`(-(-9223372036854775807 - 1)).to_s`, "9223372036854775808"},
+		{`-(2 ** 100) == 0 - 2 ** 100`, true},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestIntegerConversion(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -236,6 +360,44 @@ func TestIntegerConversion(t *testing.T) {
 	}
 }
 
+func TestIntegerChrMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`120.chr`, "x"},
+		{`28450.chr`, "漢"},
+		{`"a".ord.chr`, "a"},
+		{`"🍣".ord.chr`, "🍣"},
+		{`0.chr`, "\x00"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestIntegerChrMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`(-1).chr`, "ArgumentError: -1 out of char range", 1, 1},
+		{`1114112.chr`, "ArgumentError: 1114112 out of char range", 1, 1},
+		{`(2 ** 64).chr`, "ArgumentError: 18446744073709551616 out of char range", 1, 1},
+		{`1.chr(1)`, "ArgumentError: Expect 0 arguments. got: 1", 1, 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, tt.expectedCFP)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestIntegerEvenMethod(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -344,3 +506,107 @@ func TestIntegerTimesMethodFail(t *testing.T) {
 		v.checkSP(t, i, 1)
 	}
 }
+
+func TestIntegerOverflowPromotion(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`(9223372036854775807 + 1).to_s`, "9223372036854775808"},
+		{`(-9223372036854775807 - 2).to_s`, "-9223372036854775809"},
+		{`(9223372036854775807 * 3).to_s`, "27670116110564327421"},
+		{`(-9223372036854775807 - 1) * -1 > 0`, true},
+		{`((-9223372036854775807 - 1) / -1).to_s`, "9223372036854775808"},
+		{`(2 ** 100).to_s`, "1267650600228229401496703205376"},
+		{`(2 ** 100) / (2 ** 98)`, 4},
+		{`(2 ** 100 % 3)`, 1},
+		{`(2 ** 100 - 2 ** 100)`, 0},
+		{`(9223372036854775807 + 1) - 1`, 9223372036854775807},
+		{`(9223372036854775807 + 1).class.name`, "Integer"},
+		{`2 ** 62`, 4611686018427387904},
+		{`1 ** 1000000000000`, 1},
+		{`2 ** -1`, 0},
+		{`2 ** 100 > 2 ** 99`, true},
+		{`2 ** 100 < 1`, false},
+		{`2 ** 100 >= 2 ** 100`, true},
+		{`2 ** 100 == 2 ** 100`, true},
+		{`2 ** 100 != 2 ** 100 + 1`, true},
+		{`2 ** 100 == 1.0`, false},
+		{`2 ** 100 <=> 2 ** 99`, 1},
+		{`-(2 ** 100) <=> 1`, -1},
+		{`(2 ** 100).even?`, true},
+		{`(2 ** 100 + 1).odd?`, true},
+		{`(9223372036854775807.next).to_s`, "9223372036854775808"},
+		{`9223372036854775807.next.pred`, 9223372036854775807},
+		{`(2 ** 100).to_f`, 1267650600228229401496703205376.0},
+		{`2 ** 100 + 0.5`, 1267650600228229401496703205376.0},
+		{`1e30.to_i.to_s`, "1000000000000000019884624838656"},
+		{`{ 2 ** 100 => "a" }[2 ** 100]`, "a"},
+		{`{ v: 2 ** 70 }.to_json`, `{"v":1180591620717411303424}`},
+		{`[2 ** 70].to_s`, "[1180591620717411303424]"},
+		{`[1, 2, 3][2 ** 64]`, nil},
+		{`[1, 2, 3][-(2 ** 64)]`, nil},
+		{`"abc"[2 ** 64]`, nil},
+		{`
+		begin
+		  1 / 0
+		rescue ZeroDivisionError => e
+		  e.message
+		end
+		`, "Divided by 0"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestIntegerOverflowDemotion(t *testing.T) {
+	tests := []string{
+		`(9223372036854775807 + 1) - 1`,
+		`(2 ** 100) / (2 ** 99)`,
+		`9223372036854775807.next.pred`,
+		`(2 ** 64) % 10`,
+	}
+
+	for i, input := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, input, getFilename())
+
+		integer, ok := evaluated.(*IntegerObject)
+
+		if !ok {
+			t.Fatalf("At test case %d: Expect evaluated value to be an integer. got: %T", i, evaluated)
+		}
+
+		if integer.bigValue != nil {
+			t.Errorf("At test case %d: Expect %s to be demoted to int", i, integer.toString())
+		}
+	}
+}
+
+func TestIntegerHashMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`1.hash == 1.hash`, true},
+		{`1.hash == 2.hash`, false},
+		{`1.hash == 1.0.hash`, false},
+		{`(2 ** 100).hash == (2 ** 100).hash`, true},
+		{`(2 ** 100).hash == (2 ** 101).hash`, false},
+		{`(2 ** 100).hash.class.name`, "Integer"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
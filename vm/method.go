@@ -7,12 +7,20 @@ import (
 	"github.com/goby-lang/goby/vm/classes"
 )
 
+// Method visibilities, which are set with `public`, `private` and `protected`
+const (
+	publicMethod = iota
+	privateMethod
+	protectedMethod
+)
+
 // MethodObject represents methods defined using goby.
 type MethodObject struct {
 	*baseObj
 	Name           string
 	instructionSet *instructionSet
 	argc           int
+	visibility     int
 }
 
 // Internal functions ===================================================
@@ -56,6 +64,16 @@ func (m *MethodObject) isSplatArgIncluded() bool {
 	return false
 }
 
+func (m *MethodObject) isBlockArgIncluded() bool {
+	for _, argType := range m.paramTypes() {
+		if argType == bytecode.BlockArg {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (m *MethodObject) isKeywordArgIncluded() bool {
 	for _, argType := range m.paramTypes() {
 		if argType == bytecode.OptionalKeywordArg || argType == bytecode.RequiredKeywordArg {
@@ -66,13 +84,100 @@ func (m *MethodObject) isKeywordArgIncluded() bool {
 	return false
 }
 
+// methodLookupChainOf returns the classes and modules that the receiver's methods are looked up from, in order
+func methodLookupChainOf(receiver Object) []*RClass {
+	var chain []*RClass
+
+	switch r := receiver.(type) {
+	case *RClass:
+		if r.isSingleton {
+			chain = r.superClass.methodLookupChain()
+		} else {
+			chain = r.SingletonClass().methodLookupChain()
+		}
+	default:
+		if r.SingletonClass() != nil {
+			chain = r.SingletonClass().methodLookupChain()
+		}
+
+		chain = append(chain, r.Class().methodLookupChain()...)
+	}
+
+	return chain
+}
+
+// superMethodOf returns the method that's overridden by the given method body in the receiver's method lookup chain
+func superMethodOf(receiver Object, is *instructionSet) Object {
+	found := false
+
+	for _, c := range methodLookupChainOf(receiver) {
+		method, ok := c.Methods.get(is.name)
+
+		if !ok {
+			continue
+		}
+
+		// A method can be copied to a subclass by changing its visibility, so we skip all of its copies
+		if m, ok := method.(*MethodObject); ok && m.instructionSet == is {
+			found = true
+			continue
+		}
+
+		if found {
+			return method
+		}
+	}
+
+	return nil
+}
+
+// methodOwner returns the class or module which has the method in the receiver's method lookup chain
+func methodOwner(receiver Object, methodName string, method Object) *RClass {
+	for _, c := range methodLookupChainOf(receiver) {
+		if m, ok := c.Methods.get(methodName); ok && m == method {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// methodVisibility returns the visibility of the method
+func methodVisibility(method Object) int {
+	switch m := method.(type) {
+	case *MethodObject:
+		return m.visibility
+	case *BuiltinMethodObject:
+		return m.visibility
+	}
+
+	return publicMethod
+}
+
+// withVisibility returns a copy of the method with the given visibility
+func withVisibility(method Object, visibility int) Object {
+	switch m := method.(type) {
+	case *MethodObject:
+		copied := *m
+		copied.visibility = visibility
+		return &copied
+	case *BuiltinMethodObject:
+		copied := *m
+		copied.visibility = visibility
+		return &copied
+	}
+
+	return method
+}
+
 //  BuiltinMethodObject =================================================
 
 // BuiltinMethodObject represents methods defined in go.
 type BuiltinMethodObject struct {
 	*baseObj
-	Name string
-	Fn   func(receiver Object, sourceLine int) builtinMethodBody
+	Name       string
+	Fn         func(receiver Object, sourceLine int) builtinMethodBody
+	visibility int
 }
 
 type builtinMethodBody func(*thread, []Object, *normalCallFrame) Object
@@ -0,0 +1,142 @@
+package vm
+
+import "testing"
+
+func TestMethodMissingEvaluation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		class Foo
+		  def method_missing(name, *args)
+		    [name, args].to_s
+		  end
+		end
+
+		Foo.new.bar(1, 2)
+		`, "[:bar, [1, 2]]"},
+		{`
+		class Foo
+		  def method_missing(name, *args)
+		    args.to_s
+		  end
+		end
+
+		Foo.new.bar(1, k: 2)
+		`, "[1, { k: 2 }]"},
+		{`
+		class Foo
+		  def method_missing(name, &block)
+		    block.call(name)
+		  end
+		end
+
+		Foo.new.bar do |n|
+		  n.to_s + "!"
+		end
+		`, "bar!"},
+		{`
+		class Proxy
+		  def initialize(target)
+		    @target = target
+		  end
+
+		  def method_missing(name, *args, &block)
+		    @target.send(name, *args, &block)
+		  end
+		end
+
+		Proxy.new([1, 2, 3]).map do |x|
+		  x * 2
+		end.to_s
+		`, "[2, 4, 6]"},
+		{`
+		class Foo
+		  def self.method_missing(name)
+		    name.to_s
+		  end
+		end
+
+		Foo.bar
+		`, "bar"},
+		{`
+		class Foo
+		  def method_missing(name)
+		    10
+		  end
+		end
+
+		Foo.new.send(:bar)
+		`, 10},
+		{`
+		class Hash
+		  def method_missing(name)
+		    self[name]
+		  end
+		end
+
+		{ foo: 10 }.foo
+		`, 10},
+		{`
+		class Foo
+		  def method_missing(name)
+		    1
+		  end
+		end
+
+		Foo.new.respond_to?(:bar)
+		`, false},
+		{`
+		class Foo
+		  def method_missing(name)
+		    1
+		  end
+
+		  def respond_to_missing?(name, include_all)
+		    name == :bar
+		  end
+		end
+
+		[Foo.new.respond_to?(:bar), Foo.new.respond_to?(:baz)].to_s
+		`, "[true, false]"},
+		{`1.respond_to_missing?(:foo, false)`, false},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestMethodMissingEvaluationFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`
+		class Foo
+		  def method_missing(name)
+		    if name == :bar
+		      return 1
+		    end
+
+		    super
+		  end
+		end
+
+		Foo.new.baz
+		`, "UndefinedMethodError: Undefined Method 'baz' for <Instance of: Foo>", 8, 2},
+		{`method_missing`, "ArgumentError: Expect 1 or more arguments. got: 0", 1, 1},
+		{`method_missing(1)`, "TypeError: Expect argument to be Symbol. got: Integer", 1, 1},
+		{`send(:foo)`, "UndefinedMethodError: Undefined Method 'foo' for <Instance of: Object>", 1, 2},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, tt.expectedCFP)
+		v.checkSP(t, i, 1)
+	}
+}
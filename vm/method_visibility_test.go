@@ -0,0 +1,350 @@
+package vm
+
+import "testing"
+
+func TestMethodVisibility(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		class Foo
+		  def bar
+		    baz + self.baz
+		  end
+
+		  private
+
+		  def baz
+		    10
+		  end
+		end
+
+		Foo.new.bar
+		`, 20},
+		{`
+		class Foo
+		  def bar
+		    baz
+		  end
+
+		  def baz
+		    10
+		  end
+
+		  private :baz
+		end
+
+		Foo.new.bar
+		`, 10},
+		{`
+		class Foo
+		  private
+
+		  def baz
+		    10
+		  end
+		end
+
+		Foo.new.send(:baz)
+		`, 10},
+		{`
+		class Foo
+		  private
+
+		  def bar
+		    10
+		  end
+
+		  public
+
+		  def baz
+		    bar
+		  end
+		end
+
+		Foo.new.baz
+		`, 10},
+		{`
+		class Foo
+		  private
+
+		  def bar
+		    10
+		  end
+		end
+
+		class Foo
+		  def baz
+		    20
+		  end
+		end
+
+		Foo.new.baz
+		`, 20},
+		{`
+		class Foo
+		  private
+
+		  def bar
+		    10
+		  end
+		end
+
+		class Bar < Foo
+		  public :bar
+		end
+
+		Bar.new.bar
+		`, 10},
+		{`
+		class Foo
+		  def initialize(n)
+		    @n = n
+		  end
+
+		  def bigger?(other)
+		    n > other.n
+		  end
+
+		  protected
+
+		  def n
+		    @n
+		  end
+		end
+
+		class Bar < Foo
+		end
+
+		Foo.new(2).bigger?(Bar.new(1))
+		`, true},
+		{`
+		module Secret
+		  private
+
+		  def secret
+		    42
+		  end
+		end
+
+		class Foo
+		  include Secret
+
+		  def answer
+		    secret
+		  end
+		end
+
+		Foo.new.answer
+		`, 42},
+		{`
+		class Foo
+		  def bar
+		    10
+		  end
+
+		  private :bar
+		end
+
+		Foo.new.respond_to?(:bar)
+		`, false},
+		{`
+		class Foo
+		  def bar
+		    10
+		  end
+
+		  def baz
+		    10
+		  end
+
+		  private :bar
+		end
+
+		[Foo.new.methods.include?("bar"), Foo.new.methods.include?("baz")].to_s
+		`, "[false, true]"},
+		{`
+		class Foo
+		  def bar
+		    10
+		  end
+
+		  def baz
+		    10
+		  end
+
+		end
+
+		Foo.private(:bar, "baz").to_s
+		`, `[:bar, "baz"]`},
+		{`
+		class Foo
+		  def bar
+		    10
+		  end
+
+		end
+
+		Foo.protected(:bar).to_s
+		`, "bar"},
+		{`
+		class Foo
+		  def bar
+		    1
+		  end
+		end
+
+		class Bar < Foo
+		  def bar
+		    super + 1
+		  end
+		end
+
+		class Baz < Bar
+		  private :bar
+
+		  def call_bar
+		    bar
+		  end
+		end
+
+		Baz.new.call_bar
+		`, 2},
+		{`
+		class Foo
+		  def initialize
+		    @bar = 10
+		  end
+
+		  def double_bar
+		    bar * 2
+		  end
+
+		  private
+
+		  attr_reader :bar
+		end
+
+		Foo.new.double_bar
+		`, 20},
+		{`
+		class Foo
+		  def initialize(bar)
+		    @bar = bar
+		  end
+
+		  def ==(other)
+		    bar == other.bar
+		  end
+
+		  protected
+
+		  attr_accessor :bar
+		end
+
+		Foo.new(1) == Foo.new(1)
+		`, true},
+		{`
+		class Foo
+		  private
+
+		  attr_reader :bar
+
+		  public
+
+		  attr_writer :bar
+		end
+
+		f = Foo.new
+		f.bar = 10
+		f.instance_variable_get(:@bar)
+		`, 10},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestMethodVisibilityFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`
+		class Foo
+		  private
+
+		  def bar
+		    10
+		  end
+		end
+
+		Foo.new.bar
+		`, "UndefinedMethodError: Private method 'bar' called for <Instance of: Foo>", 10, 1},
+		{`
+		class Foo
+		  def bar
+		    10
+		  end
+
+		  protected :bar
+		end
+
+		Foo.new.bar
+		`, "UndefinedMethodError: Protected method 'bar' called for <Instance of: Foo>", 10, 1},
+		{`
+		class Foo
+		  def bar(other)
+		    other.baz
+		  end
+
+		  private
+
+		  def baz
+		    10
+		  end
+		end
+
+		Foo.new.bar(Foo.new)
+		`, "UndefinedMethodError: Private method 'baz' called for <Instance of: Foo>", 4, 2},
+		{`
+		class Foo
+		  private
+
+		  attr_reader :bar
+		end
+
+		Foo.new.bar
+		`, "UndefinedMethodError: Private method 'bar' called for <Instance of: Foo>", 8, 1},
+		{`
+		class Foo
+		  protected
+
+		  attr_accessor :bar
+		end
+
+		Foo.new.bar = 1
+		`, "UndefinedMethodError: Protected method 'bar=' called for <Instance of: Foo>", 8, 1},
+		{`
+		class Foo
+		  private :bar
+		end
+		`, "NameError: Undefined method 'bar' for Foo", 3, 2},
+		{`
+		class Foo
+		  private 1
+		end
+		`, "TypeError: Expect argument to be Symbol or String. got: Integer", 3, 2},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, tt.expectedCFP)
+		v.checkSP(t, i, 1)
+	}
+}
@@ -116,7 +116,7 @@ func TestNullAssignmentByOperation(t *testing.T) {
 		{`a = nil; a ||= "string";  a;`, "string"},
 		{`a = nil; a ||= nil;     a;`, nil},
 		{`a = nil; a ||= (1..4);    a.to_s;`, "(1..4)"},
-		{`a = nil; a ||= { b: 1 };  a["b"];`, 1},
+		{`a = nil; a ||= { b: 1 };  a[:b];`, 1},
 		{`a = nil; a ||= Object;    a.name;`, "Object"},
 		{`a = nil; a ||= [1, 2, 3]; a[0];`, 1},
 		{`a = nil; a ||= [1, 2, 3]; a[1];`, 2},
@@ -119,3 +119,18 @@ func (ro *RObject) toJSON() string {
 func (ro *RObject) Value() interface{} {
 	return ro.toString()
 }
+
+// Other helper functions -----------------------------------------------
+
+// inspectString returns the object's string format used in inspected output like `Array#to_s` and `format("%p")`,
+// strings are quoted and symbols have a leading colon.
+func inspectString(obj Object) string {
+	switch obj := obj.(type) {
+	case *StringObject:
+		return "\"" + obj.value + "\""
+	case *SymbolObject:
+		return ":" + obj.value
+	}
+
+	return obj.toString()
+}
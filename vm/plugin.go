@@ -54,7 +54,7 @@ func builtinPluginClassMethods() []*BuiltinMethodObject {
 					p, err := compileAndOpenPlugin(soName, pkgPath)
 
 					if err != nil {
-						return t.vm.initErrorObject(errors.InternalError, sourceLine, err.Error())
+						return t.vm.initErrorObject(errors.InternalError, sourceLine, "%s", err.Error())
 					}
 
 					return t.vm.initPluginObject(pkgPath, p)
@@ -84,7 +84,7 @@ func builtinPluginInstanceMethods() []*BuiltinMethodObject {
 					ok, err := fileExists(pluginDir)
 
 					if err != nil {
-						return t.vm.initErrorObject(errors.InternalError, sourceLine, err.Error())
+						return t.vm.initErrorObject(errors.InternalError, sourceLine, "%s", err.Error())
 					}
 
 					if !ok {
@@ -111,7 +111,7 @@ func builtinPluginInstanceMethods() []*BuiltinMethodObject {
 					p, err := compileAndOpenPlugin(soName, file.Name())
 
 					if err != nil {
-						t.vm.initErrorObject(errors.InternalError, sourceLine, err.Error())
+						t.vm.initErrorObject(errors.InternalError, sourceLine, "%s", err.Error())
 					}
 
 					r.plugin = p
@@ -136,13 +136,13 @@ func builtinPluginInstanceMethods() []*BuiltinMethodObject {
 					f, err := p.Lookup(funcName)
 
 					if err != nil {
-						return t.vm.initErrorObject(errors.InternalError, sourceLine, err.Error())
+						return t.vm.initErrorObject(errors.InternalError, sourceLine, "%s", err.Error())
 					}
 
 					funcArgs, err := convertToGoFuncArgs(args[1:])
 
 					if err != nil {
-						t.vm.initErrorObject(errors.TypeError, sourceLine, err.Error())
+						t.vm.initErrorObject(errors.TypeError, sourceLine, "%s", err.Error())
 					}
 
 					funcValue := reflect.ValueOf(f)
@@ -209,18 +209,18 @@ func setPluginContext(context Object) *pluginContext {
 
 	for _, f := range fs.Elements {
 		fInfos := f.(*HashObject)
-		prefix := fInfos.Pairs["prefix"].(*StringObject).value
-		name := fInfos.Pairs["name"].(*StringObject).value
+		prefix, _ := fInfos.getSymbol("prefix")
+		name, _ := fInfos.getSymbol("name")
 
-		pc.addFunc(prefix, name)
+		pc.addFunc(prefix.(*StringObject).value, name.(*StringObject).value)
 	}
 
 	for _, p := range ps.Elements {
 		pInfos := p.(*HashObject)
-		prefix := pInfos.Pairs["prefix"].(*StringObject).value
-		name := pInfos.Pairs["name"].(*StringObject).value
+		prefix, _ := pInfos.getSymbol("prefix")
+		name, _ := pInfos.getSymbol("name")
 
-		pc.importPkg(prefix, name)
+		pc.importPkg(prefix.(*StringObject).value, name.(*StringObject).value)
 	}
 
 	return pc
@@ -0,0 +1,294 @@
+package vm
+
+import (
+	"github.com/goby-lang/goby/compiler/bytecode"
+	"github.com/goby-lang/goby/vm/classes"
+	"github.com/goby-lang/goby/vm/errors"
+)
+
+// ProcObject represents a block that can be stored in a variable, returned and passed on explicitly.
+// A proc keeps the local variables and `self` of where it's created.
+//
+// ```ruby
+// add = lambda do |a, b|
+//   a + b
+// end
+// add.call(1, 2)  # => 3
+// add[1, 2]       # => 3
+//
+// double = ->(x) { x * 2 }
+// [1, 2].map(&double)   # => [2, 4]
+// ["a"].map(&:upcase)   # => ["A"]
+//
+// def foo(&block)
+//   block.call(10)
+// end
+// foo { |x| x + 1 }     # => 11
+// ```
+//
+// A lambda checks the number of arguments like a method does, while a proc created with `Proc.new` or `proc` doesn't.
+//
+// `return` in a block or a proc returns from the method where the block is defined, while `return` in a lambda only leaves the lambda.
+// The `ensure` clauses between the block and the method are run on the way.
+// Unlike Ruby, it leaves the block like `next` if the method has already returned or the block isn't defined in a method.
+//
+// ```ruby
+// def foo
+//   [1, 2, 3].each do |x|
+//     return x if x == 2
+//   end
+//   0
+// end
+// foo # => 2
+//
+// def bar
+//   ->() { return 1 }.call + 1
+// end
+// bar # => 2
+// ```
+type ProcObject struct {
+	*baseObj
+	blockFrame *normalCallFrame
+	isLambda   bool
+	argc       int
+}
+
+// Class methods --------------------------------------------------------
+func builtinProcClassMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Creates a proc from the given block.
+			//
+			// ```ruby
+			// p = Proc.new do |x|
+			//   x + 1
+			// end
+			// p.call(1) # => 2
+			// ```
+			//
+			// @return [Proc]
+			Name: "new",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if blockFrame == nil {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.CantCreateProcWithoutBlockFormat)
+					}
+
+					return t.vm.initProcObject(blockFrame, false)
+				}
+			},
+		},
+	}
+}
+
+// Instance methods -----------------------------------------------------
+func builtinProcInstanceMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Same as `call`.
+			//
+			// ```ruby
+			// ->(x) { x + 1 }[1] # => 2
+			// ```
+			//
+			// @return [Object]
+			Name: "[]",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					return receiver.(*ProcObject).call(t, args, sourceLine)
+				}
+			},
+		},
+		{
+			// Returns the number of the parameters the proc takes.
+			//
+			// ```ruby
+			// ->(x, y) { x + y }.arity # => 2
+			// proc { 1 }.arity         # => 0
+			// ```
+			//
+			// @return [Integer]
+			Name: "arity",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					return t.vm.initIntegerObject(receiver.(*ProcObject).argc)
+				}
+			},
+		},
+		{
+			// Executes the proc with the given arguments and returns the result.
+			// A lambda raises an ArgumentError if the number of the arguments is wrong.
+			//
+			// ```ruby
+			// add = ->(a, b) { a + b }
+			// add.call(1, 2) # => 3
+			// add.call(1)    # => ArgumentError
+			//
+			// p = proc do |a, b|
+			//   b
+			// end
+			// p.call(1)      # => nil
+			// ```
+			//
+			// @return [Object]
+			Name: "call",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					return receiver.(*ProcObject).call(t, args, sourceLine)
+				}
+			},
+		},
+		{
+			// Returns true if the proc is a lambda.
+			//
+			// ```ruby
+			// ->(x) { x }.lambda?      # => true
+			// proc { |x| x }.lambda?   # => false
+			// ```
+			//
+			// @return [Boolean]
+			Name: "lambda?",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					return toBooleanObject(receiver.(*ProcObject).isLambda)
+				}
+			},
+		},
+		{
+			// Returns the proc itself.
+			//
+			// @return [Proc]
+			Name: "to_proc",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					return receiver
+				}
+			},
+		},
+	}
+}
+
+// Internal functions ===================================================
+
+// Functions for initialization -----------------------------------------
+
+// initProcObject returns a proc of the block frame, a block passed from a lambda like `foo(&l)` is still a lambda
+func (vm *VM) initProcObject(blockFrame *normalCallFrame, isLambda bool) *ProcObject {
+	isLambda = isLambda || blockFrame.isLambda
+	blockFrame.isLambda = isLambda
+
+	return &ProcObject{
+		baseObj:    &baseObj{class: vm.topLevelClass(classes.ProcClass)},
+		blockFrame: blockFrame,
+		isLambda:   isLambda,
+		argc:       len(blockFrame.instructionSet.paramTypes.Types()),
+	}
+}
+
+// initSymbolProcObject returns a lambda that calls the symbol's method on its argument, like `:upcase.to_proc`
+func (vm *VM) initSymbolProcObject(s *SymbolObject, fileName string, sourceLine int) *ProcObject {
+	is := &instructionSet{name: s.value, filename: fileName, kind: bytecode.Block, paramTypes: &bytecode.ArgSet{}}
+	is.define(0, builtinActions[bytecode.GetLocal], 0, 0).sourceLine = sourceLine
+	is.define(1, builtinActions[bytecode.Send], s.value, 0, "", &bytecode.ArgSet{}).sourceLine = sourceLine
+	is.define(2, builtinActions[bytecode.Leave]).sourceLine = sourceLine
+
+	blockFrame := newNormalCallFrame(is, fileName)
+	blockFrame.isBlock = true
+	blockFrame.isLambda = true
+	blockFrame.self = s
+
+	return &ProcObject{
+		baseObj:    &baseObj{class: vm.topLevelClass(classes.ProcClass)},
+		blockFrame: blockFrame,
+		isLambda:   true,
+		argc:       1,
+	}
+}
+
+func (vm *VM) initProcClass() *RClass {
+	pc := vm.initializeClass(classes.ProcClass, false)
+	pc.setBuiltinMethods(builtinProcInstanceMethods(), false)
+	pc.setBuiltinMethods(builtinProcClassMethods(), true)
+	return pc
+}
+
+// Polymorphic helper functions -----------------------------------------
+
+// Value returns the proc's block frame
+func (p *ProcObject) Value() interface{} {
+	return p.blockFrame
+}
+
+// toString returns the object's name as the string format
+func (p *ProcObject) toString() string {
+	if p.isLambda {
+		return "<Proc (lambda)>"
+	}
+
+	return "<Proc>"
+}
+
+// toJSON just delegates to toString
+func (p *ProcObject) toJSON() string {
+	return p.toString()
+}
+
+// call executes the proc with the given arguments
+func (p *ProcObject) call(t *thread, args []Object, sourceLine int) Object {
+	if p.isLambda && len(args) != p.argc {
+		return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, p.argc, len(args))
+	}
+
+	return t.builtinMethodYield(p.blockFrame, args...).Target
+}
+
+// newBlockFrame returns a block frame for passing the proc to a method as its block
+func (p *ProcObject) newBlockFrame() *normalCallFrame {
+	c := newNormalCallFrame(p.blockFrame.instructionSet, p.blockFrame.FileName())
+	c.isBlock = true
+	c.isLambda = p.isLambda
+	c.ep = p.blockFrame.ep
+	c.self = p.blockFrame.self
+
+	return c
+}
+
+// Other helper functions -----------------------------------------------
+
+// blockFrameOf returns the block frame of the object passed as a block like `foo(&blk)`.
+// `nil` means no block, and other objects are converted with their `to_proc` method, like symbols.
+func (t *thread) blockFrameOf(obj Object, sourceLine int) (*normalCallFrame, *Error) {
+	switch obj := obj.(type) {
+	case *ProcObject:
+		return obj.newBlockFrame(), nil
+	case *NullObject:
+		return nil, nil
+	}
+
+	if obj.findMethod("to_proc") == nil {
+		return nil, t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.ProcClass, obj.Class().Name)
+	}
+
+	result := t.callMethod(obj, "to_proc", sourceLine)
+
+	switch result := result.(type) {
+	case *Error:
+		return nil, result
+	case *ProcObject:
+		return result.newBlockFrame(), nil
+	default:
+		return nil, t.vm.initErrorObject(errors.TypeError, sourceLine, "Expect %s#to_proc to return Proc. got: %s", obj.Class().Name, result.Class().Name)
+	}
+}
@@ -0,0 +1,465 @@
+package vm
+
+import "testing"
+
+func TestProcEvaluation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`lambda { |x| x }.class.name`, "Proc"},
+		{`
+		add = lambda do |a, b|
+		  a + b
+		end
+		add.call(1, 2)
+		`, 3},
+		{`->(x) { x * 2 }[5]`, 10},
+		{`->(x, y) { x - y }.call(5, 2)`, 3},
+		{`-> { 10 }.call`, 10},
+		{`Proc.new { |x| x + 1 }.call(1)`, 2},
+		{`proc { |x, y| y }.call(1)`, nil},
+		{`proc { |x| x }.call(1, 2)`, 1},
+		{`proc { |x, y| x }.arity`, 2},
+		{`->() { 1 }.arity`, 0},
+		{`:upcase.to_proc.arity`, 1},
+		{`proc { 1 }.lambda?`, false},
+		{`lambda { 1 }.lambda?`, true},
+		{`->(x) { x }.lambda?`, true},
+		{`:upcase.to_proc.lambda?`, true},
+		{`:upcase.to_proc.call("foo")`, "FOO"},
+		{`
+		p = proc { 1 }
+		p.to_proc == p
+		`, true},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestProcClosure(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		count = 0
+		inc = proc { count += 1 }
+		inc.call
+		inc.call
+		count
+		`, 2},
+		{`
+		def counter
+		  n = 0
+		  -> { n += 1 }
+		end
+
+		c = counter
+		c.call
+		c.call
+		`, 2},
+		{`
+		class Foo
+		  def initialize
+		    @x = 10
+		  end
+
+		  def adder
+		    ->(y) { @x + y }
+		  end
+		end
+
+		Foo.new.adder.call(5)
+		`, 15},
+		{`
+		def foo
+		  proc { yield(10) }
+		end
+
+		p = foo do |x|
+		  x * 3
+		end
+		p.call
+		`, 30},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestBlockParameterAndBlockPass(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		def foo(&block)
+		  block.call(10)
+		end
+
+		foo { |x| x + 1 }
+		`, 11},
+		{`
+		def foo(&block)
+		  block
+		end
+
+		foo
+		`, nil},
+		{`
+		def foo(a, b = 2, &block)
+		  block.call(a + b)
+		end
+
+		foo(1) do |x|
+		  x * 10
+		end
+		`, 30},
+		{`
+		def foo(*args, &block)
+		  block.call(args.length)
+		end
+
+		foo(1, 2, 3) { |n| n }
+		`, 3},
+		{`
+		def foo(&block)
+		  block.lambda?
+		end
+
+		foo { 1 }
+		`, false},
+		{`
+		def foo
+		  yield(5)
+		end
+
+		double = ->(x) { x * 2 }
+		foo(&double)
+		`, 10},
+		{`
+		def foo(&block)
+		  [1, 2, 3].map(&block)
+		end
+
+		foo { |x| x * x }.to_s
+		`, "[1, 4, 9]"},
+		{`["a", "b"].map(&:upcase).to_s`, `["A", "B"]`},
+		{`[1, 2].map(&:to_s).to_s`, `["1", "2"]`},
+		{`
+		def foo
+		  block_given?
+		end
+
+		foo(&nil)
+		`, false},
+		{`
+		def foo
+		  [1].map do |x|
+		    block_given?
+		  end.first
+		end
+
+		foo
+		`, false},
+		{`
+		def foo
+		  [1].map do |x|
+		    block_given?
+		  end.first
+		end
+
+		foo do
+		end
+		`, true},
+		{`
+		class Foo
+		  def to_proc
+		    ->(x) { x + 100 }
+		  end
+		end
+
+		[1, 2].map(&Foo.new).to_s
+		`, "[101, 102]"},
+		{`
+		def foo(&block)
+		  bar(&block)
+		end
+
+		def bar
+		  yield + 1
+		end
+
+		foo { 1 }
+		`, 2},
+		{`
+		def foo(&block)
+		  block.lambda?
+		end
+
+		foo(&->() { 1 })
+		`, true},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestReturnInBlock(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		def foo
+		  p = Proc.new do
+		    return 1
+		  end
+		  p.call
+		  2
+		end
+
+		foo
+		`, 1},
+		{`
+		def foo
+		  l = lambda do
+		    return 1
+		  end
+		  l.call + 1
+		end
+
+		foo
+		`, 2},
+		{`
+		def foo
+		  [1, 2, 3].each do |x|
+		    return x * 10 if x == 2
+		  end
+		  0
+		end
+
+		foo
+		`, 20},
+		{`
+		def foo
+		  [1, 2].each do |x|
+		    [3, 4].each do |y|
+		      return x * y if y == 4
+		    end
+		  end
+		  0
+		end
+
+		foo
+		`, 4},
+		{`
+		def foo
+		  l = ->() do
+		    [1, 2].each do |x|
+		      return x * 100
+		    end
+		    0
+		  end
+		  l.call + 1
+		end
+
+		foo
+		`, 101},
+		{`
+		def bar
+		  yield
+		  2
+		end
+
+		def foo
+		  bar do
+		    return 3
+		  end
+		  4
+		end
+
+		foo
+		`, 3},
+		{`
+		def bar(&block)
+		  block.call
+		  2
+		end
+
+		def foo
+		  bar(&->() { return 3 })
+		end
+
+		foo
+		`, 2},
+		{`
+		class Foo
+		  attr_reader :a
+
+		  def initialize
+		    @a = [1, 2].map do |x|
+		      return 5
+		    end
+		  end
+		end
+
+		Foo.new.a
+		`, nil},
+		{`
+		def foo
+		  Proc.new do
+		    return 1
+		  end
+		end
+
+		foo.call
+		`, 1},
+		{`
+		a = [1, 2].map do |x|
+		  return x * 2
+		end
+		a[1]
+		`, 4},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestReturnInBlockRunsEnsure(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []interface{}
+	}{
+		{`
+		$log = []
+
+		def helper
+		  yield
+		ensure
+		  $log.push("helper")
+		end
+
+		def outer
+		  helper { return 1 }
+		  2
+		end
+
+		outer
+		$log
+		`, []interface{}{"helper"}},
+		{`
+		$log = []
+
+		def helper
+		  yield
+		ensure
+		  $log.push("helper")
+		end
+
+		def outer
+		  begin
+		    [1, 2].each do |x|
+		      begin
+		        helper { return x }
+		      ensure
+		        $log.push("block")
+		      end
+		    end
+		  ensure
+		    $log.push("outer")
+		  end
+		  3
+		end
+
+		$log.push(outer)
+		$log
+		`, []interface{}{"helper", "block", "outer", 1}},
+		{`
+		$log = []
+
+		def helper
+		  begin
+		    yield
+		  rescue
+		    $log.push("rescue")
+		  end
+		  $log.push("after")
+		end
+
+		def outer
+		  helper { return 1 }
+		  2
+		end
+
+		$log.push(outer)
+		$log
+		`, []interface{}{1}},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		testArrayObject(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestProcMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`->(x) { x }.call`, "ArgumentError: Expect 1 arguments. got: 0", 1, 1},
+		{`lambda { |x, y| x }.call(1, 2, 3)`, "ArgumentError: Expect 2 arguments. got: 3", 1, 1},
+		{`Proc.new`, "ArgumentError: Can't create a Proc without a block", 1, 1},
+		{`lambda`, "ArgumentError: Can't create a Proc without a block", 1, 1},
+		{`proc { 1 }.arity(1)`, "ArgumentError: Expect 0 arguments. got: 1", 1, 1},
+		{`[1].map(&1)`, "TypeError: Expect argument to be Proc. got: Integer", 1, 1},
+		{`
+		class Foo
+		  def to_proc
+		    1
+		  end
+		end
+
+		[1].map(&Foo.new)
+		`, "TypeError: Expect Foo#to_proc to return Proc. got: Integer", 8, 1},
+		{`
+		def foo(&block)
+		  block
+		end
+
+		foo(1) { 1 }
+		`, "ArgumentError: Expect at most 0 args for method 'foo'. got: 1", 6, 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, tt.expectedCFP)
+		v.checkSP(t, i, 1)
+	}
+}
@@ -50,6 +50,40 @@ func builtinRangeClassMethods() []*BuiltinMethodObject {
 // Instance methods -----------------------------------------------------
 func builtinRangeInstanceMethods() []*BuiltinMethodObject {
 	return []*BuiltinMethodObject{
+		{
+			// Returns true if the given object is an Integer within the range, it's used by `case` expressions.
+			// Returns false for other objects instead of raising an error.
+			//
+			// ```ruby
+			// (1..5) === 3    # => true
+			// (1..5) === 6    # => false
+			// (1..5) === "3"  # => false
+			//
+			// case 3
+			// when 1..2 then "low"
+			// when 3..5 then "high"
+			// end             # => "high"
+			// ```
+			//
+			// @param object [Object]
+			// @return [Boolean]
+			Name: "===",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					i, ok := args[0].(*IntegerObject)
+
+					if !ok || i.bigValue != nil {
+						return FALSE
+					}
+
+					return toBooleanObject(receiver.(*RangeObject).include(i.value))
+				}
+			},
+		},
 		{
 			// Returns a Boolean of compared two ranges
 			//
@@ -303,10 +337,8 @@ func builtinRangeInstanceMethods() []*BuiltinMethodObject {
 					ran := receiver.(*RangeObject)
 
 					value := args[0].(*IntegerObject).value
-					ascendRangeBool := ran.Start <= ran.End && value >= ran.Start && value <= ran.End
-					descendRangeBool := ran.End <= ran.Start && value <= ran.Start && value >= ran.End
 
-					if ascendRangeBool || descendRangeBool {
+					if ran.include(value) {
 						return TRUE
 					}
 					return FALSE
@@ -486,12 +518,21 @@ func (vm *VM) initRangeClass() *RClass {
 	rc := vm.initializeClass(classes.RangeClass, false)
 	rc.setBuiltinMethods(builtinRangeInstanceMethods(), false)
 	rc.setBuiltinMethods(builtinRangeClassMethods(), true)
+	vm.libFiles = append(vm.libFiles, "range.gb")
 	return rc
 }
 
 // Polymorphic helper functions -----------------------------------------
 
 // toString returns the object's name as the string format
+// include returns true if the value is between the range's start and end, the range can be descending
+func (ro *RangeObject) include(value int) bool {
+	ascendRangeBool := ro.Start <= ro.End && value >= ro.Start && value <= ro.End
+	descendRangeBool := ro.End <= ro.Start && value <= ro.Start && value >= ro.End
+
+	return ascendRangeBool || descendRangeBool
+}
+
 func (ro *RangeObject) toString() string {
 	return fmt.Sprintf("(%d..%d)", ro.Start, ro.End)
 }
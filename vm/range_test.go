@@ -54,6 +54,31 @@ func TestRangeComparisonOperation(t *testing.T) {
 	}
 }
 
+func TestRangeCaseEqualityOperation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{`(1..3) === 1`, true},
+		{`(1..3) === 3`, true},
+		{`(1..3) === 4`, false},
+		{`(3..1) === 2`, true},
+		{`(-5..-1) === -3`, true},
+		{`(1..3) === "2"`, false},
+		{`(1..3) === 2.0`, false},
+		{`(1..3) === nil`, false},
+		{`(1..3) === 2 ** 70`, false},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestRangeBsearchMethod(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -32,11 +32,22 @@ import (
 // - Currently, UTF-8 encoding is assumed based upon Golang's string manipulation, but the encoding is not actually specified(TBD).
 // - `Regexp.new` is exceptionally supported.
 //
+// Regexp literals can be written with slashes or `%r` with any brackets, which is handy for patterns containing slashes.
+// Literals support the options `i` (ignore case), `m` (`.` matches newlines) and `x` (ignore whitespaces and comments in the pattern).
+// Interpolations like `/#{foo}/` aren't supported yet, `#{` has to be escaped like `/\#{foo}/` to match itself.
+// A slash after a local variable is a division, so `a /b` divides `a` by `b` if `a` is assigned or is a parameter.
+//
+// ```ruby
+// /orl/.match?("Hello World")        #=> true
+// /WORLD/i.match?("Hello World")     #=> true
+// %r{/usr/bin}.match?("/usr/bin/ls") #=> true
+// ```
+//
 // **To Goby maintainers**: avoid using Go's standard regexp package (slow and not rich). Consider the faster `Trim` or `Split` etc in Go's "strings" package first, or just use the dlclark/regexp2 instead.
-// ToDo: Regexp literals with '/.../'
 type RegexpObject struct {
 	*baseObj
-	Regexp *regexp2.Regexp
+	Regexp  *regexp2.Regexp
+	options string
 }
 
 // Class methods --------------------------------------------------------
@@ -69,7 +80,42 @@ func builtInRegexpClassMethods() []*BuiltinMethodObject {
 // Instance methods -----------------------------------------------------
 func builtinRegexpInstanceMethods() []*BuiltinMethodObject {
 	return []*BuiltinMethodObject{
+		{
+			// Returns true if the given object is a String that matches the regexp, it's used by `case` expressions.
+			// Returns false for other objects instead of raising an error.
+			//
+			// ```ruby
+			// /goby/ === "I love goby"  # => true
+			// /goby/ === "I love ruby"  # => false
+			// /goby/ === 1              # => false
+			//
+			// case "goby.gb"
+			// when /\.rb$/ then "ruby"
+			// when /\.gb$/ then "goby"
+			// end                       # => "goby"
+			// ```
+			//
+			// @param object [Object]
+			// @return [Boolean]
+			Name: "===",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
 
+					input, ok := args[0].(*StringObject)
+
+					if !ok {
+						return FALSE
+					}
+
+					m, _ := receiver.(*RegexpObject).Regexp.MatchString(input.value)
+
+					return toBooleanObject(m)
+				}
+			},
+		},
 		{
 			// Returns true if the two regexp patterns are exactly the same, or returns false if not.
 			// If comparing with non Regexp class, just returns false.
@@ -100,7 +146,7 @@ func builtinRegexpInstanceMethods() []*BuiltinMethodObject {
 
 					left := receiver.(*RegexpObject)
 
-					if left.Value() == right.Value() {
+					if left.Value() == right.Value() && left.options == right.options {
 						return TRUE
 					}
 					return FALSE
@@ -151,9 +197,15 @@ func (vm *VM) initRegexpObject(regexp string) *RegexpObject {
 	if err != nil {
 		return nil
 	}
+	return vm.initCompiledRegexpObject(r, "")
+}
+
+// initCompiledRegexpObject wraps a compiled regexp, so a regexp literal is compiled only once no matter how many times it's evaluated
+func (vm *VM) initCompiledRegexpObject(r *regexp2.Regexp, options string) *RegexpObject {
 	return &RegexpObject{
 		baseObj: &baseObj{class: vm.topLevelClass(classes.RegexpClass)},
 		Regexp:  r,
+		options: options,
 	}
 }
 
@@ -185,3 +237,24 @@ func (r *RegexpObject) toJSON() string {
 func (r *RegexpObject) equal(e *RegexpObject) bool {
 	return r.toString() == r.toString()
 }
+
+// Other helper functions -----------------------------------------------
+
+// regexpOptions converts the options of a regexp literal like `/foo/im` to regexp2's.
+// Ruby's `m` option makes `.` match newlines, which is called single line mode in regexp2.
+func regexpOptions(options string) regexp2.RegexOptions {
+	var result regexp2.RegexOptions
+
+	for _, option := range options {
+		switch option {
+		case 'i':
+			result |= regexp2.IgnoreCase
+		case 'm':
+			result |= regexp2.Singleline
+		case 'x':
+			result |= regexp2.IgnorePatternWhitespace
+		}
+	}
+
+	return result
+}
@@ -67,6 +67,27 @@ func TestRegexpDoubleEqual(t *testing.T) {
 	}
 }
 
+func TestRegexpCaseEquality(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`/Goby[0-9]+/ === "I like Goby123"`, true},
+		{`/goby/i === "GOBY"`, true},
+		{`/Goby[0-9]+/ === "I like Goby"`, false},
+		{`/1/ === 1`, false},
+		{`/a/ === ["a"]`, false},
+		{`/a/ === nil`, false},
+	}
+
+	for i, tt := range tests {
+		vm := initTestVM()
+		evaluated := vm.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		vm.checkCFP(t, i, 0)
+	}
+}
+
 func TestRegexpMatchQuestionMark(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -183,3 +204,96 @@ func TestRegexpMatchQuestionMarkFail(t *testing.T) {
 		v.checkSP(t, i, 1)
 	}
 }
+
+func TestRegexpLiteral(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`/orl/.match?("Hello World")`, true},
+		{`/WORLD/.match?("Hello World")`, false},
+		{`/WORLD/i.match?("Hello World")`, true},
+		{`/a.b/.match?("a
+b")`, false},
+		{`/a.b/m.match?("a
+b")`, true},
+		{`/a b # comment
+		  c/x.match?("abc")`, true},
+		{`/a\/b/.match?("a/b")`, true},
+		{`%r{/usr/bin}.match?("/usr/bin/ls")`, true},
+		{`%r{a{2}}.match?("aa")`, true},
+		{`%r(a(b)c).match?("abc")`, true},
+		{`/goby/.class.name`, "Regexp"},
+		{`/goby/ == Regexp.new("goby")`, true},
+		{`/goby/i == /goby/i`, true},
+		{`/goby/i == /goby/`, false},
+		{`
+		a = 10
+		b = 2
+		a / b + a/b
+		`, 10},
+		{`
+		a = 10
+		b = 2
+		a /b
+		`, 5},
+		{`'#{a}' =~ /\#{a}/`, 0},
+		{`
+		def foo(r)
+		  r.match?("bar")
+		end
+
+		foo /bar/
+		`, true},
+		{`"foo" =~ /o/`, 1},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestRegexpLiteralCompiledOnce(t *testing.T) {
+	input := `
+	rs = []
+	i = 0
+
+	while i < 2 do
+	  rs.push(/foo/)
+	  i += 1
+	end
+
+	rs
+	`
+
+	v := initTestVM()
+	evaluated := v.testEval(t, input, getFilename())
+	rs := evaluated.(*ArrayObject).Elements
+	first, second := rs[0].(*RegexpObject), rs[1].(*RegexpObject)
+
+	if first == second {
+		t.Fatalf("Expect every evaluation to return a new Regexp object")
+	}
+
+	if first.Regexp != second.Regexp {
+		t.Fatalf("Expect the regexp literal to be compiled only once")
+	}
+}
+
+func TestRegexpLiteralFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`/(/`, "ArgumentError: Invalid regexp: /(/. error parsing regexp: missing closing ) in `(`", 1, 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, tt.expectedCFP)
+		v.checkSP(t, i, 1)
+	}
+}
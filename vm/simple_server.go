@@ -216,8 +216,8 @@ func setupResponse(w http.ResponseWriter, req *http.Request, res *RObject) {
 	h, ok := res.instanceVariableGet("@headers")
 
 	if headers, isHashObject := h.(*HashObject); ok && isHashObject {
-		for k, v := range headers.Pairs {
-			w.Header().Set(k, v.(*StringObject).value)
+		for _, p := range headers.pairs {
+			w.Header().Set(hashKeyString(p.key), p.value.(*StringObject).value)
 		}
 	} else {
 		r.contentType = "text/plain; charset=utf-8"
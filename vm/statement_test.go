@@ -547,6 +547,81 @@ func TestWhileStatement(t *testing.T) {
 	}
 }
 
+func TestUntilStatement(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		i = 0
+		until i == 10 do
+		  i += 1
+		end
+
+		i
+		`, 10},
+		{`
+		i = 0
+		until true do
+		  i += 1
+		end
+
+		i
+		`, 0},
+		{`
+		i = 0
+		i += 1 while i < 10
+		i
+		`, 10},
+		{`
+		i = 10
+		i -= 1 until i < 5
+		i
+		`, 4},
+		{`
+		i = 10
+		begin
+		  i += 1
+		end while i < 5
+
+		i
+		`, 11},
+		{`
+		i = 0
+		begin
+		  i += 1
+		end until i >= 3
+
+		i
+		`, 3},
+		{`
+		a = []
+		i = 0
+		begin
+		  i += 1
+		  next if i.even?
+		  break if i > 6
+		  a.push(i)
+		end while i < 100
+
+		a.to_s
+		`, "[1, 3, 5]"},
+		{`
+		i = 0
+		i += 1 while i < 5 if true
+		i
+		`, 5},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestNextStatement(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -1,12 +1,15 @@
 package vm
 
 import (
+	"bytes"
+	"encoding/base64"
 	"fmt"
 	"strconv"
 	"strings"
 	"unicode"
 	"unicode/utf8"
 
+	"github.com/dlclark/regexp2"
 	"github.com/goby-lang/goby/vm/classes"
 	"github.com/goby-lang/goby/vm/errors"
 )
@@ -39,8 +42,7 @@ func builtinStringClassMethods() []*BuiltinMethodObject {
 	return []*BuiltinMethodObject{
 		{
 			// The String.fmt implements formatted I/O with functions analogous to C's printf and scanf
-			// Currently only support plain "%s" formatting
-			// TODO: Support other kind of formatting such as %f, %v ... etc
+			// It only supports plain "%s" formatting, `format` supports all of the directives.
 			//
 			// ```ruby
 			// String.fmt("Hello! %s Lang!", "Goby")                    # => "Hello! Goby Lang!"
@@ -152,6 +154,37 @@ func builtinStringInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Returns the string formatted with the given object, or with the elements of the given array,
+			// or with the values of the given hash. It supports the same directives as `format`.
+			//
+			// ```ruby
+			// "%s is %d years old" % ["Goby", 5]  # => "Goby is 5 years old"
+			// "%05.2f" % 3.14159                   # => "03.14"
+			// "%-6s|" % "ab"                       # => "ab    |"
+			// "%x %o %b" % [255, 8, 5]             # => "ff 10 101"
+			// "%{name} is %<age>d" % { name: "Goby", age: 5 } # => "Goby is 5"
+			// ```
+			//
+			// @param arguments [Object]
+			// @return [String]
+			Name: "%",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					format := receiver.(*StringObject).value
+
+					if arr, ok := args[0].(*ArrayObject); ok {
+						return t.sprintf(format, arr.Elements, sourceLine)
+					}
+
+					return t.sprintf(format, args, sourceLine)
+				}
+			},
+		},
 		{
 			// Returns a Boolean if first string greater than second string
 			//
@@ -381,7 +414,7 @@ func builtinStringInstanceMethods() []*BuiltinMethodObject {
 
 					if indexValue < 0 {
 						strLength := utf8.RuneCountInString(str)
-						if -indexValue > strLength {
+						if indexValue < -strLength {
 							return NULL
 						}
 						return t.vm.initStringObject(string([]rune(str)[strLength+indexValue]))
@@ -443,7 +476,7 @@ func builtinStringInstanceMethods() []*BuiltinMethodObject {
 
 					// Negative Index Case
 					if indexValue < 0 {
-						if -indexValue > strLength {
+						if indexValue < -strLength {
 							return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "Index value out of range. got=%v", strconv.Itoa(indexValue))
 						}
 						// Change to positive index to replace the string
@@ -459,6 +492,33 @@ func builtinStringInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Returns an array of the bytes of the string.
+			//
+			// ```ruby
+			// "abc".bytes # => [97, 98, 99]
+			// "é".bytes   # => [195, 169]
+			// ```
+			//
+			// @return [Array]
+			Name: "bytes",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					str := receiver.(*StringObject).value
+					elems := make([]Object, len(str))
+
+					for i := 0; i < len(str); i++ {
+						elems[i] = t.vm.initIntegerObject(int(str[i]))
+					}
+
+					return t.vm.initArrayObject(elems)
+				}
+			},
+		},
 		{
 			// Return a new String with the first character converted to uppercase but the rest of string converted to lowercase.
 			//
@@ -483,6 +543,145 @@ func builtinStringInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Compares the string with another string ignoring the case of the characters.
+			// Returns -1, 0 or 1 like `<=>`.
+			//
+			// ```ruby
+			// "abc".casecmp("ABC") # => 0
+			// "abc".casecmp("ABD") # => -1
+			// "ÄB".casecmp("äa")   # => 1
+			// ```
+			//
+			// @param other [String]
+			// @return [Integer]
+			Name: "casecmp",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					other, ok := args[0].(*StringObject)
+
+					if !ok {
+						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.StringClass, args[0].Class().Name)
+					}
+
+					str := receiver.(*StringObject).value
+					return t.vm.initIntegerObject(strings.Compare(strings.ToLower(str), strings.ToLower(other.value)))
+				}
+			},
+		},
+		{
+			// Returns true if the string is equal to another string when the case of the characters is ignored.
+			//
+			// ```ruby
+			// "abc".casecmp?("ABC") # => true
+			// "äöü".casecmp?("ÄÖÜ") # => true
+			// "abc".casecmp?("abd") # => false
+			// ```
+			//
+			// @param other [String]
+			// @return [Boolean]
+			Name: "casecmp?",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					other, ok := args[0].(*StringObject)
+
+					if !ok {
+						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.StringClass, args[0].Class().Name)
+					}
+
+					str := receiver.(*StringObject).value
+					return toBooleanObject(strings.EqualFold(str, other.value))
+				}
+			},
+		},
+		{
+			// Centers the string in a string of the given width, padded with spaces or with the given padding string.
+			// Returns the string itself if the width is less than its length.
+			//
+			// ```ruby
+			// "abc".center(7)       # => "  abc  "
+			// "abc".center(8, "12") # => "12abc121"
+			// "漢字".center(6, "*") # => "**漢字**"
+			// ```
+			//
+			// @param width [Integer]
+			// @param padding [String]
+			// @return [String]
+			Name: "center",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 1 && len(args) != 2 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentRangeFormat, 1, 2, len(args))
+					}
+
+					width, ok := args[0].(*IntegerObject)
+
+					if !ok {
+						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.IntegerClass, args[0].Class().Name)
+					}
+
+					pad := " "
+
+					if len(args) == 2 {
+						padStr, ok := args[1].(*StringObject)
+
+						if !ok {
+							return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.StringClass, args[1].Class().Name)
+						}
+
+						if padStr.value == "" {
+							return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "zero width padding")
+						}
+
+						pad = padStr.value
+					}
+
+					str := receiver.(*StringObject).value
+					total := width.value - utf8.RuneCountInString(str)
+
+					if total <= 0 {
+						return t.vm.initStringObject(str)
+					}
+
+					left := total / 2
+					return t.vm.initStringObject(padding(pad, left) + str + padding(pad, total-left))
+				}
+			},
+		},
+		{
+			// Returns an array of the characters of the string.
+			//
+			// ```ruby
+			// "abc".chars  # => ["a", "b", "c"]
+			// "😊🍣".chars # => ["😊", "🍣"]
+			// ```
+			//
+			// @return [Array]
+			Name: "chars",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					elems := []Object{}
+
+					for _, r := range receiver.(*StringObject).value {
+						elems = append(elems, t.vm.initStringObject(string(r)))
+					}
+
+					return t.vm.initArrayObject(elems)
+				}
+			},
+		},
 		{
 			// Returns a string with the last character chopped
 			//
@@ -505,6 +704,33 @@ func builtinStringInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Returns the first character of the string, or an empty string if it's empty.
+			//
+			// ```ruby
+			// "abc".chr  # => "a"
+			// "漢字".chr # => "漢"
+			// ```
+			//
+			// @return [String]
+			Name: "chr",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					str := receiver.(*StringObject).value
+
+					if str == "" {
+						return t.vm.initStringObject("")
+					}
+
+					r, _ := utf8.DecodeRuneInString(str)
+					return t.vm.initStringObject(string(r))
+				}
+			},
+		},
 		{
 			// Returns a string which is concatenate with the input string or character
 			//
@@ -811,43 +1037,46 @@ func builtinStringInstanceMethods() []*BuiltinMethodObject {
 			},
 		},
 		{
-			// Returns a copy of str with the all occurrences of pattern substituted for the second argument.
-			// The pattern is typically a String or Regexp (Not implemented yet); if given as a String, any
-			// regular expression metacharacters it contains will be interpreted literally, e.g. '\\d' will
-			// match a backslash followed by ‘d’, instead of a digit.
+			// Returns a copy of the string with every match of the pattern replaced, the pattern can be a String or a Regexp.
 			//
-			// Currently only support string version of String#gsub.
+			// The replacement can be a String, where `\0` is the matched text, `\1` to `\9` are the regexp's groups
+			// and `\k<name>` is a named group. It can also be a Hash whose values replace the matched texts that are its keys.
+			// Without a replacement, the block is called with the matched text and its result is the replacement.
+			// The Hash's values and the block's results are converted with `to_s`, so `nil` removes the matched text.
 			//
 			// ```ruby
-			// "Ruby Lang".gsub("Ru", "Go")                # => "Goby Lang"
-			// "Hello 😊 Hello 😊 Hello".gsub("😊", "🐟") # => "Hello 🐟 Hello 🐟 Hello"
+			// "Ruby is fun".gsub("Ruby", "Goby")                 # => "Goby is fun"
+			// "John Smith".gsub(/(\w+) (\w+)/, "\\2 \\1")        # => "Smith John"
+			// "cat hat".gsub(/[ch]/, { "c" => "b", "h" => "m" }) # => "bat mat"
+			// "1 2 3".gsub(/\d/) do |d|
+			//   d.to_i * 2
+			// end
+			// # => "2 4 6"
 			// ```
 			//
+			// @param pattern [String, Regexp]
+			// @param replacement [String, Hash]
 			// @return [String]
 			Name: "gsub",
 			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
-					if len(args) != 2 {
-						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "Expect 2 arguments. got=%v", len(args))
-					}
-
-					str := receiver.(*StringObject).value
-
-					p := args[0]
-					pattern, ok := p.(*StringObject)
-
-					if !ok {
-						return t.vm.initErrorObject(errors.TypeError, sourceLine, "Expect pattern to be String. got: %s", p.Class().Name)
-					}
-
-					r := args[1]
-					replacement, ok := r.(*StringObject)
-
-					if !ok {
-						return t.vm.initErrorObject(errors.TypeError, sourceLine, "Expect replacement to be String. got: %s", r.Class().Name)
-					}
-
-					return t.vm.initStringObject(strings.Replace(str, pattern.value, replacement.value, -1))
+					return t.substitute(receiver, args, blockFrame, true, sourceLine)
+				}
+			},
+		},
+		{
+			// Returns the hash value of the string, which is the same for strings that have the same content.
+			//
+			// ```ruby
+			// "a".hash == "a".hash # => true
+			// "a".hash == :a.hash  # => false
+			// ```
+			//
+			// @return [Integer]
+			Name: "hash",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					return t.hashMethodResult(receiver, sourceLine)
 				}
 			},
 		},
@@ -883,6 +1112,65 @@ func builtinStringInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Returns the index of the first occurrence of the pattern, which can be a String or a Regexp, or nil if there's none.
+			// The search starts at the given index, which can be negative to count from the end. Indexes are counted in characters.
+			//
+			// ```ruby
+			// "hello".index("l")      # => 2
+			// "hello".index("l", 3)   # => 3
+			// "hello".index(/[aeiou]/, -2) # => 4
+			// "こんにちは".index("に") # => 2
+			// "hello".index("z")      # => nil
+			// ```
+			//
+			// @param pattern [String, Regexp]
+			// @param start [Integer]
+			// @return [Integer]
+			Name: "index",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 1 && len(args) != 2 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentRangeFormat, 1, 2, len(args))
+					}
+
+					re, err := t.patternRegexp(args[0], sourceLine)
+
+					if err != nil {
+						return err
+					}
+
+					runes := []rune(receiver.(*StringObject).value)
+					start := 0
+
+					if len(args) == 2 {
+						s, ok := args[1].(*IntegerObject)
+
+						if !ok {
+							return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.IntegerClass, args[1].Class().Name)
+						}
+
+						start = s.value
+
+						if start < 0 {
+							start += len(runes)
+						}
+
+						if start < 0 || start > len(runes) {
+							return NULL
+						}
+					}
+
+					m, _ := re.FindRunesMatchStartingAt(runes, start)
+
+					if m == nil {
+						return NULL
+					}
+
+					return t.vm.initIntegerObject(m.Index)
+				}
+			},
+		},
 		{
 			// Insert a string input in specified index value of the receiver string
 			//
@@ -925,7 +1213,7 @@ func builtinStringInstanceMethods() []*BuiltinMethodObject {
 					strLength := utf8.RuneCountInString(str)
 
 					if indexValue < 0 {
-						if -indexValue > strLength+1 {
+						if indexValue < -(strLength + 1) {
 							return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "Index value out of range. got=%v", indexValue)
 						} else if -indexValue == strLength+1 {
 							return t.vm.initStringObject(insertStr.value + str)
@@ -965,6 +1253,35 @@ func builtinStringInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Returns an array of the lines of the string, each line keeps its "\n".
+			//
+			// ```ruby
+			// "a\nb\nc".lines # => ["a\n", "b\n", "c"]
+			// "".lines        # => []
+			// ```
+			//
+			// @return [Array]
+			Name: "lines",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					str := receiver.(*StringObject).value
+					elems := []Object{}
+
+					for _, line := range strings.SplitAfter(str, "\n") {
+						if line != "" {
+							elems = append(elems, t.vm.initStringObject(line))
+						}
+					}
+
+					return t.vm.initArrayObject(elems)
+				}
+			},
+		},
 		{
 			// If input integer is greater than the length of receiver string, returns a new String of
 			// length integer with receiver string left justified and padded with default " "; otherwise,
@@ -1027,6 +1344,26 @@ func builtinStringInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Returns a copy of the string without the leading whitespaces.
+			//
+			// ```ruby
+			// "  \t abc  ".lstrip # => "abc  "
+			// ```
+			//
+			// @return [String]
+			Name: "lstrip",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					str := receiver.(*StringObject).value
+					return t.vm.initStringObject(strings.TrimLeft(str, stripCutset))
+				}
+			},
+		},
 		{
 			// Returns the matching data of the regex with the given string.
 			//
@@ -1065,29 +1402,113 @@ func builtinStringInstanceMethods() []*BuiltinMethodObject {
 			},
 		},
 		{
-			// Return a string replaced by the input string
+			// Returns the successor of the string, see `succ`.
 			//
 			// ```ruby
-			// "Hello".replace("World")          # => "World"
-			// "你好"replace("再見")              # => "再見"
-			// "Ruby\nLang".replace("Goby\nLang") # => "Goby Lang"
-			// "Hello😊".replace("World🐟")      # => "World🐟"
+			// "a9".next # => "b0"
 			// ```
 			//
 			// @return [String]
-			Name: "replace",
+			Name: "next",
 			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
-					if len(args) != 1 {
-						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "Expect 1 argument. got=%v", strconv.Itoa(len(args)))
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
 					}
 
-					r := args[0]
-					replaceStr, ok := r.(*StringObject)
-
-					if !ok {
-						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.StringClass, r.Class().Name)
-					}
+					return t.vm.initStringObject(successor(receiver.(*StringObject).value))
+				}
+			},
+		},
+		{
+			// Returns the Unicode code point of the first character of the string, `Integer#chr` converts it back.
+			//
+			// ```ruby
+			// "a".ord  # => 97
+			// "漢".ord # => 28450
+			// ```
+			//
+			// @return [Integer]
+			Name: "ord",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					str := receiver.(*StringObject).value
+
+					if str == "" {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "empty string")
+					}
+
+					r, _ := utf8.DecodeRuneInString(str)
+					return t.vm.initIntegerObject(int(r))
+				}
+			},
+		},
+		{
+			// Splits the string at the first match of the pattern, which can be a String or a Regexp.
+			// Returns an array of the part before the match, the match and the part after it,
+			// or the string and two empty strings if there's no match.
+			//
+			// ```ruby
+			// "key=value=1".partition("=") # => ["key", "=", "value=1"]
+			// "a1b22c".partition(/\d+/)    # => ["a", "1", "b22c"]
+			// "abc".partition("x")         # => ["abc", "", ""]
+			// ```
+			//
+			// @param pattern [String, Regexp]
+			// @return [Array]
+			Name: "partition",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					re, err := t.patternRegexp(args[0], sourceLine)
+
+					if err != nil {
+						return err
+					}
+
+					runes := []rune(receiver.(*StringObject).value)
+					matches := findMatches(re, runes, 1)
+
+					if len(matches) == 0 {
+						return t.vm.initStringPartition(string(runes), "", "")
+					}
+
+					m := matches[0]
+					return t.vm.initStringPartition(string(runes[:m.Index]), m.String(), string(runes[m.Index+m.Length:]))
+				}
+			},
+		},
+		{
+			// Return a string replaced by the input string
+			//
+			// ```ruby
+			// "Hello".replace("World")          # => "World"
+			// "你好"replace("再見")              # => "再見"
+			// "Ruby\nLang".replace("Goby\nLang") # => "Goby Lang"
+			// "Hello😊".replace("World🐟")      # => "World🐟"
+			// ```
+			//
+			// @return [String]
+			Name: "replace",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "Expect 1 argument. got=%v", strconv.Itoa(len(args)))
+					}
+
+					r := args[0]
+					replaceStr, ok := r.(*StringObject)
+
+					if !ok {
+						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.StringClass, r.Class().Name)
+					}
 
 					return t.vm.initStringObject(replaceStr.value)
 				}
@@ -1120,6 +1541,65 @@ func builtinStringInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Returns the index of the last occurrence of the pattern, which can be a String or a Regexp, or nil if there's none.
+			// The search goes backwards from the given index, which can be negative to count from the end. Indexes are counted in characters.
+			//
+			// ```ruby
+			// "hello".rindex("l")      # => 3
+			// "hello".rindex("l", 2)   # => 2
+			// "hello".rindex(/[aeiou]/) # => 4
+			// "こんにちは".rindex("ん") # => 1
+			// "hello".rindex("z")      # => nil
+			// ```
+			//
+			// @param pattern [String, Regexp]
+			// @param start [Integer]
+			// @return [Integer]
+			Name: "rindex",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 1 && len(args) != 2 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentRangeFormat, 1, 2, len(args))
+					}
+
+					re, err := t.patternRegexp(args[0], sourceLine)
+
+					if err != nil {
+						return err
+					}
+
+					runes := []rune(receiver.(*StringObject).value)
+					start := len(runes)
+
+					if len(args) == 2 {
+						s, ok := args[1].(*IntegerObject)
+
+						if !ok {
+							return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.IntegerClass, args[1].Class().Name)
+						}
+
+						if s.value < 0 {
+							start = s.value + len(runes)
+						} else if s.value < len(runes) {
+							start = s.value
+						}
+
+						if start < 0 {
+							return NULL
+						}
+					}
+
+					m := lastMatch(re, runes, start)
+
+					if m == nil {
+						return NULL
+					}
+
+					return t.vm.initIntegerObject(m.Index)
+				}
+			},
+		},
 		{
 			// If input integer is greater than the length of receiver string, returns a new String of
 			// length integer with receiver string right justified and padded with default " "; otherwise,
@@ -1186,6 +1666,121 @@ func builtinStringInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Splits the string at the last match of the pattern, which can be a String or a Regexp.
+			// Returns an array of the part before the match, the match and the part after it,
+			// or two empty strings and the string if there's no match.
+			//
+			// ```ruby
+			// "key=value=1".rpartition("=") # => ["key=value", "=", "1"]
+			// "abc".rpartition("x")         # => ["", "", "abc"]
+			// ```
+			//
+			// @param pattern [String, Regexp]
+			// @return [Array]
+			Name: "rpartition",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					re, err := t.patternRegexp(args[0], sourceLine)
+
+					if err != nil {
+						return err
+					}
+
+					runes := []rune(receiver.(*StringObject).value)
+					m := lastMatch(re, runes, len(runes))
+
+					if m == nil {
+						return t.vm.initStringPartition("", "", string(runes))
+					}
+
+					return t.vm.initStringPartition(string(runes[:m.Index]), m.String(), string(runes[m.Index+m.Length:]))
+				}
+			},
+		},
+		{
+			// Returns a copy of the string without the trailing whitespaces.
+			//
+			// ```ruby
+			// "  abc \n".rstrip # => "  abc"
+			// ```
+			//
+			// @return [String]
+			Name: "rstrip",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					str := receiver.(*StringObject).value
+					return t.vm.initStringObject(strings.TrimRight(str, stripCutset))
+				}
+			},
+		},
+		{
+			// Returns an array of the matches of the pattern, which can be a String or a Regexp.
+			// If the regexp has groups, each match is an array of the groups' texts instead.
+			// With a block, each match is passed to the block and the string itself is returned.
+			//
+			// ```ruby
+			// "a1 b22 c333".scan(/\d+/)        # => ["1", "22", "333"]
+			// "a=1, b=2".scan(/(\w)=(\d)/)     # => [["a", "1"], ["b", "2"]]
+			// "a=1, b=2".scan(/(\w)=(\d)/) do |key, value|
+			//   puts(key + value)
+			// end
+			// ```
+			//
+			// @param pattern [String, Regexp]
+			// @return [Array]
+			Name: "scan",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					re, err := t.patternRegexp(args[0], sourceLine)
+
+					if err != nil {
+						return err
+					}
+
+					runes := []rune(receiver.(*StringObject).value)
+					results := []Object{}
+
+					for _, m := range findMatches(re, runes, -1) {
+						var result Object = t.vm.initStringObject(m.String())
+
+						if m.GroupCount() > 1 {
+							groups := make([]Object, m.GroupCount()-1)
+
+							for i := range groups {
+								groups[i] = t.vm.groupObject(m.GroupByNumber(i + 1))
+							}
+
+							result = t.vm.initArrayObject(groups)
+						}
+
+						if blockFrame != nil {
+							t.builtinMethodYield(blockFrame, result)
+						} else {
+							results = append(results, result)
+						}
+					}
+
+					if blockFrame != nil {
+						return receiver
+					}
+
+					return t.vm.initArrayObject(results)
+				}
+			},
+		},
 		{
 			// Returns the character length of self
 			// **Note:** the length is currently byte-based, instead of charcode-based.
@@ -1298,7 +1893,7 @@ func builtinStringInstanceMethods() []*BuiltinMethodObject {
 					case *IntegerObject:
 						intValue := args[0].(*IntegerObject).value
 						if intValue < 0 {
-							if -intValue > strLength {
+							if intValue < -strLength {
 								return NULL
 							}
 							return t.vm.initStringObject(string([]rune(str)[strLength+intValue]))
@@ -1315,15 +1910,20 @@ func builtinStringInstanceMethods() []*BuiltinMethodObject {
 			},
 		},
 		{
-			// Returns an array of strings separated by the given separator
+			// Returns an array of strings separated by the given separator, which can be a String or a Regexp.
+			// The groups captured by a Regexp separator are put into the array too.
 			//
 			// ```ruby
 			// "Hello World".split("o") # => ["Hell", " W", "rld"]
 			// "Goby".split("")         # => ["G", "o", "b", "y"]
 			// "Hello\nWorld\nGoby".split("o") # => ["Hello", "World", "Goby"]
 			// "Hello🐟World🐟Goby".split("🐟") # => ["Hello", "World", "Goby"]
+			// "a, b,c".split(/,\s*/)    # => ["a", "b", "c"]
+			// "a1b2c".split(/(\d)/)     # => ["a", "1", "b", "2", "c"]
+			// "Goby".split(//)         # => ["G", "o", "b", "y"]
 			// ```
 			//
+			// @param separator [String, Regexp]
 			// @return [Array]
 			Name: "split",
 			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
@@ -1332,16 +1932,18 @@ func builtinStringInstanceMethods() []*BuiltinMethodObject {
 						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "Expect 1 argument. got=%v", strconv.Itoa(len(args)))
 					}
 
-					s := args[0]
-					seperator, ok := s.(*StringObject)
+					str := receiver.(*StringObject).value
+					var arr []string
 
-					if !ok {
-						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.StringClass, s.Class().Name)
+					switch seperator := args[0].(type) {
+					case *StringObject:
+						arr = strings.Split(str, seperator.value)
+					case *RegexpObject:
+						arr = splitByRegexp(seperator.Regexp, []rune(str))
+					default:
+						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.StringClass+" or "+classes.RegexpClass, args[0].Class().Name)
 					}
 
-					str := receiver.(*StringObject).value
-					arr := strings.Split(str, seperator.value)
-
 					var elements []Object
 					for i := 0; i < len(arr); i++ {
 						elements = append(elements, t.vm.initStringObject(arr[i]))
@@ -1352,43 +1954,75 @@ func builtinStringInstanceMethods() []*BuiltinMethodObject {
 			},
 		},
 		{
-			// Returns true if receiver string start with the argument string
+			// Returns a copy of the string where runs of the same character are replaced by a single character.
+			// With character sets written like the arguments of `tr`, only the characters in all of the sets are squeezed.
 			//
 			// ```ruby
-			// "Hello".start_with("Hel")     # => true
-			// "Hello".start_with("hel")     # => false
-			// "😊Hello🐟".start_with("😊") # => true
-			// "😊Hello🐟".start_with("🐟") # => false
+			// "aaabbbccc".squeeze      # => "abc"
+			// "aaabbbccc".squeeze("a-b") # => "abccc"
+			// "too    many spaces".squeeze(" ") # => "too many spaces"
 			// ```
 			//
-			// @return [Boolean]
-			Name: "start_with",
+			// @param sets [String]
+			// @return [String]
+			Name: "squeeze",
 			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
-					if len(args) != 1 {
-						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "Expect 1 argument. got=%v", strconv.Itoa(len(args)))
-					}
+					sets, err := t.charSetsArguments(args, sourceLine)
 
-					str := receiver.(*StringObject).value
-					c := args[0]
-					compareStr, ok := c.(*StringObject)
-
-					if !ok {
-						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.StringClass, c.Class().Name)
+					if err != nil {
+						return err
 					}
 
-					compareStrValue := compareStr.value
-					compareStrLength := utf8.RuneCountInString(compareStrValue)
-					strLength := utf8.RuneCountInString(str)
+					var out strings.Builder
+					prev := rune(-1)
 
-					if compareStrLength > strLength {
-						return FALSE
-					}
+					for _, r := range receiver.(*StringObject).value {
+						if r == prev && includedInAll(sets, r) {
+							continue
+						}
 
-					if compareStrValue == string([]rune(str)[:compareStrLength]) {
-						return TRUE
+						out.WriteRune(r)
+						prev = r
 					}
-					return FALSE
+
+					return t.vm.initStringObject(out.String())
+				}
+			},
+		},
+		{
+			// Returns true if the string starts with one of the given prefixes, which can be Strings or Regexps.
+			//
+			// ```ruby
+			// "Hello".start_with?("Hel")         # => true
+			// "Hello".start_with?("hel")         # => false
+			// "Hello".start_with?("x", "He")     # => true
+			// "Hello".start_with?(/h/i)          # => true
+			// "😊Hello🐟".start_with?("😊")     # => true
+			// ```
+			//
+			// @param prefixes [String, Regexp]
+			// @return [Boolean]
+			Name: "start_with?",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					return t.startWith(receiver.(*StringObject).value, args, sourceLine)
+				}
+			},
+		},
+		{
+			// Deprecated: use `start_with?` instead. It's kept as an alias of `start_with?` for the scripts written before the rename.
+			//
+			// ```ruby
+			// "Hello".start_with("Hel") # => true
+			// ```
+			//
+			// @param prefixes [String, Regexp]
+			// @return [Boolean]
+			Name: "start_with",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					return t.startWith(receiver.(*StringObject).value, args, sourceLine)
 				}
 			},
 		},
@@ -1406,23 +2040,58 @@ func builtinStringInstanceMethods() []*BuiltinMethodObject {
 			Name: "strip",
 			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
-
 					str := receiver.(*StringObject).value
-
-					for {
-						str = strings.Trim(str, " ")
-
-						if strings.HasPrefix(str, "\n") || strings.HasPrefix(str, "\t") || strings.HasPrefix(str, "\r") || strings.HasPrefix(str, "\v") {
-							str = string([]rune(str)[1:])
-							continue
-						}
-						if strings.HasSuffix(str, "\n") || strings.HasSuffix(str, "\t") || strings.HasSuffix(str, "\r") || strings.HasSuffix(str, "\v") {
-							str = string([]rune(str)[:utf8.RuneCountInString(str)-2])
-							continue
-						}
-						break
+					return t.vm.initStringObject(strings.Trim(str, stripCutset))
+				}
+			},
+		},
+		{
+			// Returns a copy of the string with the first match of the pattern replaced, the pattern can be a String or a Regexp.
+			// The replacement works like the one of `gsub`: a String that can refer to the groups, a Hash, or a block.
+			//
+			// ```ruby
+			// "hello hello".sub("hello", "bye")         # => "bye hello"
+			// "2024-01-15".sub(/(\d+)-(\d+)/, "\\2/\\1") # => "01/2024-15"
+			// "hello".sub(/l/) do |l|
+			//   l.upcase
+			// end
+			// # => "heLlo"
+			// ```
+			//
+			// @param pattern [String, Regexp]
+			// @param replacement [String, Hash]
+			// @return [String]
+			Name: "sub",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					return t.substitute(receiver, args, blockFrame, false, sourceLine)
+				}
+			},
+		},
+		{
+			// Returns the successor of the string: the rightmost letter or digit is incremented,
+			// and it carries to the letter or digit on its left like an odometer.
+			// A string without letters or digits gets its last character incremented.
+			//
+			// ```ruby
+			// "abc".succ  # => "abd"
+			// "az".succ   # => "ba"
+			// "zz".succ   # => "aaa"
+			// "a9".succ   # => "b0"
+			// "1.9".succ  # => "2.0"
+			// "Zz".succ   # => "AAa"
+			// "***".succ  # => "**+"
+			// ```
+			//
+			// @return [String]
+			Name: "succ",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
 					}
-					return t.vm.initStringObject(str)
+
+					return t.vm.initStringObject(successor(receiver.(*StringObject).value))
 				}
 			},
 		},
@@ -1547,6 +2216,79 @@ func builtinStringInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Returns the symbol of the string
+			//
+			// ```ruby
+			// "foo".to_sym # => :foo
+			// ```
+			//
+			// @return [Symbol]
+			Name: "to_sym",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					return t.vm.initSymbolObject(receiver.(*StringObject).value)
+				}
+			},
+		},
+		{
+			// Returns a copy of the string where the characters of the first set are replaced by the characters
+			// at the same position in the second set. Sets can have ranges like `a-z`, and a leading `^` negates the first set.
+			// If the second set is shorter, its last character is repeated; if it's empty the characters are deleted.
+			//
+			// ```ruby
+			// "hello".tr("el", "ip")     # => "hippo"
+			// "hello".tr("a-y", "b-z")   # => "ifmmp"
+			// "hello".tr("^l", "*")      # => "**ll*"
+			// "hello".tr("lo", "")       # => "he"
+			// "ｈｅｌｌｏ".tr("ｌ", "L") # => "ｈｅLLｏ"
+			// ```
+			//
+			// @param from [String]
+			// @param to [String]
+			// @return [String]
+			Name: "tr",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 2 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 2, len(args))
+					}
+
+					sets, err := t.charSetsArguments(args, sourceLine)
+
+					if err != nil {
+						return err
+					}
+
+					from, to := sets[0], sets[1].chars
+					var out strings.Builder
+
+					for _, r := range receiver.(*StringObject).value {
+						switch {
+						case !from.includes(r):
+							out.WriteRune(r)
+						case len(to) == 0:
+						case from.negated:
+							out.WriteRune(to[len(to)-1])
+						default:
+							i := from.position(r)
+
+							if i >= len(to) {
+								i = len(to) - 1
+							}
+
+							out.WriteRune(to[i])
+						}
+					}
+
+					return t.vm.initStringObject(out.String())
+				}
+			},
+		},
 		{
 			// Returns a new String with all characters is upcase
 			//
@@ -1565,6 +2307,49 @@ func builtinStringInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Decodes the bytes of the string with the directives of the format, and returns an array of the values.
+			// Each directive can be followed by a count, or by `*` to use all the remaining bytes:
+			//
+			// - `a`, `A`, `Z`: bytes as a String, `A` removes trailing spaces and nulls, `Z` stops at the first null
+			// - `b`, `B`: bits as a String of 0 and 1, from the lowest or the highest bit of each byte
+			// - `h`, `H`: hex digits as a String, from the low or the high nibble of each byte
+			// - `C`, `c`: 8-bit unsigned and signed integers
+			// - `S`, `s`, `L`, `l`, `Q`, `q`: 16, 32 and 64-bit unsigned and signed little-endian integers
+			// - `n`, `N`: 16 and 32-bit big-endian (network order) unsigned integers
+			// - `v`, `V`: 16 and 32-bit little-endian unsigned integers
+			// - `U`: UTF-8 characters as code points
+			// - `m`: base64 encoded String
+			// - `x`: skips a byte
+			//
+			// ```ruby
+			// "abc".unpack("C*")        # => [97, 98, 99]
+			// "漢字".unpack("U*")       # => [28450, 23383]
+			// "AB".unpack("n")          # => [16706]
+			// "ab  ".unpack("A2 a*")    # => ["ab", "  "]
+			// "aGVsbG8=".unpack("m")    # => ["hello"]
+			// "a".unpack("B8")          # => ["01100001"]
+			// ```
+			//
+			// @param format [String]
+			// @return [Array]
+			Name: "unpack",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					format, ok := args[0].(*StringObject)
+
+					if !ok {
+						return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.StringClass, args[0].Class().Name)
+					}
+
+					return t.unpack([]byte(receiver.(*StringObject).value), format.value, sourceLine)
+				}
+			},
+		},
 		{
 			Name: "to_bytes",
 			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
@@ -1592,6 +2377,7 @@ func (vm *VM) initStringClass() *RClass {
 	sc := vm.initializeClass(classes.StringClass, false)
 	sc.setBuiltinMethods(builtinStringInstanceMethods(), false)
 	sc.setBuiltinMethods(builtinStringClassMethods(), true)
+	vm.libFiles = append(vm.libFiles, "string.gb")
 	return sc
 }
 
@@ -1616,3 +2402,576 @@ func (s *StringObject) toJSON() string {
 func (s *StringObject) equal(e *StringObject) bool {
 	return s.value == e.value
 }
+
+// Other helper functions -----------------------------------------------
+
+// stripCutset is the whitespace characters that `strip`, `lstrip` and `rstrip` remove
+const stripCutset = "\x00\t\n\v\f\r "
+
+// patternRegexp returns the regexp of a Regexp pattern, or a regexp that matches a String pattern literally
+func (t *thread) patternRegexp(pattern Object, sourceLine int) (*regexp2.Regexp, *Error) {
+	switch p := pattern.(type) {
+	case *RegexpObject:
+		return p.Regexp, nil
+	case *StringObject:
+		return regexp2.MustCompile(regexp2.Escape(p.value), regexp2.None), nil
+	}
+
+	return nil, t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.StringClass+" or "+classes.RegexpClass, pattern.Class().Name)
+}
+
+// findMatches returns the matches of the regexp in the characters, at most limit of them unless limit is negative.
+// Positions of the matches are counted in characters, not in bytes.
+func findMatches(re *regexp2.Regexp, runes []rune, limit int) []*regexp2.Match {
+	matches := []*regexp2.Match{}
+	m, _ := re.FindRunesMatch(runes)
+
+	for m != nil && (limit < 0 || len(matches) < limit) {
+		matches = append(matches, m)
+		m, _ = re.FindNextMatch(m)
+	}
+
+	return matches
+}
+
+// splitByRegexp splits the characters by the matches of the regexp, the groups captured by each match are put after the part before it.
+// Empty matches at the start and the end are skipped, so an empty pattern splits the characters one by one like an empty string separator.
+func splitByRegexp(re *regexp2.Regexp, runes []rune) []string {
+	parts := []string{}
+	last := 0
+
+	for _, m := range findMatches(re, runes, -1) {
+		if m.Length == 0 && (m.Index == 0 || m.Index == len(runes)) {
+			continue
+		}
+
+		parts = append(parts, string(runes[last:m.Index]))
+
+		for _, g := range m.Groups()[1:] {
+			if len(g.Captures) > 0 {
+				parts = append(parts, g.String())
+			}
+		}
+
+		last = m.Index + m.Length
+	}
+
+	return append(parts, string(runes[last:]))
+}
+
+// lastMatch returns the match that starts the closest to the start position, searching backwards
+func lastMatch(re *regexp2.Regexp, runes []rune, start int) *regexp2.Match {
+	for pos := start; pos >= 0; pos-- {
+		m, _ := re.FindRunesMatchStartingAt(runes, pos)
+
+		if m != nil && m.Index == pos {
+			return m
+		}
+	}
+
+	return nil
+}
+
+// substitute replaces the first match of the pattern, or every match if global is true.
+// The replacement is a String that can refer to the match's groups, a Hash of the matched texts, or the block's result.
+func (t *thread) substitute(receiver Object, args []Object, blockFrame *normalCallFrame, global bool, sourceLine int) Object {
+	if blockFrame == nil && len(args) != 2 {
+		return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "Expect 2 arguments. got=%v", len(args))
+	}
+
+	if blockFrame != nil && len(args) != 1 && len(args) != 2 {
+		return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentRangeFormat, 1, 2, len(args))
+	}
+
+	re, err := t.patternRegexp(args[0], sourceLine)
+
+	if err != nil {
+		return err
+	}
+
+	var replacement Object
+
+	if len(args) == 2 {
+		replacement = args[1]
+
+		switch replacement.(type) {
+		case *StringObject, *HashObject:
+		default:
+			return t.vm.initErrorObject(errors.TypeError, sourceLine, "Expect replacement to be String or Hash. got: %s", replacement.Class().Name)
+		}
+	}
+
+	limit := 1
+
+	if global {
+		limit = -1
+	}
+
+	runes := []rune(receiver.(*StringObject).value)
+	var out strings.Builder
+	last := 0
+
+	for _, m := range findMatches(re, runes, limit) {
+		out.WriteString(string(runes[last:m.Index]))
+		last = m.Index + m.Length
+		matched := t.vm.initStringObject(m.String())
+
+		var result Object
+
+		switch r := replacement.(type) {
+		case *StringObject:
+			out.WriteString(expandReplacement(r.value, m))
+			continue
+		case *HashObject:
+			pair, err := r.find(t, matched, sourceLine)
+
+			if err != nil {
+				return err
+			}
+
+			result = NULL
+
+			if pair != nil {
+				result = pair.value
+			}
+		default:
+			result = t.builtinMethodYield(blockFrame, matched).Target
+
+			if err, ok := result.(*Error); ok {
+				return err
+			}
+		}
+
+		// The hash's value or the block's result is converted with `to_s` like in string interpolations, so `nil` becomes an empty string
+		str := t.callMethod(result, "to_s", sourceLine)
+
+		if err, ok := str.(*Error); ok {
+			return err
+		}
+
+		out.WriteString(str.toString())
+	}
+
+	out.WriteString(string(runes[last:]))
+	return t.vm.initStringObject(out.String())
+}
+
+// expandReplacement replaces the references in a replacement string with the match's groups:
+// `\0` and `\&` are the whole match, `\1` to `\9` are the numbered groups, `\k<name>` is a named group and `\\` is a backslash.
+func expandReplacement(replacement string, m *regexp2.Match) string {
+	var out strings.Builder
+	runes := []rune(replacement)
+
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '\\' || i+1 == len(runes) {
+			out.WriteRune(runes[i])
+			continue
+		}
+
+		next := runes[i+1]
+
+		switch {
+		case next >= '0' && next <= '9':
+			out.WriteString(groupString(m.GroupByNumber(int(next - '0'))))
+			i++
+		case next == '&':
+			out.WriteString(m.String())
+			i++
+		case next == '\\':
+			out.WriteRune('\\')
+			i++
+		case next == 'k' && i+2 < len(runes) && runes[i+2] == '<':
+			end := i + 3
+
+			for end < len(runes) && runes[end] != '>' {
+				end++
+			}
+
+			if end == len(runes) {
+				out.WriteRune(runes[i])
+				continue
+			}
+
+			out.WriteString(groupString(m.GroupByName(string(runes[i+3 : end]))))
+			i = end
+		default:
+			out.WriteRune(runes[i])
+		}
+	}
+
+	return out.String()
+}
+
+// groupString returns the text captured by the group, or an empty string if the group didn't take part in the match
+func groupString(g *regexp2.Group) string {
+	if g == nil || len(g.Captures) == 0 {
+		return ""
+	}
+
+	return g.String()
+}
+
+// groupObject returns the text captured by the group as a String, or nil if the group didn't take part in the match
+func (vm *VM) groupObject(g *regexp2.Group) Object {
+	if g == nil || len(g.Captures) == 0 {
+		return NULL
+	}
+
+	return vm.initStringObject(g.String())
+}
+
+// charSet is a set of characters written like the arguments of `tr`:
+// `a-z` is a range of characters, and a leading `^` negates the set.
+type charSet struct {
+	chars   []rune
+	negated bool
+}
+
+func parseCharSet(spec string) *charSet {
+	runes := []rune(spec)
+	set := &charSet{}
+
+	if len(runes) > 1 && runes[0] == '^' {
+		set.negated = true
+		runes = runes[1:]
+	}
+
+	for i := 0; i < len(runes); i++ {
+		switch {
+		case runes[i] == '\\' && i+1 < len(runes):
+			i++
+			set.chars = append(set.chars, runes[i])
+		case i+2 < len(runes) && runes[i+1] == '-':
+			for c := runes[i]; c <= runes[i+2]; c++ {
+				set.chars = append(set.chars, c)
+			}
+
+			i += 2
+		default:
+			set.chars = append(set.chars, runes[i])
+		}
+	}
+
+	return set
+}
+
+// position returns the index of the character in the set, or -1 if it's not listed
+func (s *charSet) position(r rune) int {
+	for i, c := range s.chars {
+		if c == r {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (s *charSet) includes(r rune) bool {
+	return (s.position(r) >= 0) != s.negated
+}
+
+// includedInAll returns true if the character is included in all of the sets
+func includedInAll(sets []*charSet, r rune) bool {
+	for _, set := range sets {
+		if !set.includes(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// charSetsArguments parses the String arguments as character sets
+func (t *thread) charSetsArguments(args []Object, sourceLine int) ([]*charSet, *Error) {
+	sets := make([]*charSet, len(args))
+
+	for i, arg := range args {
+		str, ok := arg.(*StringObject)
+
+		if !ok {
+			return nil, t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.StringClass, arg.Class().Name)
+		}
+
+		sets[i] = parseCharSet(str.value)
+	}
+
+	return sets, nil
+}
+
+// startWith returns true if the string starts with one of the prefixes, which can be Strings or Regexps
+func (t *thread) startWith(str string, prefixes []Object, sourceLine int) Object {
+	if len(prefixes) < 1 {
+		return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentMoreFormat, 1, len(prefixes))
+	}
+
+	for _, arg := range prefixes {
+		switch prefix := arg.(type) {
+		case *StringObject:
+			if strings.HasPrefix(str, prefix.value) {
+				return TRUE
+			}
+		case *RegexpObject:
+			if m, _ := prefix.Regexp.FindStringMatch(str); m != nil && m.Index == 0 {
+				return TRUE
+			}
+		default:
+			return t.vm.initErrorObject(errors.TypeError, sourceLine, errors.WrongArgumentTypeFormat, classes.StringClass+" or "+classes.RegexpClass, arg.Class().Name)
+		}
+	}
+
+	return FALSE
+}
+
+// padding returns n characters made by repeating the pad string
+func padding(pad string, n int) string {
+	padRunes := []rune(pad)
+	result := make([]rune, n)
+
+	for i := range result {
+		result[i] = padRunes[i%len(padRunes)]
+	}
+
+	return string(result)
+}
+
+// successor returns the string's successor: the rightmost alphanumeric character is incremented,
+// and it carries to the alphanumeric character on its left like an odometer, `"az"` becomes `"ba"` and `"zz"` becomes `"aaa"`.
+// A string without alphanumeric characters gets its last character incremented.
+func successor(str string) string {
+	runes := []rune(str)
+
+	if len(runes) == 0 {
+		return str
+	}
+
+	i := len(runes) - 1
+
+	for i >= 0 && !isASCIIAlnum(runes[i]) {
+		i--
+	}
+
+	if i < 0 {
+		runes[len(runes)-1]++
+		return string(runes)
+	}
+
+	for {
+		var carry rune
+
+		switch runes[i] {
+		case 'z':
+			runes[i], carry = 'a', 'a'
+		case 'Z':
+			runes[i], carry = 'A', 'A'
+		case '9':
+			runes[i], carry = '0', '1'
+		default:
+			runes[i]++
+			return string(runes)
+		}
+
+		j := i - 1
+
+		for j >= 0 && !isASCIIAlnum(runes[j]) {
+			j--
+		}
+
+		if j < 0 {
+			runes = append(runes[:i], append([]rune{carry}, runes[i:]...)...)
+			return string(runes)
+		}
+
+		i = j
+	}
+}
+
+func isASCIIAlnum(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9'
+}
+
+// unpack decodes the bytes of the string with the directives of the format, like Ruby's `String#unpack`
+func (t *thread) unpack(data []byte, format string, sourceLine int) Object {
+	results := []Object{}
+	formatRunes := []rune(format)
+
+	for i := 0; i < len(formatRunes); i++ {
+		directive := formatRunes[i]
+
+		if unicode.IsSpace(directive) {
+			continue
+		}
+
+		// The count is -1 for `*`, which means all the remaining data
+		count := 1
+		hasCount := false
+
+		if i+1 < len(formatRunes) && formatRunes[i+1] == '*' {
+			count, hasCount = -1, true
+			i++
+		} else if i+1 < len(formatRunes) && unicode.IsDigit(formatRunes[i+1]) {
+			j := i + 1
+
+			for j < len(formatRunes) && unicode.IsDigit(formatRunes[j]) {
+				j++
+			}
+
+			count, _ = strconv.Atoi(string(formatRunes[i+1 : j]))
+			hasCount = true
+			i = j - 1
+		}
+
+		switch directive {
+		case 'a', 'A', 'Z':
+			n := count
+
+			if n < 0 || n > len(data) {
+				n = len(data)
+			}
+
+			field := data[:n]
+			data = data[n:]
+
+			switch directive {
+			case 'A':
+				field = bytes.TrimRight(field, " \x00")
+			case 'Z':
+				if end := bytes.IndexByte(field, 0); end >= 0 {
+					if count < 0 {
+						data = append(field[end+1:], data...)
+					}
+
+					field = field[:end]
+				}
+			}
+
+			results = append(results, t.vm.initStringObject(string(field)))
+		case 'b', 'B', 'h', 'H':
+			bitsPerChar := 1
+
+			if directive == 'h' || directive == 'H' {
+				bitsPerChar = 4
+			}
+
+			n := count
+
+			if n < 0 || n*bitsPerChar > len(data)*8 {
+				n = len(data) * 8 / bitsPerChar
+			}
+
+			var out strings.Builder
+
+			for c := 0; c < n; c++ {
+				b := data[c*bitsPerChar/8]
+				shift := uint(c * bitsPerChar % 8)
+
+				switch directive {
+				case 'b':
+					out.WriteByte('0' + b>>shift&1)
+				case 'B':
+					out.WriteByte('0' + b>>(7-shift)&1)
+				case 'h':
+					out.WriteString(strconv.FormatInt(int64(b>>shift&0xf), 16))
+				case 'H':
+					out.WriteString(strconv.FormatInt(int64(b>>(4-shift)&0xf), 16))
+				}
+			}
+
+			data = data[(n*bitsPerChar+7)/8:]
+			results = append(results, t.vm.initStringObject(out.String()))
+		case 'c', 'C', 's', 'S', 'v', 'n', 'l', 'L', 'V', 'N', 'q', 'Q':
+			size, signed, bigEndian := integerDirective(directive)
+
+			for c := 0; count < 0 || c < count; c++ {
+				if len(data) < size {
+					if count < 0 {
+						break
+					}
+
+					results = append(results, NULL)
+					continue
+				}
+
+				var value uint64
+
+				for b := 0; b < size; b++ {
+					if bigEndian {
+						value = value<<8 | uint64(data[b])
+					} else {
+						value |= uint64(data[b]) << uint(8*b)
+					}
+				}
+
+				data = data[size:]
+
+				if signed && size < 8 && value&(1<<uint(size*8-1)) != 0 {
+					value -= 1 << uint(size*8)
+				}
+
+				results = append(results, t.vm.initIntegerObject(int(value)))
+			}
+		case 'U':
+			for c := 0; (count < 0 || c < count) && len(data) > 0; c++ {
+				r, size := utf8.DecodeRune(data)
+
+				if r == utf8.RuneError && size <= 1 {
+					return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "malformed UTF-8 character")
+				}
+
+				data = data[size:]
+				results = append(results, t.vm.initIntegerObject(int(r)))
+			}
+		case 'm':
+			decoded, err := base64.StdEncoding.DecodeString(strings.Map(func(r rune) rune {
+				if unicode.IsSpace(r) {
+					return -1
+				}
+
+				return r
+			}, string(data)))
+
+			if err != nil {
+				return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "invalid base64")
+			}
+
+			data = nil
+			results = append(results, t.vm.initStringObject(string(decoded)))
+		case 'x':
+			n := count
+
+			if !hasCount {
+				n = 1
+			}
+
+			if n < 0 || n > len(data) {
+				return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "x outside of string")
+			}
+
+			data = data[n:]
+		default:
+			return t.vm.initErrorObject(errors.ArgumentError, sourceLine, "unknown unpack directive '%c' in '%s'", directive, format)
+		}
+	}
+
+	return t.vm.initArrayObject(results)
+}
+
+// integerDirective returns the size in bytes, the signedness and the byte order of an integer directive of `unpack`
+func integerDirective(directive rune) (size int, signed bool, bigEndian bool) {
+	switch directive {
+	case 'c', 'C':
+		size = 1
+	case 's', 'S', 'v', 'n':
+		size = 2
+	case 'l', 'L', 'V', 'N':
+		size = 4
+	default:
+		size = 8
+	}
+
+	return size, unicode.IsLower(directive) && directive != 'v' && directive != 'n', directive == 'n' || directive == 'N'
+}
+
+// initStringPartition returns the array of the three parts of a string that `partition` and `rpartition` return
+func (vm *VM) initStringPartition(before, match, after string) *ArrayObject {
+	return vm.initArrayObject([]Object{vm.initStringObject(before), vm.initStringObject(match), vm.initStringObject(after)})
+}
@@ -46,6 +46,127 @@ func TestEvalStringExpression(t *testing.T) {
 	}
 }
 
+func TestStringInterpolation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`a = 10; "a is #{a}"`, "a is 10"},
+		{`a = 10; "#{a + 1} and #{a * 2}!"`, "11 and 20!"},
+		{`"#{[1, 2].map do |i| i * 2 end}"`, "[2, 4]"},
+		{`"#{"inner #{1 + 1}"} outer"`, "inner 2 outer"},
+		{`h = { foo: "bar" }; "#{h[:foo]}"`, "bar"},
+		{`"#{nil}#{true}"`, "true"},
+		{`"#{}"`, ""},
+		{`a = 1; 'a is #{a}'`, "a is #{a}"},
+		{`a = 1; "a is \#{a}"`, "a is #{a}"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestHeredoc(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		name = "users"
+		<<~SQL
+		  SELECT *
+		    FROM #{name}
+
+		  WHERE "id" = 1
+		SQL
+		`, "SELECT *\n  FROM users\n\nWHERE \"id\" = 1\n"},
+		{`
+		s = <<-EOS
+		  foo\tbar
+		  EOS
+		s
+		`, "\t\t  foo\tbar\n"},
+		{`
+		<<~'EOS'
+		  #{foo}\n
+		EOS
+		`, "#{foo}\\n\n"},
+		{`
+		<<~EOS.length
+		EOS
+		`, 0},
+		{`
+		def foo(a, b)
+		  a + b
+		end
+
+		foo(<<~A, <<~B).upcase
+		  a
+		A
+		  b
+		B
+		`, "A\nB\n"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestHeredocFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`
+		a = <<~EOS
+		  foo
+		EOS
+		a.foo
+		`, "UndefinedMethodError: Undefined Method 'foo' for foo\n", 5, 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, tt.expectedCFP)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestPercentLiteral(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`%w[foo bar  baz].to_s`, `["foo", "bar", "baz"]`},
+		{`%w().length`, 0},
+		{`%w{a
+		  b}.length`, 2},
+		{`%i(foo bar).to_s`, "[:foo, :bar]"},
+		{`%q(it's (nested) \) #{1})`, "it's (nested) ) #{1}"},
+		{`%q[a\nb]`, "a\\nb"},
+		{`a = 1; %Q{"#{a + 1}"\t#{ {b: 2}[:b] }}`, "\"2\"\t2"},
+		{`%Q|a|`, "a"},
+		{`10 % 3`, 1},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestStringConversion(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -722,6 +843,34 @@ func TestStringGlobalSubstituteMethod(t *testing.T) {
 		{`"Hello World".gsub(" ", "\n")`, "Hello\nWorld"},
 		{`"Hello World".gsub("Hello", "Goby")`, "Goby World"},
 		{`"Hello 🍣 Hello 🍣 Hello".gsub("🍣", "🍺")`, "Hello 🍺 Hello 🍺 Hello"},
+		{`"a.b.c".gsub(".", "-")`, "a-b-c"},
+		{`"John Smith".gsub(/(\w+) (\w+)/, "\\2 \\1")`, "Smith John"},
+		{`"2024-01-15".gsub(/(?<year>\d+)-(?<month>\d+)/, "\\k<month>/\\k<year>")`, "01/2024-15"},
+		{`"a-b".gsub("-", "\\0\\0")`, "a--b"},
+		{`"cat hat".gsub(/[ch]/, { "c" => "b", "h" => "m" })`, "bat mat"},
+		{`"cat hat".gsub(/[ct]/, { "c" => "b" })`, "ba ha"},
+		{`"Hello 🍣 Hello".gsub(/l+/, "L")`, "HeLo 🍣 HeLo"},
+		{`
+		"1 2 3".gsub(/\d/) do |d|
+		  (d.to_i * 2).to_s
+		end
+		`, "2 4 6"},
+		{`
+		"abc".gsub("") do |s|
+		  "-"
+		end
+		`, "-a-b-c-"},
+		{`
+		"a1b2".gsub(/\d/) do |d|
+		  d.to_i * 10
+		end
+		`, "a10b20"},
+		{`
+		"a1b2".gsub(/\d/) do |d|
+		  nil
+		end
+		`, "ab"},
+		{`"cat hat".gsub(/[ct]/, { "c" => nil, "t" => 1 })`, "a1 ha1"},
 	}
 
 	for i, tt := range tests {
@@ -737,8 +886,8 @@ func TestStringGlobalSubstituteMethodFail(t *testing.T) {
 	testsFail := []errorTestCase{
 		{`"Ruby".gsub()`, "ArgumentError: Expect 2 arguments. got=0", 1, 1},
 		{`"Ruby".gsub("Ru")`, "ArgumentError: Expect 2 arguments. got=1", 1, 1},
-		{`"Ruby".gsub(123, "Go")`, "TypeError: Expect pattern to be String. got: Integer", 1, 1},
-		{`"Ruby".gsub("Ru", 456)`, "TypeError: Expect replacement to be String. got: Integer", 1, 1},
+		{`"Ruby".gsub(123, "Go")`, "TypeError: Expect argument to be String or Regexp. got: Integer", 1, 1},
+		{`"Ruby".gsub("Ru", 456)`, "TypeError: Expect replacement to be String or Hash. got: Integer", 1, 1},
 	}
 
 	for i, tt := range testsFail {
@@ -1178,6 +1327,13 @@ func TestStringSplitMethod(t *testing.T) {
 		arr = "Hello🍺World🍣Goby".split("🍺")
 		arr[1]
 		`, "World🍣Goby"},
+		{`"a, b,c".split(/,\s*/) == ["a", "b", "c"]`, true},
+		{`"a1b22c".split(/\d+/) == ["a", "b", "c"]`, true},
+		{`"a1b2c".split(/(\d)/) == ["a", "1", "b", "2", "c"]`, true},
+		{`"Goby".split(//) == ["G", "o", "b", "y"]`, true},
+		{`"漢字,かな".split(/,/) == ["漢字", "かな"]`, true},
+		{`"a,b,".split(/,/) == "a,b,".split(",")`, true},
+		{`"abc".split(/x/) == ["abc"]`, true},
 	}
 
 	for i, tt := range tests {
@@ -1192,9 +1348,9 @@ func TestStringSplitMethod(t *testing.T) {
 func TestStringSplitMethodFail(t *testing.T) {
 	testsFail := []errorTestCase{
 		{`"Hello World".split`, "ArgumentError: Expect 1 argument. got=0", 1, 1},
-		{`"Hello World".split(true)`, "TypeError: Expect argument to be String. got: Boolean", 1, 1},
-		{`"Hello World".split(123)`, "TypeError: Expect argument to be String. got: Integer", 1, 1},
-		{`"Hello World".split(1..2)`, "TypeError: Expect argument to be String. got: Range", 1, 1},
+		{`"Hello World".split(true)`, "TypeError: Expect argument to be String or Regexp. got: Boolean", 1, 1},
+		{`"Hello World".split(123)`, "TypeError: Expect argument to be String or Regexp. got: Integer", 1, 1},
+		{`"Hello World".split(1..2)`, "TypeError: Expect argument to be String or Regexp. got: Range", 1, 1},
 	}
 
 	for i, tt := range testsFail {
@@ -1207,6 +1363,51 @@ func TestStringSplitMethodFail(t *testing.T) {
 }
 
 func TestStringStartWithMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`"Hello".start_with?("Hel")`, true},
+		{`"Hello".start_with?("Hello")`, true},
+		{`"Hello".start_with?("Hello ")`, false},
+		{`"哈囉！世界！".start_with?("哈囉！")`, true},
+		{`"Hello".start_with?("hel")`, false},
+		{`"哈囉！世界".start_with?("世界！")`, false},
+		{`"🍣Hello🍺".start_with?("🍣")`, true},
+		{`"🍣Hello🍺".start_with?("🍺")`, false},
+		{`"Hello".start_with?("x", "He")`, true},
+		{`"Hello".start_with?("x", "y")`, false},
+		{`"Hello".start_with?(/h/i)`, true},
+		{`"Hello".start_with?(/l+/)`, false},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestStringStartWithMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`"Taipei".start_with?`, "ArgumentError: Expect 1 or more arguments. got: 0", 1, 1},
+		{`"Taipei".start_with?(101)`, "TypeError: Expect argument to be String or Regexp. got: Integer", 1, 1},
+		{`"Hello".start_with?(true)`, "TypeError: Expect argument to be String or Regexp. got: Boolean", 1, 1},
+		{`"Hello".start_with?(1..5)`, "TypeError: Expect argument to be String or Regexp. got: Range", 1, 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, tt.expectedCFP)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestStringDeprecatedStartWithMethod(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected interface{}
@@ -1230,12 +1431,12 @@ func TestStringStartWithMethod(t *testing.T) {
 	}
 }
 
-func TestStringStartWithMethodFail(t *testing.T) {
+func TestStringDeprecatedStartWithMethodFail(t *testing.T) {
 	testsFail := []errorTestCase{
-		{`"Taipei".start_with("1", "0", "1")`, "ArgumentError: Expect 1 argument. got=3", 1, 1},
-		{`"Taipei".start_with(101)`, "TypeError: Expect argument to be String. got: Integer", 1, 1},
-		{`"Hello".start_with(true)`, "TypeError: Expect argument to be String. got: Boolean", 1, 1},
-		{`"Hello".start_with(1..5)`, "TypeError: Expect argument to be String. got: Range", 1, 1},
+		{`"Taipei".start_with`, "ArgumentError: Expect 1 or more arguments. got: 0", 1, 1},
+		{`"Taipei".start_with(101)`, "TypeError: Expect argument to be String or Regexp. got: Integer", 1, 1},
+		{`"Hello".start_with(true)`, "TypeError: Expect argument to be String or Regexp. got: Boolean", 1, 1},
+		{`"Hello".start_with(1..5)`, "TypeError: Expect argument to be String or Regexp. got: Range", 1, 1},
 	}
 
 	for i, tt := range testsFail {
@@ -1255,6 +1456,13 @@ func TestStringStripMethod(t *testing.T) {
 		{`"  Goby Lang   ".strip`, "Goby Lang"},
 		{`"\nGoby Lang\r\t".strip`, "Goby Lang"},
 		{`" \t 🍣 Goby Lang 🍺 \r\n ".strip`, "🍣 Goby Lang 🍺"},
+		{`"hi\n".strip`, "hi"},
+		{`"\fhi\n\n".strip`, "hi"},
+		{`
+		<<~EOS.strip
+		  Goby
+		EOS
+		`, "Goby"},
 	}
 
 	for i, tt := range tests {
@@ -1321,3 +1529,394 @@ func TestFormattedString(t *testing.T) {
 		v.checkSP(t, i, 1)
 	}
 }
+
+func TestStringSubstituteMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`"hello hello".sub("hello", "bye")`, "bye hello"},
+		{`"hello".sub("x", "y")`, "hello"},
+		{`"2024-01-15".sub(/(\d+)-(\d+)/, "\\2/\\1")`, "01/2024-15"},
+		{`"漢字漢字".sub(/字/, "z")`, "漢z漢字"},
+		{`"cat".sub(/c/, { "c" => "b" })`, "bat"},
+		{`
+		"hello".sub(/l/) do |l|
+		  l.upcase
+		end
+		`, "heLlo"},
+		{`
+		"hello".sub(/l/) do |l|
+		  nil
+		end
+		`, "helo"},
+		{`
+		"hello".sub(/l/) do |l|
+		  :L
+		end
+		`, "heLlo"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestStringSubstituteMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`"Ruby".sub("Ru")`, "ArgumentError: Expect 2 arguments. got=1", 1, 1},
+		{`"Ruby".sub(1, "a")`, "TypeError: Expect argument to be String or Regexp. got: Integer", 1, 1},
+		{`"Ruby".sub("R", nil)`, "TypeError: Expect replacement to be String or Hash. got: Null", 1, 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, tt.expectedCFP)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestStringScanMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`"a1 b22 c333".scan(/\d+/).to_s`, `["1", "22", "333"]`},
+		{`"a=1, b=2".scan(/(\w)=(\d)/).to_s`, `[["a", "1"], ["b", "2"]]`},
+		{`"ab".scan(/(a)|(b)/).to_s`, `[["a", nil], [nil, "b"]]`},
+		{`"a.b.c".scan(".").to_s`, `[".", "."]`},
+		{`"🍣 and 🍺".scan(/🍣|🍺/).to_s`, `["🍣", "🍺"]`},
+		{`"abc".scan(/x/).to_s`, `[]`},
+		{`
+		result = []
+		"a=1, b=2".scan(/(\w)=(\d)/) do |key, value|
+		  result.push(key + value)
+		end
+		result.to_s
+		`, `["a1", "b2"]`},
+		{`
+		"abc".scan(/\w/) do |c|
+		  c
+		end
+		`, "abc"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestStringIndexMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`"hello".index("l")`, 2},
+		{`"hello".index("l", 3)`, 3},
+		{`"hello".index("l", 4)`, nil},
+		{`"hello".index(/[aeiou]/, -2)`, 4},
+		{`"hello".index("", 5)`, 5},
+		{`"hello".index("h", 6)`, nil},
+		{`"こんにちは".index("に")`, 2},
+		{`"hello".index("z")`, nil},
+		{`"hello".rindex("l")`, 3},
+		{`"hello".rindex("l", 2)`, 2},
+		{`"hello".rindex("l", -3)`, 2},
+		{`"hello".rindex("h", -6)`, nil},
+		{`"hello".rindex(/[aeiou]/)`, 4},
+		{`"hello".rindex("")`, 5},
+		{`"こんにちは".rindex("ん")`, 1},
+		{`"hello".rindex("z")`, nil},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestStringIndexMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`"hello".index`, "ArgumentError: Expect 1 to 2 arguments. got: 0", 1, 1},
+		{`"hello".index(1)`, "TypeError: Expect argument to be String or Regexp. got: Integer", 1, 1},
+		{`"hello".rindex("l", "a")`, "TypeError: Expect argument to be Integer. got: String", 1, 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, tt.expectedCFP)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestStringPartitionMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`"key=value=1".partition("=").to_s`, `["key", "=", "value=1"]`},
+		{`"a1b22c".partition(/\d+/).to_s`, `["a", "1", "b22c"]`},
+		{`"abc".partition("x").to_s`, `["abc", "", ""]`},
+		{`"漢=字".partition("=").to_s`, `["漢", "=", "字"]`},
+		{`"key=value=1".rpartition("=").to_s`, `["key=value", "=", "1"]`},
+		{`"a1b22c".rpartition(/\d/).to_s`, `["a1b2", "2", "c"]`},
+		{`"abc".rpartition("x").to_s`, `["", "", "abc"]`},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestStringTrMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`"hello".tr("el", "ip")`, "hippo"},
+		{`"hello".tr("a-y", "b-z")`, "ifmmp"},
+		{`"hello".tr("a-z", "*")`, "*****"},
+		{`"hello".tr("^l", "*")`, "**ll*"},
+		{`"hello".tr("lo", "")`, "he"},
+		{`"a-b".tr("\\-", "+")`, "a+b"},
+		{`"漢字漢".tr("漢", "かん")`, "か字か"},
+		{`"aaabbbccc".squeeze`, "abc"},
+		{`"aaabbbccc".squeeze("a-b")`, "abccc"},
+		{`"aaabbbccc".squeeze("a-b", "b-c")`, "aaabccc"},
+		{`"too    many  spaces".squeeze(" ")`, "too many spaces"},
+		{`"ｈｅｌｌｏ".squeeze`, "ｈｅｌｏ"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestStringTrMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`"hello".tr("a")`, "ArgumentError: Expect 2 arguments. got: 1", 1, 1},
+		{`"hello".tr("a", 1)`, "TypeError: Expect argument to be String. got: Integer", 1, 1},
+		{`"hello".squeeze(1)`, "TypeError: Expect argument to be String. got: Integer", 1, 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, tt.expectedCFP)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestStringCharactersMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`"abc".chars.to_s`, `["a", "b", "c"]`},
+		{`"😊🍣".chars.length`, 2},
+		{`"".chars.to_s`, `[]`},
+		{`"abc".bytes.to_s`, `[97, 98, 99]`},
+		{`"é".bytes.to_s`, `[195, 169]`},
+		{`"a\nb\nc".lines.length`, 3},
+		{`"a\nb\n".lines[1]`, "b\n"},
+		{`"".lines.to_s`, `[]`},
+		{`"a".ord`, 97},
+		{`"漢字".ord`, 28450},
+		{`"漢字".chr`, "漢"},
+		{`"".chr`, ""},
+		{`"abc".center(7)`, "  abc  "},
+		{`"abc".center(8, "12")`, "12abc121"},
+		{`"漢字".center(6, "*")`, "**漢字**"},
+		{`"abc".center(2)`, "abc"},
+		{`"  \t abc  ".lstrip`, "abc  "},
+		{`"  abc \n".rstrip`, "  abc"},
+		{`"abc".casecmp("ABC")`, 0},
+		{`"abc".casecmp("ABD")`, -1},
+		{`"b".casecmp("A")`, 1},
+		{`"äöü".casecmp?("ÄÖÜ")`, true},
+		{`"abc".casecmp?("abd")`, false},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestStringCharactersMethodsFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`"".ord`, "ArgumentError: empty string", 1, 1},
+		{`"abc".center(5, "")`, "ArgumentError: zero width padding", 1, 1},
+		{`"abc".center("5")`, "TypeError: Expect argument to be Integer. got: String", 1, 1},
+		{`"abc".casecmp(1)`, "TypeError: Expect argument to be String. got: Integer", 1, 1},
+		{`"abc".chars(1)`, "ArgumentError: Expect 0 arguments. got: 1", 1, 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, tt.expectedCFP)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestStringSuccMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`"abc".succ`, "abd"},
+		{`"az".succ`, "ba"},
+		{`"zz".succ`, "aaa"},
+		{`"a9".succ`, "b0"},
+		{`"99".succ`, "100"},
+		{`"1.9".succ`, "2.0"},
+		{`"Zz".succ`, "AAa"},
+		{`"***".succ`, "**+"},
+		{`"".succ`, ""},
+		{`"v1.9".next`, "v2.0"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestStringUnpackMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`"abc".unpack("C*").to_s`, `[97, 98, 99]`},
+		{`"abc".unpack("C2").to_s`, `[97, 98]`},
+		{`"a".unpack("C2").to_s`, `[97, nil]`},
+		{`"漢字".unpack("U*").to_s`, `[28450, 23383]`},
+		{`"AB".unpack("n").to_s`, `[16706]`},
+		{`"AB".unpack("v").to_s`, `[16961]`},
+		{`"ABCD".unpack("N").to_s`, `[1094861636]`},
+		{`"ab  ".unpack("A2 a*").to_s`, `["ab", "  "]`},
+		{`"ab  ".unpack("A*").to_s`, `["ab"]`},
+		{`"aGVsbG8=".unpack("m").to_s`, `["hello"]`},
+		{`"a".unpack("B8").to_s`, `["01100001"]`},
+		{`"a".unpack("b*").to_s`, `["10000110"]`},
+		{`"ab".unpack("H*").to_s`, `["6162"]`},
+		{`"ab".unpack("h3").to_s`, `["162"]`},
+		{`"abc".unpack("x C").to_s`, `[98]`},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestStringUnpackMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`"abc".unpack("y")`, "ArgumentError: unknown unpack directive 'y' in 'y'", 1, 1},
+		{`"abc".unpack("x4")`, "ArgumentError: x outside of string", 1, 1},
+		{`"abc".unpack(1)`, "TypeError: Expect argument to be String. got: Integer", 1, 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, tt.expectedCFP)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestStringPercentOperator(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`"%s is %d years old" % ["Goby", 5]`, "Goby is 5 years old"},
+		{`"%05.2f" % 3.14159`, "03.14"},
+		{`"%-6s|" % "ab"`, "ab    |"},
+		{`"%3d|%-3d|" % [7, 8]`, "  7|8  |"},
+		{`"100%%" % []`, "100%"},
+		{`"%s" % [[1, 2]]`, "[1, 2]"},
+		{`"%x-%o" % [255, 8]`, "ff-10"},
+		{`"%{a}-%<b>.1f" % { a: 1, b: 2 }`, "1-2.0"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestStringPercentOperatorFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`"%s %s" % ["a"]`, "ArgumentError: too few arguments", 1, 1},
+		{`"%d" % ["a"]`, "ArgumentError: invalid value for Integer(): \"a\"", 1, 1},
+		{`"%d" % [nil]`, "TypeError: Expect argument to be Integer. got: Null", 1, 1},
+		{`"%z" % [1]`, "ArgumentError: malformed format string - %z", 1, 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, tt.expectedCFP)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestStringHashMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`"a".hash == "a".hash`, true},
+		{`"a".hash == "b".hash`, false},
+		{`"a".hash == :a.hash`, false},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
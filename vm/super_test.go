@@ -0,0 +1,266 @@
+package vm
+
+import "testing"
+
+func TestSuperEvaluation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		class Foo
+		  def bar(x)
+		    x + 1
+		  end
+		end
+
+		class Bar < Foo
+		  def bar(x)
+		    super(x * 10)
+		  end
+		end
+
+		Bar.new.bar(1)
+		`, 11},
+		{`
+		class Foo
+		  def bar(a, b = 2, k: 3, *rest)
+		    [a, b, k, rest].to_s
+		  end
+		end
+
+		class Bar < Foo
+		  def bar(a, b = 5, k: 7, *rest)
+		    super
+		  end
+		end
+
+		Bar.new.bar(1)
+		`, "[1, 5, 7, []]"},
+		{`
+		class Foo
+		  def bar(a, b = 2, *rest)
+		    [a, b, rest].to_s
+		  end
+		end
+
+		class Bar < Foo
+		  def bar(a, b = 5, *rest)
+		    a = 100
+		    super
+		  end
+		end
+
+		Bar.new.bar(1, 2, 3, 4)
+		`, "[100, 2, [3, 4]]"},
+		{`
+		class Foo
+		  def bar(a, k: 3)
+		    [a, k].to_s
+		  end
+		end
+
+		class Bar < Foo
+		  def bar(a, k: 7)
+		    super
+		  end
+		end
+
+		Bar.new.bar(1, k: 9)
+		`, "[1, 9]"},
+		{`
+		class Foo
+		  def bar
+		    yield(1)
+		  end
+		end
+
+		class Bar < Foo
+		  def bar
+		    super
+		  end
+		end
+
+		Bar.new.bar do |x|
+		  x + 10
+		end
+		`, 11},
+		{`
+		class Foo
+		  def bar
+		    yield(1)
+		  end
+		end
+
+		class Bar < Foo
+		  def bar
+		    super() do |x|
+		      x + 100
+		    end
+		  end
+		end
+
+		Bar.new.bar do |x|
+		  x + 10
+		end
+		`, 101},
+		{`
+		class Foo
+		  def bar(x)
+		    x
+		  end
+		end
+
+		class Bar < Foo
+		  def bar(x)
+		    [1, 2].map do |i|
+		      super(x + i)
+		    end.to_s
+		  end
+		end
+
+		Bar.new.bar(10)
+		`, "[11, 12]"},
+		{`
+		module Greet
+		  def hi(name)
+		    "Hi " + name
+		  end
+		end
+
+		class Foo
+		  include Greet
+
+		  def hi(name)
+		    super + "!"
+		  end
+		end
+
+		Foo.new.hi("Goby")
+		`, "Hi Goby!"},
+		{`
+		class Foo
+		  def self.bar(x)
+		    x * 2
+		  end
+		end
+
+		class Bar < Foo
+		  def self.bar(x)
+		    super + 1
+		  end
+		end
+
+		Bar.bar(3)
+		`, 7},
+		{`
+		class Foo
+		  def initialize(x)
+		    @x = x
+		  end
+
+		  def x
+		    @x
+		  end
+		end
+
+		class Bar < Foo
+		  def initialize(x)
+		    super(x + 1)
+		  end
+		end
+
+		Bar.new(1).x
+		`, 2},
+		{`
+		class Foo
+		  def initialize
+		    super
+		    @x = 1
+		  end
+
+		  def x
+		    @x
+		  end
+		end
+
+		Foo.new.x
+		`, 1},
+		{`
+		class Foo
+		  def to_s
+		    "Foo " + super
+		  end
+		end
+
+		Foo.new.to_s
+		`, "Foo <Instance of: Foo>"},
+		{`
+		class Foo
+		  def bar
+		    1
+		  end
+		end
+
+		class Bar < Foo
+		  def bar
+		    super + 1
+		  end
+		end
+
+		class Baz < Bar
+		  def bar
+		    super + 1
+		  end
+		end
+
+		Baz.new.bar
+		`, 3},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestSuperEvaluationFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`
+		class Foo
+		  def bar
+		    super
+		  end
+		end
+
+		Foo.new.bar
+		`, "UndefinedMethodError: Undefined super method 'bar' for <Instance of: Foo>", 4, 2},
+		{`super`, "InternalError: Can't call super outside of a method", 1, 1},
+		{`
+		class Foo
+		  def bar(x)
+		    x
+		  end
+		end
+
+		class Bar < Foo
+		  def bar(x)
+		    super(x, 1)
+		  end
+		end
+
+		Bar.new.bar(1)
+		`, "ArgumentError: Expect at most 1 args for method 'bar'. got: 2", 10, 2},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, tt.expectedCFP)
+		v.checkSP(t, i, 1)
+	}
+}
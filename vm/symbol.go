@@ -0,0 +1,178 @@
+package vm
+
+import (
+	"strconv"
+
+	"github.com/goby-lang/goby/vm/classes"
+	"github.com/goby-lang/goby/vm/errors"
+)
+
+// SymbolObject represents a name like `:foo`.
+// Symbols are interned, which means the same name always refers to the same object.
+//
+// ```ruby
+// :foo.object_id == :foo.object_id # => true
+// :foo == "foo"                    # => false
+// "foo".to_sym == :foo             # => true
+// ```
+//
+// - `Symbol.new` is not supported.
+type SymbolObject struct {
+	*baseObj
+	value string
+}
+
+// Class methods --------------------------------------------------------
+func builtinSymbolClassMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			Name: "new",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					return t.initUnsupportedMethodError(sourceLine, "#new", receiver)
+				}
+			},
+		},
+	}
+}
+
+// Instance methods -----------------------------------------------------
+func builtinSymbolInstanceMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Returns the hash value of the symbol, which is the same for symbols that have the same name.
+			//
+			// ```ruby
+			// :a.hash == :a.hash # => true
+			// ```
+			//
+			// @return [Integer]
+			Name: "hash",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					return t.hashMethodResult(receiver, sourceLine)
+				}
+			},
+		},
+		{
+			// Returns the symbol's name as a string.
+			//
+			// ```ruby
+			// :foo.to_s # => "foo"
+			// ```
+			//
+			// @return [String]
+			Name: "to_s",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					return t.vm.initStringObject(receiver.(*SymbolObject).value)
+				}
+			},
+		},
+		{
+			// Returns a lambda that calls the method of the symbol's name on its argument.
+			// It's what `&:name` does when passing a symbol as a block.
+			//
+			// ```ruby
+			// :upcase.to_proc.call("foo") # => "FOO"
+			// ["a", "b"].map(&:upcase)    # => ["A", "B"]
+			// ```
+			//
+			// @return [Proc]
+			Name: "to_proc",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					return t.vm.initSymbolProcObject(receiver.(*SymbolObject), t.callFrameStack.top().FileName(), sourceLine)
+				}
+			},
+		},
+		{
+			// Returns the symbol itself.
+			//
+			// ```ruby
+			// :foo.to_sym # => :foo
+			// ```
+			//
+			// @return [Symbol]
+			Name: "to_sym",
+			Fn: func(receiver Object, sourceLine int) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *normalCallFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, sourceLine, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					return receiver
+				}
+			},
+		},
+	}
+}
+
+// Internal functions ===================================================
+
+// Functions for initialization -----------------------------------------
+
+// initSymbolObject returns the symbol of the given name, the symbol is created only when it's used for the first time
+func (vm *VM) initSymbolObject(value string) *SymbolObject {
+	vm.symbolTableMutex.Lock()
+	defer vm.symbolTableMutex.Unlock()
+
+	if s, ok := vm.symbolTable[value]; ok {
+		return s
+	}
+
+	s := &SymbolObject{
+		baseObj: &baseObj{class: vm.topLevelClass(classes.SymbolClass)},
+		value:   value,
+	}
+	vm.symbolTable[value] = s
+
+	return s
+}
+
+func (vm *VM) initSymbolClass() *RClass {
+	sc := vm.initializeClass(classes.SymbolClass, false)
+	sc.setBuiltinMethods(builtinSymbolInstanceMethods(), false)
+	sc.setBuiltinMethods(builtinSymbolClassMethods(), true)
+	return sc
+}
+
+// Polymorphic helper functions -----------------------------------------
+
+// Value returns the object
+func (s *SymbolObject) Value() interface{} {
+	return s.value
+}
+
+// toString returns the symbol's name, the leading colon is only added when the symbol is inspected, see inspectString
+func (s *SymbolObject) toString() string {
+	return s.value
+}
+
+// toJSON returns the symbol's name as a JSON string
+func (s *SymbolObject) toJSON() string {
+	return strconv.Quote(s.value)
+}
+
+// Other helper functions -----------------------------------------------
+
+// symbolOrStringValue returns the name of a symbol or the value of a string, it's for the arguments that accept both.
+// The second value is false if the object is neither a symbol nor a string.
+func symbolOrStringValue(obj Object) (string, bool) {
+	switch obj := obj.(type) {
+	case *SymbolObject:
+		return obj.value, true
+	case *StringObject:
+		return obj.value, true
+	}
+
+	return "", false
+}
@@ -0,0 +1,156 @@
+package vm
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestSymbolEvaluation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`:foo.class.name`, "Symbol"},
+		{`:foo.to_s`, "foo"},
+		{`:empty?.to_s`, "empty?"},
+		{`:foo.to_sym.to_s`, "foo"},
+		{`:foo.object_id == :foo.object_id`, true},
+		{`"foo".to_sym.object_id == :foo.object_id`, true},
+		{`"foo".to_sym == :foo`, true},
+		{`:foo == :foo`, true},
+		{`:foo == :bar`, false},
+		{`:foo == "foo"`, false},
+		{`:foo != "foo"`, true},
+		{`[:foo, :bar].to_s`, "[:foo, :bar]"},
+		{`[[:foo], { a: :bar }].to_s`, "[[:foo], { a: :bar }]"},
+		{`[:a, :b].join(",")`, "a,b"},
+		{`"#{:foo}"`, "foo"},
+		{`format("%s %p", :foo, :foo)`, "foo :foo"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestSymbolPuts(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stdout := os.Stdout
+	os.Stdout = w
+
+	v := initTestVM()
+	v.testEval(t, `puts(:foo, [:a, :b].join(","))`, getFilename())
+
+	os.Stdout = stdout
+	w.Close()
+
+	out, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(out) != "foo\na,b\n" {
+		t.Fatalf("Expect puts to print symbols without colons. got: %q", out)
+	}
+}
+
+func TestSymbolAsName(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		class Foo
+		  attr_accessor :bar, "baz"
+		end
+
+		f = Foo.new
+		f.bar = 10
+		f.baz = 5
+		f.bar + f.baz
+		`, 15},
+		{`
+		class Foo
+		  def bar(x)
+		    x * 2
+		  end
+		end
+
+		Foo.new.send(:bar, 5)
+		`, 10},
+		{`
+		class Foo
+		  def initialize
+		    @bar = 1
+		  end
+		end
+
+		f = Foo.new
+		f.instance_variable_set(:@baz, 2)
+		f.instance_variable_get(:@bar) + f.instance_variable_get("@baz")
+		`, 3},
+		{`1.respond_to?(:to_s)`, true},
+		{`1.respond_to?("to_s")`, true},
+		{`1.respond_to?(:foo)`, false},
+		{`{ foo: 1 }[:foo]`, 1},
+		{`{ foo: 1 }["foo"]`, nil},
+		{`{ "a" => 1, :a => 2 }.length`, 2},
+		{`{ foo: 1 }.keys.first.class.name`, "Symbol"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestSymbolMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`Symbol.new`, "UnsupportedMethodError: Unsupported Method #new for Symbol", 1, 1},
+		{`:foo.to_s(1)`, "ArgumentError: Expect 0 arguments. got: 1", 1, 1},
+		{`1.respond_to?(1)`, "TypeError: Expect argument to be Symbol or String. got: Integer", 1, 1},
+		{`1.send(1)`, "TypeError: Expect argument to be Symbol or String. got: Integer", 1, 1},
+		{`
+		class Foo
+		  attr_reader 1
+		end`, "TypeError: Expect argument to be Symbol or String. got: Integer", 3, 2},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, tt.expectedCFP)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestSymbolHashMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`:a.hash == :a.hash`, true},
+		{`:a.hash == :b.hash`, false},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
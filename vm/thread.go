@@ -45,10 +45,9 @@ func (t *thread) startFromTopFrame() {
 func (t *thread) evalCallFrame(cf callFrame) {
 	switch cf := cf.(type) {
 	case *normalCallFrame:
+		// Execution restarts from the rescue handler after an error is rescued
 		for cf.pc < cf.instructionsCount() {
-			i := cf.instructionSet.instructions[cf.pc]
-			t.execInstruction(cf, i)
-			if t.hasError() {
+			if t.execInstructions(cf) {
 				return
 			}
 		}
@@ -72,6 +71,137 @@ func (t *thread) evalCallFrame(cf callFrame) {
 	t.removeUselessBlockFrame(cf)
 }
 
+// raisedError is used for unwinding the go stack to the frame that has a rescue handler for the error
+type raisedError struct {
+	frame *normalCallFrame
+	err   *Error
+}
+
+// blockReturn is used for unwinding the go stack to the frame that a `return` in a block returns from
+type blockReturn struct {
+	frame *normalCallFrame
+	value Object
+}
+
+// execInstructions executes the frame's instructions until it stops or an error is raised.
+// It returns true if the raised error isn't rescued.
+func (t *thread) execInstructions(cf *normalCallFrame) (hasError bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch r := r.(type) {
+			case *raisedError:
+				if r.frame == cf {
+					t.rescueError(cf, r.err)
+					return
+				}
+			case *blockReturn:
+				if h := cf.ensureHandler(); h != nil {
+					t.runEnsure(cf, h, r)
+					return
+				}
+
+				if r.frame == cf {
+					t.returnFromFrame(cf, r.value)
+					return
+				}
+			}
+
+			panic(r)
+		}
+	}()
+
+	for cf.pc < cf.instructionsCount() {
+		i := cf.instructionSet.instructions[cf.pc]
+		t.execInstruction(cf, i)
+		if t.hasError() {
+			return true
+		}
+	}
+
+	return
+}
+
+// rescueError restores the thread to the state when the frame's available rescue handler was set, and jumps to the handler with the error on the stack top
+func (t *thread) rescueError(cf *normalCallFrame, err *Error) {
+	i := len(cf.rescueHandlers) - 1
+
+	for !cf.rescueHandlers[i].available() {
+		i--
+	}
+
+	h := cf.rescueHandlers[i]
+	h.err = err
+	// Handlers set after the available one belong to the code that has been stopped
+	cf.rescueHandlers = cf.rescueHandlers[:i+1]
+
+	for t.cfp > h.cfp {
+		t.callFrameStack.pop()
+	}
+
+	t.sp = h.sp
+	err.raised = false
+	t.stack.push(&Pointer{Target: err})
+	cf.pc = h.pc
+}
+
+// runEnsure restores the thread to the state when the ensure handler was set, and jumps to the ensure body.
+// The `throw` at the end of the body continues the return since there's no error on the stack top.
+func (t *thread) runEnsure(cf *normalCallFrame, h *rescueHandler, r *blockReturn) {
+	h.blockReturn = r
+
+	for i, handler := range cf.rescueHandlers {
+		if handler == h {
+			cf.rescueHandlers = cf.rescueHandlers[:i+1]
+			break
+		}
+	}
+
+	for t.cfp > h.cfp {
+		t.callFrameStack.pop()
+	}
+
+	t.sp = h.sp
+	t.stack.push(&Pointer{Target: NULL})
+	cf.pc = h.pc
+}
+
+// returnFromFrame pops the frame and the frames called from it, and leaves the value on the stack top as the frame's result
+func (t *thread) returnFromFrame(cf *normalCallFrame, value Object) {
+	for t.callFrameStack.top() != callFrame(cf) {
+		t.callFrameStack.pop()
+	}
+
+	t.callFrameStack.pop()
+	cf.stopExecution()
+	t.stack.push(&Pointer{Target: value})
+}
+
+// returnTarget returns the frame that a `return` in the block returns from, which is the method where the block is defined.
+// A lambda's block frame is returned instead if the block is in a lambda, and it returns nil if the method has already returned.
+func (t *thread) returnTarget(cf *normalCallFrame) *normalCallFrame {
+	frame := cf
+
+	for frame.instructionSet.kind == bytecode.Block && !(frame.blockFrame != nil && frame.blockFrame.isLambda) {
+		frame = frame.ep
+
+		if frame == nil {
+			return nil
+		}
+	}
+
+	if frame.instructionSet.kind != bytecode.Block && frame.instructionSet.kind != bytecode.MethodDef {
+		return nil
+	}
+
+	for i := t.cfp - 1; i >= 0; i-- {
+		if t.callFrameStack.callFrames[i] == callFrame(frame) {
+			return frame
+		}
+	}
+
+	return nil
+}
+
 /*
 	Remove top frame if it's a block frame
 
@@ -93,17 +223,93 @@ func (t *thread) removeUselessBlockFrame(frame callFrame) {
 func (t *thread) hasError() (hasError bool) {
 	if t.stack.top() != nil {
 		top := t.stack.top().Target
-		err, hasError := top.(*Error)
+		err, ok := top.(*Error)
+
+		if ok && err.raised {
+			if frame := t.findRescueFrame(); frame != nil {
+				panic(&raisedError{frame: frame, err: err})
+			}
 
-		if hasError {
 			t.reportErrorAndStop(err)
+			return true
 		}
-		return hasError
 	}
 
 	return
 }
 
+// findRescueFrame returns the innermost frame that has an available rescue handler
+func (t *thread) findRescueFrame() *normalCallFrame {
+	for i := t.cfp - 1; i >= 0; i-- {
+		if cf, ok := t.callFrameStack.callFrames[i].(*normalCallFrame); ok {
+			for _, h := range cf.rescueHandlers {
+				if h.available() {
+					return cf
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// rescuedError returns the error being rescued by the innermost rescue clause
+func (t *thread) rescuedError() *Error {
+	for i := t.cfp - 1; i >= 0; i-- {
+		if cf, ok := t.callFrameStack.callFrames[i].(*normalCallFrame); ok {
+			for j := len(cf.rescueHandlers) - 1; j >= 0; j-- {
+				if err := cf.rescueHandlers[j].err; err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// backtrace returns the places of the executing call frames, the innermost one comes first
+func (t *thread) backtrace(sourceLine int) []string {
+	bt := []string{}
+
+	for i := t.cfp - 1; i >= 0; i-- {
+		var label string
+
+		switch cf := t.callFrameStack.callFrames[i].(type) {
+		case *normalCallFrame:
+			// Block frames that haven't been executed only hold the block for method calls, the frames that run the block are kept
+			if cf.IsBlock() && cf.pc == 0 {
+				continue
+			}
+
+			if i != t.cfp-1 && cf.pc > 0 {
+				sourceLine = cf.instructionSet.instructions[cf.pc-1].sourceLine
+			}
+
+			switch cf.instructionSet.kind {
+			case bytecode.Program:
+				label = "<main>"
+			case bytecode.Block:
+				label = "block"
+			case bytecode.ClassDef:
+				label = fmt.Sprintf("<class:%s>", cf.instructionSet.name)
+			default:
+				label = cf.instructionSet.name
+			}
+		case *goMethodCallFrame:
+			if i != t.cfp-1 {
+				sourceLine = cf.SourceLine()
+			}
+
+			label = cf.name
+		}
+
+		bt = append(bt, fmt.Sprintf("%s:%d in %s", t.callFrameStack.callFrames[i].FileName(), sourceLine, label))
+	}
+
+	return bt
+}
+
 func (t *thread) reportErrorAndStop(err *Error) {
 	cf := t.callFrameStack.top()
 	cf.stopExecution()
@@ -131,6 +337,7 @@ func (t *thread) builtinMethodYield(blockFrame *normalCallFrame, args ...Object)
 	c.blockFrame = blockFrame
 	c.ep = blockFrame.ep
 	c.self = blockFrame.self
+	args = spreadBlockArguments(blockFrame.instructionSet, args)
 
 	for i := 0; i < len(args); i++ {
 		c.insertLCL(i, 0, args[i])
@@ -142,6 +349,50 @@ func (t *thread) builtinMethodYield(blockFrame *normalCallFrame, args ...Object)
 	return t.stack.top()
 }
 
+// spreadBlockArguments spreads a single array argument over the block's parameters when the block takes more than one,
+// so `[[1, 2]].each do |a, b|` and `hash.map do |key, value|` work like in Ruby
+func spreadBlockArguments(is *instructionSet, args []Object) []Object {
+	if len(args) != 1 || is.paramTypes == nil || len(is.paramTypes.Types()) < 2 {
+		return args
+	}
+
+	if arr, ok := args[0].(*ArrayObject); ok {
+		return arr.Elements
+	}
+
+	return args
+}
+
+// callMethod calls the receiver's method with the given arguments and returns the result.
+// It's for builtin methods that need to call methods which can be defined in Goby, like `hash` or `eql?`.
+func (t *thread) callMethod(receiver Object, methodName string, sourceLine int, args ...Object) Object {
+	method := receiver.findMethod(methodName)
+
+	if method == nil {
+		return t.vm.initErrorObject(errors.UndefinedMethodError, sourceLine, "Undefined Method '%+v' for %+v", methodName, receiver.toString())
+	}
+
+	receiverPr := t.sp
+	t.stack.push(&Pointer{Target: receiver})
+
+	for _, arg := range args {
+		t.stack.push(&Pointer{Target: arg})
+	}
+
+	switch m := method.(type) {
+	case *MethodObject:
+		callObj := newCallObject(receiver, m, receiverPr, len(args), &bytecode.ArgSet{}, nil, sourceLine)
+		t.evalMethodObject(callObj, sourceLine)
+	case *BuiltinMethodObject:
+		t.evalBuiltinMethod(receiver, m, receiverPr, len(args), &bytecode.ArgSet{}, nil, sourceLine, t.callFrameStack.top().FileName())
+	}
+
+	result := t.stack.Data[receiverPr].Target
+	t.sp = receiverPr
+
+	return result
+}
+
 func (t *thread) retrieveBlock(fileName, blockFlag string) (blockFrame *normalCallFrame) {
 	var blockName string
 	var hasBlock bool
@@ -162,6 +413,126 @@ func (t *thread) retrieveBlock(fileName, blockFlag string) (blockFrame *normalCa
 	return
 }
 
+// popBlockPass pops the object passed as a block like `foo(&blk)`, which is pushed after other arguments
+func (t *thread) popBlockPass(blockFlag string) Object {
+	if blockFlag == bytecode.BlockPassFlag {
+		return t.stack.pop().Target
+	}
+
+	return nil
+}
+
+// expandSplatArgument pushes the elements of the splat array on the stack top as arguments and returns the new argument count
+func (t *thread) expandSplatArgument(argCount int) int {
+	if arr, ok := t.stack.top().Target.(*ArrayObject); ok && arr.splat {
+		// Pop array
+		t.stack.pop()
+		// Can't count array itself, only the number of array elements
+		argCount = argCount - 1 + len(arr.Elements)
+		for _, elem := range arr.Elements {
+			t.stack.push(&Pointer{Target: elem})
+		}
+	}
+
+	return argCount
+}
+
+// findBlockFrame returns the block frame of a method call, either from the passed object or from the literal block
+func (t *thread) findBlockFrame(cf *normalCallFrame, blockFlag string, blockPass Object, sourceLine int) (*normalCallFrame, *Error) {
+	if blockPass != nil {
+		return t.blockFrameOf(blockPass, sourceLine)
+	}
+
+	blockFrame := t.retrieveBlock(cf.FileName(), blockFlag)
+
+	if blockFrame != nil {
+		blockFrame.ep = cf
+		blockFrame.self = cf.self
+	}
+
+	return blockFrame, nil
+}
+
+// setMethodMissingArguments rearranges the arguments on the stack for calling `method_missing`, which takes the method name as its first argument.
+// Keyword arguments are collected into a hash as the last argument. It returns the new argument count.
+func (t *thread) setMethodMissingArguments(methodName string, argPr, argCount int, argSet *bytecode.ArgSet, sourceLine int) int {
+	var argTypes []int
+	var keywords *HashObject
+
+	if argSet != nil {
+		argTypes = argSet.Types()
+	}
+
+	args := []Object{t.vm.initSymbolObject(methodName)}
+
+	for i := 0; i < argCount; i++ {
+		arg := t.stack.Data[argPr+i].Target
+
+		if i < len(argTypes) && (argTypes[i] == bytecode.RequiredKeywordArg || argTypes[i] == bytecode.OptionalKeywordArg) {
+			if keywords == nil {
+				keywords = t.vm.initEmptyHashObject()
+			}
+
+			keywords.set(t, t.vm.initSymbolObject(argSet.Names()[i]), arg, sourceLine)
+			continue
+		}
+
+		args = append(args, arg)
+	}
+
+	if keywords != nil {
+		args = append(args, keywords)
+	}
+
+	t.sp = argPr
+
+	for _, arg := range args {
+		t.stack.push(&Pointer{Target: arg})
+	}
+
+	return len(args)
+}
+
+// checkMethodVisibility returns an error if the method can't be called on the receiver from the given self.
+// Private methods can only be called on self, and protected methods can also be called from the instances of the method's class.
+func (t *thread) checkMethodVisibility(receiver, self, method Object, methodName string, sourceLine int) *Error {
+	switch methodVisibility(method) {
+	case privateMethod:
+		if receiver != self {
+			return t.vm.initErrorObject(errors.UndefinedMethodError, sourceLine, errors.PrivateMethodCallFormat, methodName, receiver.toString())
+		}
+	case protectedMethod:
+		if receiver == self {
+			return nil
+		}
+
+		owner := methodOwner(receiver, methodName, method)
+
+		for _, c := range methodLookupChainOf(self) {
+			if c == owner {
+				return nil
+			}
+		}
+
+		return t.vm.initErrorObject(errors.UndefinedMethodError, sourceLine, errors.ProtectedMethodCallFormat, methodName, receiver.toString())
+	}
+
+	return nil
+}
+
+// evalMethod evaluates the method with the arguments on the stack
+func (t *thread) evalMethod(receiver, method Object, receiverPr, argCount int, argSet *bytecode.ArgSet, blockFrame *normalCallFrame, sourceLine int, fileName string) {
+	switch m := method.(type) {
+	case *MethodObject:
+		callObj := newCallObject(receiver, m, receiverPr, argCount, argSet, blockFrame, sourceLine)
+		t.evalMethodObject(callObj, sourceLine)
+	case *BuiltinMethodObject:
+		t.evalBuiltinMethod(receiver, m, receiverPr, argCount, argSet, blockFrame, sourceLine, fileName)
+	case *Error:
+		t.pushErrorObject(errors.InternalError, sourceLine, "%s", m.toString())
+	}
+}
+
 func (t *thread) sendMethod(methodName string, argCount int, blockFrame *normalCallFrame, sourceLine int) {
 	var method Object
 
@@ -207,10 +578,8 @@ func (t *thread) sendMethod(methodName string, argCount int, blockFrame *normalC
 	method = receiver.findMethod(methodName)
 
 	if method == nil {
-		err := t.vm.initErrorObject(errors.UndefinedMethodError, sourceLine, "Undefined Method '%+v' for %+v", methodName, receiver.toString())
-		t.stack.set(receiverPr, &Pointer{Target: err})
-		t.sp = argPr
-		return
+		method = receiver.findMethod(methodMissing)
+		argCount = t.setMethodMissingArguments(methodName, argPr, argCount, nil, sourceLine)
 	}
 
 	sendCallFrame := t.callFrameStack.top()
@@ -222,7 +591,7 @@ func (t *thread) sendMethod(methodName string, argCount int, blockFrame *normalC
 	case *BuiltinMethodObject:
 		t.evalBuiltinMethod(receiver, m, receiverPr, argCount, &bytecode.ArgSet{}, blockFrame, sourceLine, sendCallFrame.FileName())
 	case *Error:
-		t.pushErrorObject(errors.InternalError, sourceLine, m.toString())
+		t.pushErrorObject(errors.InternalError, sourceLine, "%s", m.toString())
 	}
 }
 
@@ -242,10 +611,23 @@ func (t *thread) evalBuiltinMethod(receiver Object, method *BuiltinMethodObject,
 
 	_, ok := receiver.(*RClass)
 	if method.Name == "new" && ok {
-		instance, ok := evaluated.Target.(*RObject)
-		if ok && instance.InitializeMethod != nil {
-			callObj := newCallObject(instance, instance.InitializeMethod, receiverPtr, argCount, argSet, blockFrame, sourceLine)
+		var initMethod *MethodObject
+
+		switch instance := evaluated.Target.(type) {
+		case *RObject:
+			initMethod = instance.InitializeMethod
+		case *Error:
+			initMethod = instance.initializeMethod
+		}
+
+		if initMethod != nil {
+			callObj := newCallObject(evaluated.Target, initMethod, receiverPtr, argCount, argSet, blockFrame, sourceLine)
 			t.evalMethodObject(callObj, sourceLine)
+
+			// The errors of wrong arguments are left on the stack instead of the new object
+			if err, ok := t.stack.Data[receiverPtr].Target.(*Error); ok && err.raised {
+				return
+			}
 		}
 	}
 
@@ -274,6 +656,10 @@ func (t *thread) evalMethodObject(call *callObject, sourceLine int) {
 	paramsCount := len(call.paramTypes())
 	stack := t.stack.Data
 
+	if call.method.isBlockArgIncluded() {
+		paramsCount--
+	}
+
 	if call.argCount > paramsCount && !call.method.isSplatArgIncluded() {
 		t.reportArgumentError(sourceLine, paramsCount, call.methodName(), call.argCount, call.receiverPtr)
 		return
@@ -301,7 +687,7 @@ func (t *thread) evalMethodObject(call *callObject, sourceLine int) {
 	err := call.assignKeywordArguments(stack)
 
 	if err != nil {
-		e := t.vm.initErrorObject(errors.ArgumentError, sourceLine, err.Error())
+		e := t.vm.initErrorObject(errors.ArgumentError, sourceLine, "%s", err.Error())
 		t.stack.set(call.receiverPtr, &Pointer{Target: e})
 		t.sp = call.argPtr()
 		return
@@ -322,8 +708,16 @@ func (t *thread) evalMethodObject(call *callObject, sourceLine int) {
 		}
 	} else {
 		call.assignNormalArguments(stack)
+
+		// The splat parameter still needs to be an empty array without extra arguments
+		if call.method.isSplatArgIncluded() {
+			call.argIndex = call.argCount
+			call.assignSplatArgument(stack, t.vm.initArrayObject([]Object{}))
+		}
 	}
 
+	call.assignBlockArgument(t.vm)
+
 	t.callFrameStack.push(call.callFrame)
 	t.startFromTopFrame()
 
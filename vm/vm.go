@@ -47,6 +47,7 @@ var standardLibraries = map[string]func(*VM){
 	"json":              initJSONClass,
 	"concurrent/array":  initConcurrentArrayClass,
 	"concurrent/hash":   initConcurrentHashClass,
+	"decimal":           initDecimalClass,
 }
 
 // VM represents a stack based virtual machine.
@@ -73,6 +74,14 @@ type VM struct {
 
 	channelObjectMap *objectMap
 
+	// symbolTable holds all symbols, so each name has only one symbol object
+	symbolTable      map[string]*SymbolObject
+	symbolTableMutex sync.Mutex
+
+	// globalVariables holds variables like `$stdout`, which are shared by all threads
+	globalVariables      *environment
+	globalVariablesMutex sync.RWMutex
+
 	sync.Mutex
 
 	mode int
@@ -82,7 +91,7 @@ type VM struct {
 
 // New initializes a vm to initialize state and returns it.
 func New(fileDir string, args []string) (vm *VM, e error) {
-	vm = &VM{args: args}
+	vm = &VM{args: args, symbolTable: make(map[string]*SymbolObject)}
 	vm.mainThread = vm.newThread()
 
 	vm.methodISIndexTables = map[filename]*isIndexTable{
@@ -121,6 +130,7 @@ func New(fileDir string, args []string) (vm *VM, e error) {
 	}
 
 	vm.initConstants()
+	vm.initGlobalVariables()
 	vm.mainObj = vm.initMainObj()
 	vm.channelObjectMap = &objectMap{store: &sync.Map{}}
 
@@ -142,7 +152,15 @@ func (vm *VM) newThread() *thread {
 }
 
 // ExecInstructions accepts a sequence of bytecodes and use vm to evaluate them.
+// The file is the program being run, so its name is stored in `$0` and `$PROGRAM_NAME`.
 func (vm *VM) ExecInstructions(sets []*bytecode.InstructionSet, fn string) {
+	vm.setGlobalVariable("$0", vm.initStringObject(fn))
+	vm.setGlobalVariable("$PROGRAM_NAME", vm.initStringObject(fn))
+
+	vm.execInstructions(sets, fn)
+}
+
+func (vm *VM) execInstructions(sets []*bytecode.InstructionSet, fn string) {
 	translator := newInstructionTranslator(fn)
 	translator.vm = vm
 	translator.transferInstructionSets(sets)
@@ -205,17 +223,22 @@ func (vm *VM) initConstants() {
 	vm.objectClass = initObjectClass(cClass)
 	vm.topLevelClass(classes.ObjectClass).setClassConstant(cClass)
 
+	// Init builtin modules, they're written in Goby and loaded before the builtin classes' libraries that include them
+	vm.libFiles = append(vm.libFiles, "comparable.gb", "enumerable.gb")
+
 	// Init builtin classes
 	builtinClasses := []*RClass{
 		vm.initIntegerClass(),
 		vm.initFloatClass(),
 		vm.initStringClass(),
+		vm.initSymbolClass(),
 		vm.initBoolClass(),
 		vm.initNullClass(),
 		vm.initArrayClass(),
 		vm.initHashClass(),
 		vm.initRangeClass(),
 		vm.initMethodClass(),
+		vm.initProcClass(),
 		vm.initChannelClass(),
 		vm.initGoClass(),
 		vm.initFileClass(),
@@ -254,6 +277,27 @@ func (vm *VM) initConstants() {
 	vm.objectClass.constants["STDIN"] = &Pointer{Target: vm.initFileObject(os.Stdin)}
 }
 
+func (vm *VM) initGlobalVariables() {
+	vm.globalVariables = newEnvironment()
+	vm.globalVariables.set("$stdout", vm.objectClass.constants["STDOUT"].Target)
+	vm.globalVariables.set("$stderr", vm.objectClass.constants["STDERR"].Target)
+	vm.globalVariables.set("$stdin", vm.objectClass.constants["STDIN"].Target)
+}
+
+func (vm *VM) getGlobalVariable(name string) (Object, bool) {
+	vm.globalVariablesMutex.RLock()
+	defer vm.globalVariablesMutex.RUnlock()
+
+	return vm.globalVariables.get(name)
+}
+
+func (vm *VM) setGlobalVariable(name string, value Object) {
+	vm.globalVariablesMutex.Lock()
+	defer vm.globalVariablesMutex.Unlock()
+
+	vm.globalVariables.set(name, value)
+}
+
 func (vm *VM) topLevelClass(cn string) *RClass {
 	objClass := vm.objectClass
 
@@ -364,15 +408,49 @@ func (vm *VM) lookupConstant(cf callFrame, constName string) (constant *Pointer)
 	return
 }
 
+// libInstructionSets caches the compiled Goby libraries by their paths.
+// Every VM loads libraries like lib/enumerable.gb when it's created, so they're only parsed and compiled once per process.
+// It's safe to share them since bytecode isn't changed after compilation, each VM translates it into its own instruction sets.
+var libInstructionSets = struct {
+	sync.Mutex
+	sets map[string][]*bytecode.InstructionSet
+}{sets: make(map[string][]*bytecode.InstructionSet)}
+
 func (vm *VM) execGobyLib(libName string) {
 	libPath := filepath.Join(vm.projectRoot, "lib", libName)
+	instructionSets, ok := vm.compileGobyLib(libPath)
+
+	if ok {
+		vm.execRequiredInstructions(libPath, instructionSets)
+	}
+}
+
+// compileGobyLib returns the library's instruction sets from libInstructionSets, it compiles the library if it's not cached yet.
+// It returns false if the library can't be read or compiled.
+func (vm *VM) compileGobyLib(libPath string) ([]*bytecode.InstructionSet, bool) {
+	libInstructionSets.Lock()
+	defer libInstructionSets.Unlock()
+
+	if instructionSets, ok := libInstructionSets.sets[libPath]; ok {
+		return instructionSets, true
+	}
+
 	file, err := ioutil.ReadFile(libPath)
 
 	if err != nil {
-		vm.mainThread.pushErrorObject(errors.InternalError, -1, err.Error())
+		vm.mainThread.pushErrorObject(errors.InternalError, -1, "%s", err.Error())
+		return nil, false
+	}
+
+	instructionSets, err := compiler.CompileToInstructions(string(file), parser.NormalMode)
+
+	if err != nil {
+		fmt.Println(err.Error())
+		return nil, false
 	}
 
-	vm.execRequiredFile(libPath, file)
+	libInstructionSets.sets[libPath] = instructionSets
+	return instructionSets, true
 }
 
 func (vm *VM) execRequiredFile(filepath string, file []byte) {
@@ -383,6 +461,10 @@ func (vm *VM) execRequiredFile(filepath string, file []byte) {
 		return
 	}
 
+	vm.execRequiredInstructions(filepath, instructionSets)
+}
+
+func (vm *VM) execRequiredInstructions(filepath string, instructionSets []*bytecode.InstructionSet) {
 	oldMethodTable := isTable{}
 	oldClassTable := isTable{}
 
@@ -397,7 +479,7 @@ func (vm *VM) execRequiredFile(filepath string, file []byte) {
 
 	// This creates new execution environments for required file, including new instruction set table.
 	// So we need to copy old instruction sets and restore them later, otherwise current program's instruction set would be overwrite.
-	vm.ExecInstructions(instructionSets, filepath)
+	vm.execInstructions(instructionSets, filepath)
 
 	// Restore instruction sets.
 	vm.isTables[bytecode.MethodDef] = oldMethodTable
@@ -405,5 +487,5 @@ func (vm *VM) execRequiredFile(filepath string, file []byte) {
 }
 
 func newError(format string, args ...interface{}) *Error {
-	return &Error{Message: fmt.Sprintf(format, args...)}
+	return &Error{Message: fmt.Sprintf(format, args...), raised: true}
 }
@@ -6,7 +6,9 @@ import (
 	"github.com/goby-lang/goby/compiler/bytecode"
 	"github.com/goby-lang/goby/compiler/lexer"
 	"github.com/goby-lang/goby/compiler/parser"
+	"github.com/goby-lang/goby/vm/classes"
 	"os"
+	"path/filepath"
 	"runtime"
 	"testing"
 )
@@ -157,6 +159,32 @@ foo
 	}
 }
 
+func TestGobyLibInstructionSetsCache(t *testing.T) {
+	v1 := initTestVM()
+	v2 := initTestVM()
+	libPath := filepath.Join(v1.projectRoot, "lib", "enumerable.gb")
+
+	cached, ok := libInstructionSets.sets[libPath]
+
+	if !ok {
+		t.Fatalf("Expect %s to be cached", libPath)
+	}
+
+	sets, ok := v2.compileGobyLib(libPath)
+
+	if !ok || sets[0] != cached[0] {
+		t.Fatalf("Expect %s to be compiled only once", libPath)
+	}
+
+	// Each VM still translates the library into its own instruction sets
+	m1 := v1.topLevelClass(classes.EnumerableModule).lookupMethod("to_a").(*MethodObject)
+	m2 := v2.topLevelClass(classes.EnumerableModule).lookupMethod("to_a").(*MethodObject)
+
+	if m1.instructionSet == m2.instructionSet {
+		t.Fatalf("Expect VMs not to share the translated instruction sets of %s", libPath)
+	}
+}
+
 func initTestVM() *VM {
 	fn, err := os.Getwd()
 
@@ -336,7 +364,10 @@ func testConcurrentHashObject(t *testing.T, index int, objectResult Object, expe
 	pairs := make(map[string]Object)
 
 	iterator := func(key, value interface{}) bool {
-		pairs[key.(string)] = value.(Object)
+		for _, pair := range value.([]*hashPair) {
+			pairs[hashKeyString(pair.key)] = pair.value
+		}
+
 		return true
 	}
 
@@ -348,11 +379,9 @@ func testConcurrentHashObject(t *testing.T, index int, objectResult Object, expe
 // Tests a Hash Object, with a few limitations:
 //
 // - the tested hash must be shallow (no nested objects as values);
-// - the test hash must have strings as keys;
+// - the test hash must have strings or symbols as keys;
 // - the error message won't mention the key - only the value.
 //
-// The second limitation is currently the only Hash format in Goby, anyway.
-//
 func testHashObject(t *testing.T, index int, objectResult Object, expected map[string]interface{}) bool {
 	result, ok := objectResult.(*HashObject)
 
@@ -361,7 +390,7 @@ func testHashObject(t *testing.T, index int, objectResult Object, expected map[s
 		return false
 	}
 
-	return _checkHashPairs(t, result.Pairs, expected)
+	return _checkHashPairs(t, result.Value().(map[string]Object), expected)
 }
 
 // Testing API like testArrayObject(), but performed on bidimensional arrays.